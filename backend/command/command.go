@@ -1,13 +1,17 @@
 package command
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/migrations"
 	"github.com/Caknoooo/go-gin-clean-starter/script"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
 	"github.com/samber/do"
 	"gorm.io/gorm"
 )
@@ -20,6 +24,7 @@ func Commands(injector *do.Injector) bool {
 	migrate := false
 	seed := false
 	run := false
+	worker := false
 	scriptFlag := false
 
 	for _, arg := range os.Args[1:] {
@@ -32,6 +37,9 @@ func Commands(injector *do.Injector) bool {
 		if arg == "--run" {
 			run = true
 		}
+		if arg == "--worker" {
+			worker = true
+		}
 		if strings.HasPrefix(arg, "--script:") {
 			scriptFlag = true
 			scriptName = strings.TrimPrefix(arg, "--script:")
@@ -59,6 +67,15 @@ func Commands(injector *do.Injector) bool {
 		log.Println("script run successfully")
 	}
 
+	if worker {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		do.MustInvoke[service.JobWorker](injector).Run(ctx)
+		log.Println("worker stopped")
+		return false
+	}
+
 	if run {
 		return true
 	}
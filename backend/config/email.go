@@ -1,30 +1,64 @@
 package config
 
 import (
-	"github.com/spf13/viper"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	MAIL_DRIVER_SMTP   = "smtp"
+	MAIL_DRIVER_FILE   = "file"
+	MAIL_DRIVER_MEMORY = "memory"
 )
 
 type EmailConfig struct {
-	Host         string `mapstructure:"SMTP_HOST"`
-	Port         int    `mapstructure:"SMTP_PORT"`
-	SenderName   string `mapstructure:"SMTP_SENDER_NAME"`
-	AuthEmail    string `mapstructure:"SMTP_AUTH_EMAIL"`
-	AuthPassword string `mapstructure:"SMTP_AUTH_PASSWORD"`
+	// Driver picks the Mailer: smtp (default), file writes .eml files to
+	// Dir for local development, memory keeps messages for tests.
+	Driver       string
+	Dir          string
+	Host         string
+	Port         int
+	SenderName   string
+	AuthEmail    string
+	AuthPassword string
 }
 
+// NewEmailConfig reads the mail settings from the environment, which
+// SetUpDatabaseConnection has already populated from .env outside production.
 func NewEmailConfig() (*EmailConfig, error) {
-	viper.SetConfigFile(".env")
-
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, err
+	cfg := EmailConfig{
+		Driver:       os.Getenv("MAIL_DRIVER"),
+		Dir:          os.Getenv("MAIL_DIR"),
+		Host:         os.Getenv("SMTP_HOST"),
+		SenderName:   os.Getenv("SMTP_SENDER_NAME"),
+		AuthEmail:    os.Getenv("SMTP_AUTH_EMAIL"),
+		AuthPassword: os.Getenv("SMTP_AUTH_PASSWORD"),
 	}
 
-	viper.AutomaticEnv()
+	if cfg.Driver == "" {
+		cfg.Driver = MAIL_DRIVER_SMTP
+	}
+	if cfg.Dir == "" {
+		cfg.Dir = "./storage/mail"
+	}
 
-	var config EmailConfig
-	if err := viper.Unmarshal(&config); err != nil {
-		return nil, err
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT %q: %w", port, err)
+		}
+		cfg.Port = p
 	}
 
-	return &config, nil
-}
\ No newline at end of file
+	return &cfg, nil
+}
+
+// From is the sender address, falling back to the SMTP login when no
+// display sender is configured.
+func (c *EmailConfig) From() string {
+	if c.SenderName != "" {
+		return c.SenderName
+	}
+	return c.AuthEmail
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type JobConfig struct {
+	// InProcess runs a worker inside the API server. Turn it off when
+	// dedicated `--worker` processes are deployed.
+	InProcess bool
+	// Concurrency is how many jobs one worker process runs at a time.
+	Concurrency  int
+	PollInterval time.Duration
+	// LockTimeout is how long a job may stay running before it is assumed
+	// to belong to a crashed worker and is handed out again.
+	LockTimeout time.Duration
+}
+
+func NewJobConfig() JobConfig {
+	cfg := JobConfig{
+		InProcess:    true,
+		Concurrency:  4,
+		PollInterval: time.Second,
+		LockTimeout:  30 * time.Minute,
+	}
+
+	if v, err := strconv.ParseBool(os.Getenv("JOB_IN_PROCESS")); err == nil {
+		cfg.InProcess = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("JOB_CONCURRENCY")); err == nil && v > 0 {
+		cfg.Concurrency = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("JOB_POLL_INTERVAL")); err == nil && v > 0 {
+		cfg.PollInterval = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("JOB_LOCK_TIMEOUT")); err == nil && v > 0 {
+		cfg.LockTimeout = v
+	}
+
+	return cfg
+}
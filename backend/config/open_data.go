@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+type OpenDataConfig struct {
+	// Enabled schedules the daily snapshot.
+	Enabled bool
+	// GridDegrees is the cell size coordinates are snapped to.
+	GridDegrees float64
+	// K is the minimum number of reports of one class in one cell for
+	// those reports to be published.
+	K           int
+	IncludeText bool
+}
+
+func NewOpenDataConfig() OpenDataConfig {
+	cfg := OpenDataConfig{
+		GridDegrees: 0.01,
+		K:           5,
+		IncludeText: false,
+	}
+
+	if v, err := strconv.ParseBool(os.Getenv("OPEN_DATA_ENABLED")); err == nil {
+		cfg.Enabled = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("OPEN_DATA_GRID_DEGREES"), 64); err == nil && v > 0 {
+		cfg.GridDegrees = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("OPEN_DATA_K")); err == nil && v > 0 {
+		cfg.K = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("OPEN_DATA_INCLUDE_TEXT")); err == nil {
+		cfg.IncludeText = v
+	}
+
+	return cfg
+}
@@ -0,0 +1,36 @@
+package config
+
+import "os"
+
+const (
+	PUSH_DRIVER_FCM = "fcm"
+	PUSH_DRIVER_LOG = "log"
+)
+
+type PushConfig struct {
+	// Driver picks the PushSender: fcm, or log (default) which only prints
+	// what would have been sent.
+	Driver string
+	// ProjectID is the Firebase project, falling back to GCP_PROJECT_ID.
+	ProjectID string
+	// CredentialsFile is a service account key. Without it the application
+	// default credentials are used.
+	CredentialsFile string
+}
+
+func NewPushConfig() PushConfig {
+	cfg := PushConfig{
+		Driver:          os.Getenv("PUSH_DRIVER"),
+		ProjectID:       os.Getenv("FCM_PROJECT_ID"),
+		CredentialsFile: os.Getenv("FCM_CREDENTIALS_FILE"),
+	}
+
+	if cfg.Driver == "" {
+		cfg.Driver = PUSH_DRIVER_LOG
+	}
+	if cfg.ProjectID == "" {
+		cfg.ProjectID = os.Getenv("GCP_PROJECT_ID")
+	}
+
+	return cfg
+}
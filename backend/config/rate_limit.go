@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	RATE_LIMIT_BACKEND_MEMORY   = "memory"
+	RATE_LIMIT_BACKEND_POSTGRES = "postgres"
+
+	// Policy names
+	RATE_LIMIT_LOGIN                   = "login"
+	RATE_LIMIT_REGISTER                = "register"
+	RATE_LIMIT_SEND_VERIFICATION_EMAIL = "send_verification_email"
+	RATE_LIMIT_CREATE_REPORT           = "create_report"
+)
+
+type (
+	// RateLimitPolicy is a token bucket holding Limit tokens that refills
+	// completely over Period.
+	RateLimitPolicy struct {
+		Limit  int
+		Period time.Duration
+	}
+
+	RateLimitConfig struct {
+		Enabled bool
+		// Backend keeps the buckets in memory (default), or in postgres so
+		// the limits hold across replicas.
+		Backend  string
+		Policies map[string]RateLimitPolicy
+	}
+)
+
+func NewRateLimitConfig() RateLimitConfig {
+	cfg := RateLimitConfig{
+		Enabled: true,
+		Backend: RATE_LIMIT_BACKEND_MEMORY,
+		Policies: map[string]RateLimitPolicy{
+			RATE_LIMIT_LOGIN:                   {Limit: 5, Period: time.Minute},
+			RATE_LIMIT_REGISTER:                {Limit: 5, Period: time.Hour},
+			RATE_LIMIT_SEND_VERIFICATION_EMAIL: {Limit: 3, Period: 15 * time.Minute},
+			RATE_LIMIT_CREATE_REPORT:           {Limit: 30, Period: time.Hour},
+		},
+	}
+
+	if v, err := strconv.ParseBool(os.Getenv("RATE_LIMIT_ENABLED")); err == nil {
+		cfg.Enabled = v
+	}
+	if v := os.Getenv("RATE_LIMIT_BACKEND"); v == RATE_LIMIT_BACKEND_POSTGRES {
+		cfg.Backend = v
+	}
+	for name := range cfg.Policies {
+		if policy, ok := ParseRateLimitPolicy(os.Getenv("RATE_LIMIT_" + strings.ToUpper(name))); ok {
+			cfg.Policies[name] = policy
+		}
+	}
+
+	return cfg
+}
+
+// ParseRateLimitPolicy reads a policy written as "<limit>/<period>", e.g.
+// "5/1m" for five requests a minute.
+func ParseRateLimitPolicy(s string) (RateLimitPolicy, bool) {
+	limit, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return RateLimitPolicy{}, false
+	}
+
+	l, err := strconv.Atoi(limit)
+	if err != nil || l <= 0 {
+		return RateLimitPolicy{}, false
+	}
+	p, err := time.ParseDuration(period)
+	if err != nil || p <= 0 {
+		return RateLimitPolicy{}, false
+	}
+
+	return RateLimitPolicy{Limit: l, Period: p}, true
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+type SchedulerConfig struct {
+	Enabled bool
+}
+
+func NewSchedulerConfig() SchedulerConfig {
+	cfg := SchedulerConfig{
+		Enabled: true,
+	}
+
+	if v, err := strconv.ParseBool(os.Getenv("SCHEDULER_ENABLED")); err == nil {
+		cfg.Enabled = v
+	}
+
+	return cfg
+}
+
+// TaskSchedule returns the cron expression for a scheduler task, which can
+// be overridden with SCHEDULE_<TASK_NAME>, e.g. SCHEDULE_OPEN_DATA_SNAPSHOT.
+func TaskSchedule(name string, fallback string) string {
+	if v := os.Getenv("SCHEDULE_" + strings.ToUpper(name)); v != "" {
+		return v
+	}
+	return fallback
+}
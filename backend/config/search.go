@@ -0,0 +1,18 @@
+package config
+
+import "os"
+
+const (
+	SEARCH_CONFIG_SIMPLE     = "simple"
+	SEARCH_CONFIG_INDONESIAN = "indonesian"
+)
+
+// ReportSearchConfig returns the Postgres text search configuration used
+// for the reports search_vector column. Only known configurations are
+// accepted because the value ends up inside DDL.
+func ReportSearchConfig() string {
+	if os.Getenv("REPORT_SEARCH_CONFIG") == SEARCH_CONFIG_INDONESIAN {
+		return SEARCH_CONFIG_INDONESIAN
+	}
+	return SEARCH_CONFIG_SIMPLE
+}
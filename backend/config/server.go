@@ -0,0 +1,21 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// TrustedProxies returns the proxies, as IPs or CIDRs from the comma
+// separated TRUSTED_PROXIES, whose X-Forwarded-For and X-Real-IP headers
+// are believed when working out the client IP. None are trusted by
+// default, so the client IP is the address of the connection and cannot
+// be picked by the client.
+func TrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type VerificationConfig struct {
+	// Quorum is the total vote weight at which the community decides on
+	// an unverified report.
+	Quorum float64
+	// ConfirmRatio is the share of that weight that must confirm for the
+	// report to be verified; otherwise it is flagged for an admin.
+	ConfirmRatio float64
+	// FullWeightAge is the account age at which a voter's weight stops
+	// growing.
+	FullWeightAge time.Duration
+}
+
+func NewVerificationConfig() VerificationConfig {
+	cfg := VerificationConfig{
+		Quorum:        5,
+		ConfirmRatio:  0.7,
+		FullWeightAge: 180 * 24 * time.Hour,
+	}
+
+	if v, err := strconv.ParseFloat(os.Getenv("VERIFICATION_QUORUM"), 64); err == nil && v > 0 {
+		cfg.Quorum = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("VERIFICATION_CONFIRM_RATIO"), 64); err == nil && v > 0 && v <= 1 {
+		cfg.ConfirmRatio = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("VERIFICATION_FULL_WEIGHT_AGE")); err == nil && v > 0 {
+		cfg.FullWeightAge = v
+	}
+
+	return cfg
+}
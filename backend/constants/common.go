@@ -3,6 +3,7 @@ package constants
 const (
 	ENUM_ROLE_ADMIN = "admin"
 	ENUM_ROLE_USER = "user"
+	ENUM_ROLE_OFFICER = "officer"
 
 	ENUM_RUN_PRODUCTION = "production"
 	ENUM_RUN_TESTING = "testing"
@@ -10,6 +11,13 @@ const (
 	ENUM_PAGINATION_PER_PAGE = 10
 	ENUM_PAGINATION_PAGE = 1
 
+	TIMEZONE = "Asia/Jakarta"
+
 	DB = "db"
 	JWTService = "JWTService"
+	EventBroker = "EventBroker"
+	Mailer = "Mailer"
+	PushSender = "PushSender"
+	JobHandler = "JobHandler:"
+	ScheduledTask = "ScheduledTask:"
 )
\ No newline at end of file
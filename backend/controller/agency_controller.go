@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	AgencyController interface {
+		CreateAgency(ctx *gin.Context)
+		GetAgencies(ctx *gin.Context)
+		DeleteAgency(ctx *gin.Context)
+		AddMember(ctx *gin.Context)
+		RemoveMember(ctx *gin.Context)
+		GetRoutes(ctx *gin.Context)
+		SaveRoute(ctx *gin.Context)
+		DeleteRoute(ctx *gin.Context)
+	}
+
+	agencyController struct {
+		agencyService service.AgencyService
+	}
+)
+
+func NewAgencyController(as service.AgencyService) AgencyController {
+	return &agencyController{
+		agencyService: as,
+	}
+}
+
+func (c *agencyController) CreateAgency(ctx *gin.Context) {
+	var req dto.CreateAgencyRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.agencyService.CreateAgency(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_AGENCY, err.Error(), nil)
+		ctx.JSON(agencyErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_AGENCY, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *agencyController) GetAgencies(ctx *gin.Context) {
+	result, err := c.agencyService.GetAgencies(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_AGENCIES, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_AGENCIES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *agencyController) DeleteAgency(ctx *gin.Context) {
+	if err := c.agencyService.DeleteAgency(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_AGENCY, err.Error(), nil)
+		ctx.JSON(agencyErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_AGENCY, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *agencyController) AddMember(ctx *gin.Context) {
+	var req dto.AgencyMemberRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.agencyService.AddMember(ctx.Request.Context(), ctx.Param("id"), ctx.Param("user_id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ADD_AGENCY_MEMBER, err.Error(), nil)
+		ctx.JSON(agencyErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ADD_AGENCY_MEMBER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *agencyController) RemoveMember(ctx *gin.Context) {
+	if err := c.agencyService.RemoveMember(ctx.Request.Context(), ctx.Param("id"), ctx.Param("user_id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REMOVE_AGENCY_MEMBER, err.Error(), nil)
+		ctx.JSON(agencyErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REMOVE_AGENCY_MEMBER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *agencyController) GetRoutes(ctx *gin.Context) {
+	result, err := c.agencyService.GetRoutes(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_AGENCY_ROUTES, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_AGENCY_ROUTES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *agencyController) SaveRoute(ctx *gin.Context) {
+	var req dto.SaveAgencyRouteRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.agencyService.SaveRoute(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SAVE_AGENCY_ROUTE, err.Error(), nil)
+		ctx.JSON(agencyErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SAVE_AGENCY_ROUTE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *agencyController) DeleteRoute(ctx *gin.Context) {
+	if err := c.agencyService.DeleteRoute(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_AGENCY_ROUTE, err.Error(), nil)
+		ctx.JSON(agencyErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_AGENCY_ROUTE, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func agencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrAgencyNotFound),
+		errors.Is(err, dto.ErrAgencyMemberNotFound),
+		errors.Is(err, dto.ErrAgencyRouteNotFound),
+		errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrAgencyNameExists):
+		return http.StatusConflict
+	case errors.Is(err, dto.ErrAssigneeNotOfficer):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
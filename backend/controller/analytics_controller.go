@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	AnalyticsController interface {
+		GetReportAnalytics(ctx *gin.Context)
+		GetSatisfaction(ctx *gin.Context)
+	}
+
+	analyticsController struct {
+		analyticsService service.AnalyticsService
+	}
+)
+
+func NewAnalyticsController(as service.AnalyticsService) AnalyticsController {
+	return &analyticsController{
+		analyticsService: as,
+	}
+}
+
+func (c *analyticsController) GetReportAnalytics(ctx *gin.Context) {
+	var req dto.ReportAnalyticsRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.analyticsService.GetReportAnalytics(ctx.Request.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, dto.ErrGetAnalytics) {
+			status = http.StatusInternalServerError
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ANALYTICS, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_ANALYTICS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *analyticsController) GetSatisfaction(ctx *gin.Context) {
+	var req dto.SatisfactionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.analyticsService.GetSatisfaction(ctx.Request.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, dto.ErrGetSatisfaction) {
+			status = http.StatusInternalServerError
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SATISFACTION, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SATISFACTION, result)
+	ctx.JSON(http.StatusOK, res)
+}
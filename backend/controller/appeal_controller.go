@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	// AppealController lets reporters file and read the appeal of their
+	// report, and admins work through the appeal queue.
+	AppealController interface {
+		FileAppeal(ctx *gin.Context)
+		GetAppeal(ctx *gin.Context)
+		GetAppeals(ctx *gin.Context)
+		AcceptAppeal(ctx *gin.Context)
+		UpholdAppeal(ctx *gin.Context)
+	}
+
+	appealController struct {
+		appealService service.AppealService
+	}
+)
+
+func NewAppealController(as service.AppealService) AppealController {
+	return &appealController{
+		appealService: as,
+	}
+}
+
+func (c *appealController) FileAppeal(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.FileAppealRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	result, err := c.appealService.FileAppeal(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_FILE_APPEAL, err.Error(), nil)
+		ctx.JSON(appealErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FILE_APPEAL, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *appealController) GetAppeal(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	result, err := c.appealService.GetAppeal(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_APPEAL, err.Error(), nil)
+		ctx.JSON(appealErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_APPEAL, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *appealController) GetAppeals(ctx *gin.Context) {
+	var req dto.AppealListRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.appealService.GetAppeals(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_APPEALS, err.Error(), nil)
+		ctx.JSON(appealErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_APPEALS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *appealController) AcceptAppeal(ctx *gin.Context) {
+	c.review(ctx, true)
+}
+
+func (c *appealController) UpholdAppeal(ctx *gin.Context) {
+	c.review(ctx, false)
+}
+
+func (c *appealController) review(ctx *gin.Context, accept bool) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.ReviewAppealRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.appealService.ReviewAppeal(ctx.Request.Context(), ctx.Param("id"), accept, req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REVIEW_APPEAL, err.Error(), nil)
+		ctx.JSON(appealErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REVIEW_APPEAL, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func appealErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById),
+		errors.Is(err, dto.ErrAppealNotFound),
+		errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrNotReportReporter):
+		return http.StatusForbidden
+	case errors.Is(err, dto.ErrAppealExists),
+		errors.Is(err, dto.ErrAppealNotPending),
+		errors.Is(err, dto.ErrReportNotRejected):
+		return http.StatusConflict
+	case errors.Is(err, dto.ErrAppealEmpty),
+		errors.Is(err, dto.ErrTooManyAppealImages),
+		errors.Is(err, dto.ErrInvalidAppealStatus),
+		errors.Is(err, dto.ErrInvalidImageExtension),
+		errors.Is(err, dto.ErrEmptyFileUploaded),
+		errors.Is(err, dto.ErrImageSizeTooLarge):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
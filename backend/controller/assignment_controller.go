@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	AssignmentController interface {
+		AssignReport(ctx *gin.Context)
+		UnassignReport(ctx *gin.Context)
+		GetMyAssignments(ctx *gin.Context)
+		RouteReport(ctx *gin.Context)
+	}
+
+	assignmentController struct {
+		assignmentService service.AssignmentService
+	}
+)
+
+func NewAssignmentController(as service.AssignmentService) AssignmentController {
+	return &assignmentController{
+		assignmentService: as,
+	}
+}
+
+func (c *assignmentController) AssignReport(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.AssignReportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.assignmentService.AssignReport(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ASSIGN_REPORT, err.Error(), nil)
+		ctx.JSON(assignmentErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ASSIGN_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *assignmentController) UnassignReport(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.assignmentService.UnassignReport(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNASSIGN_REPORT, err.Error(), nil)
+		ctx.JSON(assignmentErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNASSIGN_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *assignmentController) GetMyAssignments(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.ReportFilterRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.assignmentService.GetMyAssignments(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ASSIGNMENTS, err.Error(), nil)
+		ctx.JSON(reportListErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_ASSIGNMENTS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func assignmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById), errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrReportNotAssignable), errors.Is(err, dto.ErrReportNotAssigned):
+		return http.StatusConflict
+	case errors.Is(err, dto.ErrAssignReport):
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+func (c *assignmentController) RouteReport(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.RouteReportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.assignmentService.RouteReport(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, dto.ErrGetReportById) || errors.Is(err, dto.ErrAgencyNotFound) {
+			status = http.StatusNotFound
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ROUTE_REPORT, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ROUTE_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	CommentController interface {
+		CreateComment(ctx *gin.Context)
+		GetComments(ctx *gin.Context)
+		UpdateComment(ctx *gin.Context)
+		DeleteComment(ctx *gin.Context)
+		ModerateComment(ctx *gin.Context)
+	}
+
+	commentController struct {
+		commentService service.CommentService
+	}
+)
+
+func NewCommentController(cs service.CommentService) CommentController {
+	return &commentController{
+		commentService: cs,
+	}
+}
+
+func (c *commentController) CreateComment(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.CreateCommentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.commentService.CreateComment(ctx.Request.Context(), ctx.Param("id"), req, userId, ctx.GetStringSlice("agency_ids"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_COMMENT, err.Error(), nil)
+		ctx.JSON(commentErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_COMMENT, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *commentController) GetComments(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.commentService.GetComments(ctx.Request.Context(), ctx.Param("id"), req, userId, ctx.GetStringSlice("agency_ids"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_COMMENTS, err.Error(), nil)
+		ctx.JSON(commentErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_COMMENTS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *commentController) UpdateComment(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.UpdateCommentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.commentService.UpdateComment(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_COMMENT, err.Error(), nil)
+		ctx.JSON(commentErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_COMMENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *commentController) DeleteComment(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	if err := c.commentService.DeleteComment(ctx.Request.Context(), ctx.Param("id"), userId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_COMMENT, err.Error(), nil)
+		ctx.JSON(commentErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_COMMENT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *commentController) ModerateComment(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.ModerateCommentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.commentService.ModerateComment(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_MODERATE_COMMENT, err.Error(), nil)
+		ctx.JSON(commentErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_MODERATE_COMMENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById),
+		errors.Is(err, dto.ErrCommentNotFound),
+		errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrCommentForbidden),
+		errors.Is(err, dto.ErrInternalCommentDenied):
+		return http.StatusForbidden
+	case errors.Is(err, dto.ErrCommentEmpty),
+		errors.Is(err, dto.ErrInvalidVisibility),
+		errors.Is(err, dto.ErrCommentParentMismatch),
+		errors.Is(err, dto.ErrCommentVisibilityReply):
+		return http.StatusBadRequest
+	case errors.Is(err, dto.ErrCommentDeleted):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	DeviceController interface {
+		RegisterDevice(ctx *gin.Context)
+		UnregisterDevice(ctx *gin.Context)
+		GetDevices(ctx *gin.Context)
+	}
+
+	deviceController struct {
+		deviceService service.DeviceService
+	}
+)
+
+func NewDeviceController(ds service.DeviceService) DeviceController {
+	return &deviceController{
+		deviceService: ds,
+	}
+}
+
+func (c *deviceController) RegisterDevice(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.RegisterDeviceRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.deviceService.RegisterDevice(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_DEVICE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_DEVICE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *deviceController) UnregisterDevice(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.UnregisterDeviceRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.deviceService.UnregisterDevice(ctx.Request.Context(), userId, req); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, dto.ErrDeviceNotFound) {
+			status = http.StatusNotFound
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNREGISTER_DEVICE, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNREGISTER_DEVICE, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *deviceController) GetDevices(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.deviceService.GetDevices(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DEVICES, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DEVICES, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	EventController interface {
+		Stream(ctx *gin.Context)
+	}
+
+	eventController struct {
+		eventBroker service.EventBroker
+		userService service.UserService
+	}
+)
+
+const eventHeartbeatInterval = 25 * time.Second
+
+func NewEventController(eb service.EventBroker, us service.UserService) EventController {
+	return &eventController{
+		eventBroker: eb,
+		userService: us,
+	}
+}
+
+func (c *eventController) Stream(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	user, err := c.userService.GetUserById(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// citizens only follow their own reports
+	visible := func(event dto.ReportEvent) bool {
+		return user.Role == constants.ENUM_ROLE_ADMIN || event.UserID == user.ID
+	}
+
+	lastEventId := ctx.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = ctx.Query("last_event_id")
+	}
+	lastId, _ := strconv.ParseInt(lastEventId, 10, 64)
+
+	events, replay, unsubscribe := c.eventBroker.Subscribe(lastId)
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	w := ctx.Writer
+	fmt.Fprint(w, "retry: 3000\n\n")
+	for _, event := range replay {
+		if visible(event) {
+			writeEvent(w, event)
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				// dropped for falling behind; the client reconnects and replays
+				return
+			}
+			if visible(event) {
+				writeEvent(w, event)
+				w.Flush()
+			}
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			w.Flush()
+		}
+	}
+}
+
+func writeEvent(w gin.ResponseWriter, event dto.ReportEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ExportController interface {
+		ExportGeoJSON(ctx *gin.Context)
+		ExportKML(ctx *gin.Context)
+		ExportSpreadsheet(ctx *gin.Context)
+		GetReportExport(ctx *gin.Context)
+		DownloadReportExport(ctx *gin.Context)
+	}
+
+	exportController struct {
+		exportService service.ExportService
+	}
+)
+
+func NewExportController(es service.ExportService) ExportController {
+	return &exportController{
+		exportService: es,
+	}
+}
+
+// bindExportFilter validates up front: once streaming starts the status
+// code is already sent and errors can no longer be reported as JSON.
+func bindExportFilter(ctx *gin.Context) (dto.ReportFilterRequest, bool) {
+	var req dto.ReportFilterRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return req, false
+	}
+
+	if err := req.Validate(); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPORT_REPORTS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return req, false
+	}
+	req.AgencyIDs = ctx.GetStringSlice("agency_ids")
+
+	return req, true
+}
+
+func (c *exportController) ExportGeoJSON(ctx *gin.Context) {
+	req, ok := bindExportFilter(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Header("Content-Type", "application/geo+json")
+	ctx.Header("Content-Disposition", `attachment; filename="reports.geojson"`)
+	ctx.Status(http.StatusOK)
+
+	if err := c.exportService.ExportGeoJSON(ctx.Request.Context(), req, ctx.Writer); err != nil {
+		log.Printf("geojson export: %v", err)
+	}
+}
+
+func (c *exportController) ExportKML(ctx *gin.Context) {
+	req, ok := bindExportFilter(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Header("Content-Type", "application/vnd.google-earth.kml+xml")
+	ctx.Header("Content-Disposition", `attachment; filename="reports.kml"`)
+	ctx.Status(http.StatusOK)
+
+	if err := c.exportService.ExportKML(ctx.Request.Context(), req, ctx.Writer); err != nil {
+		log.Printf("kml export: %v", err)
+	}
+}
+
+var spreadsheetContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func (c *exportController) ExportSpreadsheet(ctx *gin.Context) {
+	var req dto.SpreadsheetExportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.exportService.ValidateSpreadsheetExport(req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPORT_REPORTS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	background, err := c.exportService.ShouldExportInBackground(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPORT_REPORTS, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	if background {
+		userId := ctx.MustGet("user_id").(string)
+		result, err := c.exportService.StartSpreadsheetExport(ctx.Request.Context(), req, userId)
+		if err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPORT_REPORTS, err.Error(), nil)
+			ctx.JSON(http.StatusInternalServerError, res)
+			return
+		}
+
+		res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_START_REPORT_EXPORT, result)
+		ctx.JSON(http.StatusAccepted, res)
+		return
+	}
+
+	ctx.Header("Content-Type", spreadsheetContentTypes[req.Format])
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="reports.%s"`, req.Format))
+	ctx.Status(http.StatusOK)
+
+	if err := c.exportService.ExportSpreadsheet(ctx.Request.Context(), req, ctx.Writer); err != nil {
+		log.Printf("spreadsheet export: %v", err)
+	}
+}
+
+func (c *exportController) GetReportExport(ctx *gin.Context) {
+	result, err := c.exportService.GetReportExport(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_EXPORT, err.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REPORT_EXPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *exportController) DownloadReportExport(ctx *gin.Context) {
+	content, filename, err := c.exportService.GetReportExportFile(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, dto.ErrExportNotReady) {
+			status = http.StatusConflict
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_EXPORT, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, spreadsheetContentTypes[strings.TrimPrefix(filepath.Ext(filename), ".")], content)
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	HeldReportController interface {
+		GetHeldReports(ctx *gin.Context)
+		ReleaseReport(ctx *gin.Context)
+	}
+
+	heldReportController struct {
+		heldReportService service.HeldReportService
+	}
+)
+
+func NewHeldReportController(hs service.HeldReportService) HeldReportController {
+	return &heldReportController{
+		heldReportService: hs,
+	}
+}
+
+func (c *heldReportController) GetHeldReports(ctx *gin.Context) {
+	var req dto.ReportFilterRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	result, err := c.heldReportService.GetHeldReports(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_HELD, err.Error(), nil)
+		ctx.JSON(reportListErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_HELD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *heldReportController) ReleaseReport(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	result, err := c.heldReportService.ReleaseReport(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RELEASE_REPORT, err.Error(), nil)
+		ctx.JSON(releaseErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RELEASE_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func releaseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrReportNotHeld):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	JobController interface {
+		GetJobs(ctx *gin.Context)
+		GetJob(ctx *gin.Context)
+		RetryJob(ctx *gin.Context)
+	}
+
+	jobController struct {
+		jobService service.JobService
+	}
+)
+
+func NewJobController(js service.JobService) JobController {
+	return &jobController{
+		jobService: js,
+	}
+}
+
+func (c *jobController) GetJobs(ctx *gin.Context) {
+	var req dto.JobListRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.jobService.GetJobs(ctx.Request.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, dto.ErrInvalidJobStatus) {
+			status = http.StatusBadRequest
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_JOBS, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_JOBS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *jobController) GetJob(ctx *gin.Context) {
+	result, err := c.jobService.GetJob(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_JOB, err.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_JOB, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *jobController) RetryJob(ctx *gin.Context) {
+	result, err := c.jobService.RetryJob(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, dto.ErrJobNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, dto.ErrJobNotRetryable):
+			status = http.StatusConflict
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RETRY_JOB, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RETRY_JOB, result)
+	ctx.JSON(http.StatusOK, res)
+}
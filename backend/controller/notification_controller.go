@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	NotificationController interface {
+		GetNotifications(ctx *gin.Context)
+		CountUnread(ctx *gin.Context)
+		MarkRead(ctx *gin.Context)
+		MarkAllRead(ctx *gin.Context)
+		GetPreferences(ctx *gin.Context)
+		UpdatePreferences(ctx *gin.Context)
+	}
+
+	notificationController struct {
+		notificationService service.NotificationService
+	}
+)
+
+func NewNotificationController(ns service.NotificationService) NotificationController {
+	return &notificationController{
+		notificationService: ns,
+	}
+}
+
+func (c *notificationController) GetNotifications(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.NotificationListRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.notificationService.GetNotifications(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_NOTIFICATIONS, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_NOTIFICATIONS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *notificationController) CountUnread(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.notificationService.CountUnread(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_COUNT_UNREAD_NOTIFICATIONS, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_COUNT_UNREAD_NOTIFICATIONS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *notificationController) MarkRead(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.notificationService.MarkRead(ctx.Request.Context(), userId, ctx.Param("id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, dto.ErrNotificationNotFound) {
+			status = http.StatusNotFound
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_READ_NOTIFICATION, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_READ_NOTIFICATION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *notificationController) MarkAllRead(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.notificationService.MarkAllRead(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_READ_NOTIFICATION, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_READ_NOTIFICATION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *notificationController) GetPreferences(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.notificationService.GetPreferences(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_NOTIFICATION_PREFERENCES, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_NOTIFICATION_PREFERENCES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *notificationController) UpdatePreferences(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.NotificationPreferencesRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.notificationService.UpdatePreferences(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_NOTIFICATION_PREFERENCE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_NOTIFICATION_PREFERENCE, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	OpenDataController interface {
+		ListSnapshots(ctx *gin.Context)
+		GetSnapshotFile(ctx *gin.Context)
+	}
+
+	openDataController struct {
+		openDataService service.OpenDataService
+	}
+)
+
+func NewOpenDataController(ods service.OpenDataService) OpenDataController {
+	return &openDataController{
+		openDataService: ods,
+	}
+}
+
+func (c *openDataController) ListSnapshots(ctx *gin.Context) {
+	result, err := c.openDataService.ListSnapshots(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_OPEN_DATA, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_OPEN_DATA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *openDataController) GetSnapshotFile(ctx *gin.Context) {
+	file := ctx.Param("file")
+	content, err := c.openDataService.GetSnapshotFile(ctx.Request.Context(), ctx.Param("version"), file)
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, dto.ErrInvalidOpenDataFile) {
+			status = http.StatusBadRequest
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_OPEN_DATA, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if file == dto.OPEN_DATA_FILE_CSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file))
+	ctx.Data(http.StatusOK, contentType, content)
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	RatingController interface {
+		RateReport(ctx *gin.Context)
+		GetRating(ctx *gin.Context)
+	}
+
+	ratingController struct {
+		ratingService service.RatingService
+	}
+)
+
+func NewRatingController(rs service.RatingService) RatingController {
+	return &ratingController{
+		ratingService: rs,
+	}
+}
+
+func (c *ratingController) RateReport(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.RateReportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.ratingService.RateReport(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RATE_REPORT, err.Error(), nil)
+		ctx.JSON(ratingErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RATE_REPORT, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *ratingController) GetRating(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	result, err := c.ratingService.GetRating(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_RATING, err.Error(), nil)
+		ctx.JSON(ratingErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_RATING, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func ratingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById),
+		errors.Is(err, dto.ErrRatingNotFound),
+		errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrNotReportReporter):
+		return http.StatusForbidden
+	case errors.Is(err, dto.ErrReportAlreadyRated),
+		errors.Is(err, dto.ErrReportNotCompleted):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	RejectionReasonController interface {
+		GetReasons(ctx *gin.Context)
+		GetAllReasons(ctx *gin.Context)
+		SaveReason(ctx *gin.Context)
+		DeactivateReason(ctx *gin.Context)
+	}
+
+	rejectionReasonController struct {
+		reasonService service.RejectionReasonService
+	}
+)
+
+func NewRejectionReasonController(rs service.RejectionReasonService) RejectionReasonController {
+	return &rejectionReasonController{
+		reasonService: rs,
+	}
+}
+
+func (c *rejectionReasonController) GetReasons(ctx *gin.Context) {
+	c.getReasons(ctx, false)
+}
+
+func (c *rejectionReasonController) GetAllReasons(ctx *gin.Context) {
+	c.getReasons(ctx, true)
+}
+
+func (c *rejectionReasonController) getReasons(ctx *gin.Context, includeInactive bool) {
+	lang := utils.PreferredLanguage(ctx.GetHeader("Accept-Language"))
+	result, err := c.reasonService.GetReasons(ctx.Request.Context(), lang, includeInactive)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REJECTION_REASONS, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REJECTION_REASONS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *rejectionReasonController) SaveReason(ctx *gin.Context) {
+	var req dto.SaveRejectionReasonRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	lang := utils.PreferredLanguage(ctx.GetHeader("Accept-Language"))
+	result, err := c.reasonService.SaveReason(ctx.Request.Context(), req, lang)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SAVE_REJECTION_REASON, err.Error(), nil)
+		ctx.JSON(rejectionReasonErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SAVE_REJECTION_REASON, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *rejectionReasonController) DeactivateReason(ctx *gin.Context) {
+	if err := c.reasonService.DeactivateReason(ctx.Request.Context(), ctx.Param("code")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_REJECTION_REASON, err.Error(), nil)
+		ctx.JSON(rejectionReasonErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_REJECTION_REASON, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func rejectionReasonErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrRejectionReasonNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrInvalidRejectionCode):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/Caknoooo/go-gin-clean-starter/dto"
 	"github.com/Caknoooo/go-gin-clean-starter/service"
@@ -20,18 +22,20 @@ type (
 		CountReportStatus(ctx *gin.Context)
 		GetReportsByStatus(ctx *gin.Context)
 		InferenceStatus(ctx *gin.Context)
+		GetReportHistory(ctx *gin.Context)
+		GetCompletion(ctx *gin.Context)
+		ConfirmCompletion(ctx *gin.Context)
+		DisputeCompletion(ctx *gin.Context)
 	}
 
 	reportController struct {
 		reportService service.ReportService
-		userService   service.UserService
 	}
 )
 
-func NewReportController(rs service.ReportService, us service.UserService) ReportController {
+func NewReportController(rs service.ReportService) ReportController {
 	return &reportController{
 		reportService: rs,
-		userService:   us,
 	}
 }
 
@@ -46,7 +50,7 @@ func (c *reportController) CreateReport(ctx *gin.Context) {
 	result, err := c.reportService.CreateReport(ctx, report)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SEND_REPORT, err.Error(), nil)
-		ctx.JSON(http.StatusBadRequest, res)
+		ctx.JSON(createReportErrorStatus(err), res)
 		return
 	}
 
@@ -55,16 +59,17 @@ func (c *reportController) CreateReport(ctx *gin.Context) {
 }
 
 func (c *reportController) GetAllReports(ctx *gin.Context) {
-	var req dto.PaginationRequest
+	var req dto.ReportFilterRequest
 	if err := ctx.ShouldBind(&req); err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	reports, err := c.reportService.GetAllReports(ctx.Request.Context(), req)
+	req.AgencyIDs = ctx.GetStringSlice("agency_ids")
+	reports, err := c.reportService.GetReports(ctx.Request.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORTS, err.Error(), nil)
-		ctx.JSON(http.StatusInternalServerError, res)
+		ctx.JSON(reportListErrorStatus(err), res)
 		return
 	}
 
@@ -73,11 +78,17 @@ func (c *reportController) GetAllReports(ctx *gin.Context) {
 }
 
 func (c *reportController) GetReportById(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
 	reportId := ctx.Param("id")
-	result, err := c.reportService.GetReportById(ctx.Request.Context(), reportId)
+	result, err := c.reportService.GetReportById(ctx.Request.Context(), reportId, userId)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_BY_ID, err.Error(), nil)
-		ctx.JSON(http.StatusBadRequest, res)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+	if !inAgencyScope(ctx, result.AgencyID) {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_BY_ID, dto.ErrGetReportById.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
 		return
 	}
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REPORT_BY_ID, result)
@@ -85,14 +96,15 @@ func (c *reportController) GetReportById(ctx *gin.Context) {
 }
 
 func (c *reportController) GetReportsByUserId(ctx *gin.Context) {
-	userId := ctx.Param("id")
-	var req dto.PaginationRequest
+	var req dto.ReportFilterRequest
 	if err := ctx.ShouldBind(&req); err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	result, err := c.reportService.GetReportsByUserId(ctx.Request.Context(), userId, req)
+	req.UserID = ctx.Param("id")
+	req.AgencyIDs = ctx.GetStringSlice("agency_ids")
+	result, err := c.reportService.GetReports(ctx.Request.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORTS_BY_USER_ID, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -104,17 +116,6 @@ func (c *reportController) GetReportsByUserId(ctx *gin.Context) {
 
 func (c *reportController) UpdateReportStatus(ctx *gin.Context) {
 	userId := ctx.MustGet("user_id").(string)
-	user, err := c.userService.GetUserById(ctx.Request.Context(), userId)
-	if err != nil {
-		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
-		ctx.JSON(http.StatusBadRequest, res)
-		return
-	}
-	if user.Role != "admin" {
-		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DENIED_ACCESS, dto.MESSAGE_FAILED_DENIED, nil)
-		ctx.JSON(http.StatusForbidden, res)
-		return
-	}
 	reportId := ctx.Param("id")
 	var req dto.UpdateStatusReportRequest
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -122,8 +123,18 @@ func (c *reportController) UpdateReportStatus(ctx *gin.Context) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	result, err := c.reportService.UpdateReportStatus(ctx.Request.Context(), reportId, req.Status)
+	result, err := c.reportService.UpdateReportStatus(ctx.Request.Context(), reportId, req, userId)
 	if err != nil {
+		if errors.Is(err, dto.ErrStatusChangeForbidden) {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DENIED_ACCESS, err.Error(), nil)
+			ctx.JSON(http.StatusForbidden, res)
+			return
+		}
+		if errors.Is(err, dto.ErrReportHeld) {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_BY_ID, err.Error(), nil)
+			ctx.JSON(http.StatusConflict, res)
+			return
+		}
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_BY_ID, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
 		return
@@ -133,7 +144,7 @@ func (c *reportController) UpdateReportStatus(ctx *gin.Context) {
 }
 
 func (c *reportController) CountReportStatus(ctx *gin.Context) {
-	result, err := c.reportService.CountReportStatus(ctx.Request.Context())
+	result, err := c.reportService.CountReportStatus(ctx.Request.Context(), ctx.GetStringSlice("agency_ids"))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORTS, err.Error(), nil)
 		ctx.JSON(http.StatusInternalServerError, res)
@@ -144,14 +155,15 @@ func (c *reportController) CountReportStatus(ctx *gin.Context) {
 }
 
 func (c *reportController) GetReportsByStatus(ctx *gin.Context) {
-	var req dto.PaginationRequest
+	var req dto.ReportFilterRequest
 	if err := ctx.ShouldBind(&req); err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
-	status := ctx.Param("status")
-	result, err := c.reportService.GetReportsByStatus(ctx.Request.Context(), status, req)
+	req.Status = []string{ctx.Param("status")}
+	req.AgencyIDs = ctx.GetStringSlice("agency_ids")
+	result, err := c.reportService.GetReports(ctx.Request.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORTS, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -179,3 +191,120 @@ func (c *reportController) InferenceStatus(ctx *gin.Context) {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REPORTS, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *reportController) GetReportHistory(ctx *gin.Context) {
+	reportId := ctx.Param("id")
+	userId := ctx.MustGet("user_id").(string)
+	if _, scoped := ctx.Get("agency_ids"); scoped {
+		report, err := c.reportService.GetReportById(ctx.Request.Context(), reportId, userId)
+		if err != nil || !inAgencyScope(ctx, report.AgencyID) {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_HISTORY, dto.ErrGetReportById.Error(), nil)
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+	}
+	result, err := c.reportService.GetReportHistory(ctx.Request.Context(), reportId, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPORT_HISTORY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REPORT_HISTORY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *reportController) GetCompletion(ctx *gin.Context) {
+	reportId := ctx.Param("id")
+	if _, scoped := ctx.Get("agency_ids"); scoped {
+		report, err := c.reportService.GetReportById(ctx.Request.Context(), reportId, ctx.MustGet("user_id").(string))
+		if err != nil || !inAgencyScope(ctx, report.AgencyID) {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_COMPLETION, dto.ErrGetReportById.Error(), nil)
+			ctx.JSON(http.StatusNotFound, res)
+			return
+		}
+	}
+	result, err := c.reportService.GetCompletion(ctx.Request.Context(), reportId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_COMPLETION, err.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_COMPLETION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *reportController) ConfirmCompletion(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	result, err := c.reportService.ConfirmCompletion(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CONFIRM_COMPLETION, err.Error(), nil)
+		ctx.JSON(completionErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CONFIRM_COMPLETION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *reportController) DisputeCompletion(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.DisputeCompletionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	result, err := c.reportService.DisputeCompletion(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DISPUTE_COMPLETION, err.Error(), nil)
+		ctx.JSON(completionErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DISPUTE_COMPLETION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// createReportErrorStatus tells throttled reporters to come back later.
+func createReportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrReportQuotaExceeded),
+		errors.Is(err, dto.ErrReportCooldown):
+		return http.StatusTooManyRequests
+	}
+	return http.StatusBadRequest
+}
+
+func completionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById),
+		errors.Is(err, dto.ErrCompletionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrNotReportOwner):
+		return http.StatusForbidden
+	case errors.Is(err, dto.ErrCompletionNotPending),
+		errors.Is(err, dto.ErrCompletionWindowClosed):
+		return http.StatusConflict
+	case errors.Is(err, dto.ErrEmptyContent):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// reportListErrorStatus separates bad filter input from query failures.
+func reportListErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrInvalidReportStatus),
+		errors.Is(err, dto.ErrInvalidReportSort),
+		errors.Is(err, dto.ErrInvalidDateRange),
+		errors.Is(err, dto.ErrInvalidCursor),
+		errors.Is(err, dto.ErrInvalidCursorSort):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// inAgencyScope reports whether agency staff may see a report of agencyId.
+// Unscoped users see everything.
+func inAgencyScope(ctx *gin.Context, agencyId string) bool {
+	agencyIds := ctx.GetStringSlice("agency_ids")
+	return len(agencyIds) == 0 || slices.Contains(agencyIds, agencyId)
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ReputationController interface {
+		GetLeaderboard(ctx *gin.Context)
+		GetMyLedger(ctx *gin.Context)
+		GetUserLedger(ctx *gin.Context)
+	}
+
+	reputationController struct {
+		reputationService service.ReputationService
+	}
+)
+
+func NewReputationController(rs service.ReputationService) ReputationController {
+	return &reputationController{
+		reputationService: rs,
+	}
+}
+
+func (c *reputationController) GetLeaderboard(ctx *gin.Context) {
+	var req dto.LeaderboardRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.reputationService.GetLeaderboard(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LEADERBOARD, err.Error(), nil)
+		ctx.JSON(reputationErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LEADERBOARD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *reputationController) GetMyLedger(ctx *gin.Context) {
+	c.getLedger(ctx, ctx.MustGet("user_id").(string))
+}
+
+func (c *reputationController) GetUserLedger(ctx *gin.Context) {
+	c.getLedger(ctx, ctx.Param("id"))
+}
+
+func (c *reputationController) getLedger(ctx *gin.Context, userId string) {
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.reputationService.GetLedger(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REPUTATION, err.Error(), nil)
+		ctx.JSON(reputationErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REPUTATION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func reputationErrorStatus(err error) int {
+	if errors.Is(err, dto.ErrInvalidLeaderboardRange) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SchedulerController interface {
+		ListTasks(ctx *gin.Context)
+		RunTask(ctx *gin.Context)
+	}
+
+	schedulerController struct {
+		scheduler service.Scheduler
+	}
+)
+
+func NewSchedulerController(s service.Scheduler) SchedulerController {
+	return &schedulerController{
+		scheduler: s,
+	}
+}
+
+func (c *schedulerController) ListTasks(ctx *gin.Context) {
+	result, err := c.scheduler.ListTasks(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SCHEDULED_TASKS, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SCHEDULED_TASKS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *schedulerController) RunTask(ctx *gin.Context) {
+	result, err := c.scheduler.RunNow(ctx.Request.Context(), ctx.Param("name"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, dto.ErrScheduledTaskNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, dto.ErrScheduledTaskRunning):
+			status = http.StatusConflict
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RUN_SCHEDULED_TASK, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RUN_SCHEDULED_TASK, result)
+	ctx.JSON(http.StatusAccepted, res)
+}
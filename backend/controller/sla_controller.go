@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SLAController interface {
+		GetPolicies(ctx *gin.Context)
+		SavePolicy(ctx *gin.Context)
+		DeletePolicy(ctx *gin.Context)
+	}
+
+	slaController struct {
+		slaService service.SLAService
+	}
+)
+
+func NewSLAController(ss service.SLAService) SLAController {
+	return &slaController{
+		slaService: ss,
+	}
+}
+
+func (c *slaController) GetPolicies(ctx *gin.Context) {
+	result, err := c.slaService.GetPolicies(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SLA_POLICIES, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SLA_POLICIES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *slaController) SavePolicy(ctx *gin.Context) {
+	var req dto.SaveSLAPolicyRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.slaService.SavePolicy(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SAVE_SLA_POLICY, err.Error(), nil)
+		ctx.JSON(slaErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SAVE_SLA_POLICY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *slaController) DeletePolicy(ctx *gin.Context) {
+	if err := c.slaService.DeletePolicy(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_SLA_POLICY, err.Error(), nil)
+		ctx.JSON(slaErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_SLA_POLICY, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func slaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrSLAPolicyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrSLAPolicyStatus):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SubscriptionController interface {
+		CreateSubscription(ctx *gin.Context)
+		GetSubscriptions(ctx *gin.Context)
+		DeleteSubscription(ctx *gin.Context)
+	}
+
+	subscriptionController struct {
+		subscriptionService service.SubscriptionService
+	}
+)
+
+func NewSubscriptionController(ss service.SubscriptionService) SubscriptionController {
+	return &subscriptionController{
+		subscriptionService: ss,
+	}
+}
+
+func (c *subscriptionController) CreateSubscription(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.CreateSubscriptionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.subscriptionService.CreateSubscription(ctx.Request.Context(), userId, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, dto.ErrSubscriptionTagMissing) {
+			status = http.StatusNotFound
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_SUBSCRIPTION, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_SUBSCRIPTION, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *subscriptionController) GetSubscriptions(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.subscriptionService.GetSubscriptions(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SUBSCRIPTIONS, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SUBSCRIPTIONS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *subscriptionController) DeleteSubscription(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	if err := c.subscriptionService.DeleteSubscription(ctx.Request.Context(), userId, ctx.Param("id")); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, dto.ErrSubscriptionNotFound) {
+			status = http.StatusNotFound
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_SUBSCRIPTION, err.Error(), nil)
+		ctx.JSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_SUBSCRIPTION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	TrustController interface {
+		GetTrust(ctx *gin.Context)
+		SetTrust(ctx *gin.Context)
+		ClearTrust(ctx *gin.Context)
+	}
+
+	trustController struct {
+		trustService service.TrustService
+	}
+)
+
+func NewTrustController(ts service.TrustService) TrustController {
+	return &trustController{
+		trustService: ts,
+	}
+}
+
+func (c *trustController) GetTrust(ctx *gin.Context) {
+	result, err := c.trustService.GetTrust(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TRUST, err.Error(), nil)
+		ctx.JSON(trustErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_TRUST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *trustController) SetTrust(ctx *gin.Context) {
+	adminId := ctx.MustGet("user_id").(string)
+	var req dto.SetTrustRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.trustService.SetOverride(ctx.Request.Context(), ctx.Param("id"), req, adminId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SET_TRUST, err.Error(), nil)
+		ctx.JSON(trustErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SET_TRUST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *trustController) ClearTrust(ctx *gin.Context) {
+	result, err := c.trustService.ClearOverride(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CLEAR_TRUST, err.Error(), nil)
+		ctx.JSON(trustErrorStatus(err), res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CLEAR_TRUST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func trustErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrInvalidTrustLevel):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
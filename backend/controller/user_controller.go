@@ -19,17 +19,20 @@ type (
 		SendVerificationEmail(ctx *gin.Context)
 		VerifyEmail(ctx *gin.Context)
 		Update(ctx *gin.Context)
+		UpdateRole(ctx *gin.Context)
 		Delete(ctx *gin.Context)
 	}
 
 	userController struct {
-		userService service.UserService
+		userService       service.UserService
+		reputationService service.ReputationService
 	}
 )
 
-func NewUserController(us service.UserService) UserController {
+func NewUserController(us service.UserService, rs service.ReputationService) UserController {
 	return &userController{
-		userService: us,
+		userService:       us,
+		reputationService: rs,
 	}
 }
 
@@ -80,14 +83,24 @@ func (c *userController) GetAllUser(ctx *gin.Context) {
 func (c *userController) Me(ctx *gin.Context) {
 	userId := ctx.MustGet("user_id").(string)
 
-	result, err := c.userService.GetUserById(ctx.Request.Context(), userId)
+	user, err := c.userService.GetUserById(ctx.Request.Context(), userId)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
-	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	reputation, err := c.reputationService.GetReputation(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, dto.MeResponse{
+		UserResponse: user,
+		Reputation:   reputation,
+	})
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -168,6 +181,25 @@ func (c *userController) Update(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (c *userController) UpdateRole(ctx *gin.Context) {
+	var req dto.UpdateUserRoleRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.userService.UpdateRole(ctx.Request.Context(), ctx.Param("id"), req.Role)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_ROLE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (c *userController) Delete(ctx *gin.Context) {
 	userId := ctx.MustGet("user_id").(string)
 
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	VoteController interface {
+		VoteReport(ctx *gin.Context)
+		GetVotes(ctx *gin.Context)
+		UpvoteReport(ctx *gin.Context)
+		RemoveUpvote(ctx *gin.Context)
+	}
+
+	voteController struct {
+		voteService service.VoteService
+	}
+)
+
+func NewVoteController(vs service.VoteService) VoteController {
+	return &voteController{
+		voteService: vs,
+	}
+}
+
+func (c *voteController) VoteReport(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	var req dto.VoteReportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	result, err := c.voteService.VoteReport(ctx.Request.Context(), ctx.Param("id"), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VOTE_REPORT, err.Error(), nil)
+		ctx.JSON(voteErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_VOTE_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *voteController) GetVotes(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	result, err := c.voteService.GetVotes(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_VOTES, err.Error(), nil)
+		ctx.JSON(voteErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_VOTES, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func voteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById),
+		errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrVoterNotVerified),
+		errors.Is(err, dto.ErrVoteOwnReport):
+		return http.StatusForbidden
+	case errors.Is(err, dto.ErrReportNotUnverified),
+		errors.Is(err, dto.ErrReportHeld):
+		return http.StatusConflict
+	case errors.Is(err, dto.ErrInvalidVoteKind):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func (c *voteController) UpvoteReport(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	result, err := c.voteService.UpvoteReport(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPVOTE_REPORT, err.Error(), nil)
+		ctx.JSON(upvoteErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPVOTE_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *voteController) RemoveUpvote(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	result, err := c.voteService.RemoveUpvote(ctx.Request.Context(), ctx.Param("id"), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPVOTE_REPORT, err.Error(), nil)
+		ctx.JSON(upvoteErrorStatus(err), res)
+		return
+	}
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REMOVE_UPVOTE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func upvoteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrGetReportById),
+		errors.Is(err, dto.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dto.ErrUpvoteOwnReport):
+		return http.StatusForbidden
+	case errors.Is(err, dto.ErrReportHeld):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
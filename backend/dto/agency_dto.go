@@ -0,0 +1,93 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_AGENCY        = "gagal membuat instansi"
+	MESSAGE_FAILED_GET_AGENCIES         = "gagal mendapatkan daftar instansi"
+	MESSAGE_FAILED_DELETE_AGENCY        = "gagal menghapus instansi"
+	MESSAGE_FAILED_ADD_AGENCY_MEMBER    = "gagal menambahkan anggota instansi"
+	MESSAGE_FAILED_REMOVE_AGENCY_MEMBER = "gagal menghapus anggota instansi"
+	MESSAGE_FAILED_GET_AGENCY_ROUTES    = "gagal mendapatkan daftar rute instansi"
+	MESSAGE_FAILED_SAVE_AGENCY_ROUTE    = "gagal menyimpan rute instansi"
+	MESSAGE_FAILED_DELETE_AGENCY_ROUTE  = "gagal menghapus rute instansi"
+	MESSAGE_FAILED_ROUTE_REPORT         = "gagal meneruskan laporan"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_AGENCY        = "berhasil membuat instansi"
+	MESSAGE_SUCCESS_GET_AGENCIES         = "berhasil mendapatkan daftar instansi"
+	MESSAGE_SUCCESS_DELETE_AGENCY        = "berhasil menghapus instansi"
+	MESSAGE_SUCCESS_ADD_AGENCY_MEMBER    = "berhasil menambahkan anggota instansi"
+	MESSAGE_SUCCESS_REMOVE_AGENCY_MEMBER = "berhasil menghapus anggota instansi"
+	MESSAGE_SUCCESS_GET_AGENCY_ROUTES    = "berhasil mendapatkan daftar rute instansi"
+	MESSAGE_SUCCESS_SAVE_AGENCY_ROUTE    = "berhasil menyimpan rute instansi"
+	MESSAGE_SUCCESS_DELETE_AGENCY_ROUTE  = "berhasil menghapus rute instansi"
+	MESSAGE_SUCCESS_ROUTE_REPORT         = "berhasil meneruskan laporan"
+)
+
+var (
+	ErrCreateAgency         = errors.New("gagal membuat instansi")
+	ErrAgencyNameExists     = errors.New("nama instansi sudah digunakan")
+	ErrGetAgencies          = errors.New("gagal mendapatkan daftar instansi")
+	ErrAgencyNotFound       = errors.New("instansi tidak ditemukan")
+	ErrAgencyMemberNotFound = errors.New("anggota instansi tidak ditemukan")
+	ErrSaveAgencyMember     = errors.New("gagal menyimpan anggota instansi")
+	ErrAgencyRouteNotFound  = errors.New("rute instansi tidak ditemukan")
+	ErrSaveAgencyRoute      = errors.New("gagal menyimpan rute instansi")
+	ErrRouteReport          = errors.New("gagal meneruskan laporan")
+	ErrAssigneeNotInAgency  = errors.New("petugas bukan anggota instansi yang menangani laporan")
+)
+
+type (
+	CreateAgencyRequest struct {
+		Name        string `json:"name" form:"name" binding:"required,min=2,max=100"`
+		Description string `json:"description" form:"description"`
+	}
+
+	AgencyMemberRequest struct {
+		Role string `json:"role" form:"role" binding:"omitempty,oneof=staff supervisor"`
+	}
+
+	AgencyMemberResponse struct {
+		UserID string `json:"user_id"`
+		Name   string `json:"name"`
+		Email  string `json:"email"`
+		Role   string `json:"role"`
+	}
+
+	AgencyResponse struct {
+		ID          string                 `json:"id"`
+		Name        string                 `json:"name"`
+		Description string                 `json:"description"`
+		Members     []AgencyMemberResponse `json:"members"`
+		CreatedAt   string                 `json:"created_at"`
+	}
+
+	// SaveAgencyRouteRequest creates or replaces the route for a class and
+	// region. Leave the region empty for the class-wide fallback.
+	SaveAgencyRouteRequest struct {
+		Class    string `json:"class" form:"class" binding:"required,max=20"`
+		Region   string `json:"region" form:"region" binding:"max=100"`
+		AgencyID string `json:"agency_id" form:"agency_id" binding:"required,uuid"`
+	}
+
+	AgencyRouteResponse struct {
+		ID         string `json:"id"`
+		Class      string `json:"class"`
+		Region     string `json:"region"`
+		AgencyID   string `json:"agency_id"`
+		AgencyName string `json:"agency_name"`
+	}
+
+	RouteReportRequest struct {
+		AgencyID string `json:"agency_id" form:"agency_id" binding:"required,uuid"`
+		Note     string `json:"note" form:"note" binding:"max=500"`
+	}
+
+	RouteReportResponse struct {
+		ReportID   string `json:"report_id"`
+		AgencyID   string `json:"agency_id"`
+		AgencyName string `json:"agency_name"`
+	}
+)
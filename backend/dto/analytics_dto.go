@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_ANALYTICS = "gagal mendapatkan analitik laporan"
+
+	// Success
+	MESSAGE_SUCCESS_GET_ANALYTICS = "berhasil mendapatkan analitik laporan"
+
+	ANALYTICS_INTERVAL_DAY   = "day"
+	ANALYTICS_INTERVAL_WEEK  = "week"
+	ANALYTICS_INTERVAL_MONTH = "month"
+
+	ANALYTICS_GROUP_CLASS    = "class"
+	ANALYTICS_GROUP_STATUS   = "status"
+	ANALYTICS_GROUP_LOCATION = "location"
+
+	ANALYTICS_MAX_BUCKETS = 366
+)
+
+var (
+	ErrGetAnalytics             = errors.New("gagal mendapatkan analitik laporan")
+	ErrInvalidAnalyticsInterval = errors.New("interval analitik tidak valid")
+	ErrInvalidAnalyticsGroup    = errors.New("pengelompokan analitik tidak valid")
+	ErrAnalyticsRangeTooLarge   = errors.New("rentang analitik terlalu besar")
+)
+
+type (
+	ReportAnalyticsRequest struct {
+		From     time.Time `form:"from" time_format:"2006-01-02"`
+		To       time.Time `form:"to" time_format:"2006-01-02"`
+		Interval string    `form:"interval"`
+		GroupBy  string    `form:"group_by"`
+	}
+
+	ReportBucketCount struct {
+		Bucket string
+		Key    string
+		Count  int64
+	}
+
+	ReportTransitionMedian struct {
+		MedianHours *float64
+		Count       int64
+	}
+
+	ReportAnalyticsSeries struct {
+		Key    string  `json:"key"`
+		Counts []int64 `json:"counts"`
+		Total  int64   `json:"total"`
+	}
+
+	ReportAnalyticsMetrics struct {
+		MedianTimeToVerifyHours   *float64 `json:"median_time_to_verify_hours"`
+		MedianTimeToCompleteHours *float64 `json:"median_time_to_complete_hours"`
+		VerifiedCount             int64    `json:"verified_count"`
+		CompletedCount            int64    `json:"completed_count"`
+		// SLACompliance is per status left; reports still sitting past
+		// their deadline count as misses.
+		SLACompliance        []SLAComplianceMetric `json:"sla_compliance"`
+		SLACompliancePercent *float64              `json:"sla_compliance_percent"`
+	}
+
+	// ReportAnalyticsResponse is laid out for charting: Buckets holds the
+	// x axis and every series has one count per bucket, zeros included.
+	ReportAnalyticsResponse struct {
+		Interval string                  `json:"interval"`
+		GroupBy  string                  `json:"group_by,omitempty"`
+		From     string                  `json:"from"`
+		To       string                  `json:"to"`
+		Buckets  []string                `json:"buckets"`
+		Totals   []int64                 `json:"totals"`
+		Series   []ReportAnalyticsSeries `json:"series"`
+		Metrics  ReportAnalyticsMetrics  `json:"metrics"`
+	}
+)
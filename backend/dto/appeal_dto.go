@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"errors"
+	"mime/multipart"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+)
+
+const (
+	APPEAL_MAX_IMAGES = 3
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_REJECTION_REASONS   = "gagal mendapatkan daftar alasan penolakan"
+	MESSAGE_FAILED_SAVE_REJECTION_REASON   = "gagal menyimpan alasan penolakan"
+	MESSAGE_FAILED_DELETE_REJECTION_REASON = "gagal menonaktifkan alasan penolakan"
+	MESSAGE_FAILED_FILE_APPEAL             = "gagal mengajukan banding"
+	MESSAGE_FAILED_GET_APPEAL              = "gagal mendapatkan banding"
+	MESSAGE_FAILED_GET_APPEALS             = "gagal mendapatkan daftar banding"
+	MESSAGE_FAILED_REVIEW_APPEAL           = "gagal memproses banding"
+
+	// Success
+	MESSAGE_SUCCESS_GET_REJECTION_REASONS   = "berhasil mendapatkan daftar alasan penolakan"
+	MESSAGE_SUCCESS_SAVE_REJECTION_REASON   = "berhasil menyimpan alasan penolakan"
+	MESSAGE_SUCCESS_DELETE_REJECTION_REASON = "berhasil menonaktifkan alasan penolakan"
+	MESSAGE_SUCCESS_FILE_APPEAL             = "berhasil mengajukan banding"
+	MESSAGE_SUCCESS_GET_APPEAL              = "berhasil mendapatkan banding"
+	MESSAGE_SUCCESS_GET_APPEALS             = "berhasil mendapatkan daftar banding"
+	MESSAGE_SUCCESS_REVIEW_APPEAL           = "berhasil memproses banding"
+)
+
+var (
+	ErrGetRejectionReasons     = errors.New("gagal mendapatkan daftar alasan penolakan")
+	ErrSaveRejectionReason     = errors.New("gagal menyimpan alasan penolakan")
+	ErrRejectionReasonNotFound = errors.New("alasan penolakan tidak ditemukan")
+	ErrRejectionReasonRequired = errors.New("alasan penolakan wajib dipilih saat menolak laporan")
+	ErrInvalidRejectionCode    = errors.New("kode alasan penolakan hanya boleh huruf kecil, angka dan garis bawah")
+	ErrReportNotRejected       = errors.New("hanya laporan yang ditolak yang dapat diajukan banding")
+	ErrAppealExists            = errors.New("banding untuk laporan ini sudah pernah diajukan")
+	ErrAppealEmpty             = errors.New("banding wajib berisi penjelasan atau gambar")
+	ErrTooManyAppealImages     = errors.New("gambar banding terlalu banyak")
+	ErrFileAppeal              = errors.New("gagal mengajukan banding")
+	ErrAppealNotFound          = errors.New("banding tidak ditemukan")
+	ErrAppealNotPending        = errors.New("banding sudah diproses")
+	ErrGetAppeals              = errors.New("gagal mendapatkan daftar banding")
+	ErrInvalidAppealStatus     = errors.New("status banding tidak valid")
+	ErrReviewAppeal            = errors.New("gagal memproses banding")
+)
+
+type (
+	SaveRejectionReasonRequest struct {
+		Code          string `json:"code" form:"code" binding:"required,max=50"`
+		DescriptionID string `json:"description_id" form:"description_id" binding:"required,max=500"`
+		DescriptionEN string `json:"description_en" form:"description_en" binding:"max=500"`
+		Spam          bool   `json:"spam" form:"spam"`
+	}
+
+	// RejectionReasonResponse carries Description in the caller's language
+	// next to every translation.
+	RejectionReasonResponse struct {
+		Code          string `json:"code"`
+		Description   string `json:"description"`
+		DescriptionID string `json:"description_id"`
+		DescriptionEN string `json:"description_en"`
+		Active        bool   `json:"active"`
+		Spam          bool   `json:"spam"`
+	}
+
+	FileAppealRequest struct {
+		Text   string                  `json:"text" form:"text" binding:"max=2000"`
+		Images []*multipart.FileHeader `json:"-" form:"images"`
+	}
+
+	ReviewAppealRequest struct {
+		Note string `json:"note" form:"note" binding:"max=1000"`
+	}
+
+	AppealListRequest struct {
+		PaginationRequest
+		Status string `form:"status"`
+	}
+
+	AppealResponse struct {
+		ID            string   `json:"id"`
+		ReportID      string   `json:"report_id"`
+		UserID        string   `json:"user_id"`
+		Text          string   `json:"text"`
+		Images        []string `json:"images"`
+		RejectionCode string   `json:"rejection_code"`
+		Status        string   `json:"status"`
+		ReviewerID    string   `json:"reviewer_id,omitempty"`
+		ReviewNote    string   `json:"review_note,omitempty"`
+		ReviewedAt    string   `json:"reviewed_at,omitempty"`
+		CreatedAt     string   `json:"created_at"`
+	}
+
+	AppealPaginationResponse struct {
+		Data []AppealResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllAppealRepositoryResponse struct {
+		Appeals []entity.ReportAppeal
+		PaginationResponse
+	}
+)
+
+// Validate defaults the queue to pending appeals.
+func (r *AppealListRequest) Validate() error {
+	if r.Status == "" {
+		r.Status = string(entity.AppealPending)
+	}
+	if !entity.AppealStatus(r.Status).IsValid() {
+		return ErrInvalidAppealStatus
+	}
+	return nil
+}
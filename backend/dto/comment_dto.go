@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+)
+
+const (
+	COMMENT_MAX_LENGTH   = 2000
+	COMMENT_MAX_MENTIONS = 10
+	// COMMENT_MAX_REPLIES caps the replies returned with each thread.
+	COMMENT_MAX_REPLIES = 50
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_COMMENT   = "gagal membuat komentar"
+	MESSAGE_FAILED_GET_COMMENTS     = "gagal mendapatkan komentar"
+	MESSAGE_FAILED_UPDATE_COMMENT   = "gagal memperbarui komentar"
+	MESSAGE_FAILED_DELETE_COMMENT   = "gagal menghapus komentar"
+	MESSAGE_FAILED_MODERATE_COMMENT = "gagal memoderasi komentar"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_COMMENT   = "berhasil membuat komentar"
+	MESSAGE_SUCCESS_GET_COMMENTS     = "berhasil mendapatkan komentar"
+	MESSAGE_SUCCESS_UPDATE_COMMENT   = "berhasil memperbarui komentar"
+	MESSAGE_SUCCESS_DELETE_COMMENT   = "berhasil menghapus komentar"
+	MESSAGE_SUCCESS_MODERATE_COMMENT = "berhasil memoderasi komentar"
+)
+
+var (
+	ErrCreateComment          = errors.New("gagal membuat komentar")
+	ErrGetComments            = errors.New("gagal mendapatkan komentar")
+	ErrUpdateComment          = errors.New("gagal memperbarui komentar")
+	ErrCommentNotFound        = errors.New("komentar tidak ditemukan")
+	ErrCommentForbidden       = errors.New("anda tidak berhak mengubah komentar ini")
+	ErrCommentEmpty           = errors.New("komentar tidak boleh kosong")
+	ErrInvalidVisibility      = errors.New("visibilitas komentar tidak valid")
+	ErrInternalCommentDenied  = errors.New("hanya admin yang dapat menulis komentar internal")
+	ErrCommentParentMismatch  = errors.New("komentar induk bukan milik laporan ini")
+	ErrCommentVisibilityReply = errors.New("balasan komentar internal harus internal")
+	ErrCommentDeleted         = errors.New("komentar sudah dihapus")
+)
+
+type (
+	CreateCommentRequest struct {
+		Body       string `json:"body" form:"body" binding:"required,max=2000"`
+		ParentID   string `json:"parent_id" form:"parent_id"`
+		Visibility string `json:"visibility" form:"visibility"`
+	}
+
+	UpdateCommentRequest struct {
+		Body string `json:"body" form:"body" binding:"required,max=2000"`
+	}
+
+	ModerateCommentRequest struct {
+		Hidden bool   `json:"hidden" form:"hidden"`
+		Reason string `json:"reason" form:"reason" binding:"max=500"`
+	}
+
+	// CommentResponse leaves Body empty for deleted comments, and for
+	// hidden ones unless the viewer is an admin.
+	CommentResponse struct {
+		ID               string            `json:"id"`
+		ReportID         string            `json:"report_id"`
+		ParentID         string            `json:"parent_id,omitempty"`
+		UserID           string            `json:"user_id"`
+		Username         string            `json:"username"`
+		Visibility       string            `json:"visibility"`
+		Body             string            `json:"body"`
+		Edited           bool              `json:"edited"`
+		Deleted          bool              `json:"deleted"`
+		Hidden           bool              `json:"hidden"`
+		ModerationReason string            `json:"moderation_reason,omitempty"`
+		CreatedAt        string            `json:"created_at"`
+		Replies          []CommentResponse `json:"replies,omitempty"`
+	}
+
+	CommentPaginationResponse struct {
+		Data []CommentResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllCommentRepositoryResponse struct {
+		Comments []entity.Comment
+		PaginationResponse
+	}
+)
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	COMPLETION_MAX_PHOTOS = 5
+	// COMPLETION_MAX_DISTANCE_METERS is how far a geotagged proof may be
+	// taken from the report location.
+	COMPLETION_MAX_DISTANCE_METERS = 250
+	// COMPLETION_CONFIRM_WINDOW is how long the reporter has to confirm or
+	// dispute; silence counts as confirmation.
+	COMPLETION_CONFIRM_WINDOW = 7 * 24 * time.Hour
+
+	COMPLETION_EXPIRE_BATCH_SIZE = 100
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_COMPLETION     = "gagal mendapatkan bukti penyelesaian"
+	MESSAGE_FAILED_CONFIRM_COMPLETION = "gagal mengonfirmasi penyelesaian"
+	MESSAGE_FAILED_DISPUTE_COMPLETION = "gagal menyanggah penyelesaian"
+
+	// Success
+	MESSAGE_SUCCESS_GET_COMPLETION     = "berhasil mendapatkan bukti penyelesaian"
+	MESSAGE_SUCCESS_CONFIRM_COMPLETION = "berhasil mengonfirmasi penyelesaian"
+	MESSAGE_SUCCESS_DISPUTE_COMPLETION = "berhasil menyanggah penyelesaian"
+)
+
+var (
+	ErrEvidenceRequired       = errors.New("laporan selesai wajib disertai foto sesudah dan catatan")
+	ErrTooManyEvidencePhotos  = errors.New("foto bukti terlalu banyak")
+	ErrEvidenceTooFar         = errors.New("lokasi foto bukti terlalu jauh dari lokasi laporan")
+	ErrCompletionNotFound     = errors.New("bukti penyelesaian tidak ditemukan")
+	ErrCompletionNotPending   = errors.New("penyelesaian tidak sedang menunggu konfirmasi")
+	ErrCompletionWindowClosed = errors.New("batas waktu konfirmasi penyelesaian sudah lewat")
+	ErrNotReportOwner         = errors.New("hanya pelapor yang dapat menanggapi penyelesaian")
+)
+
+type (
+	DisputeCompletionRequest struct {
+		Reason string `json:"reason" form:"reason" binding:"required,max=1000"`
+	}
+
+	CompletionEvidenceResponse struct {
+		ID             string   `json:"id"`
+		ReportID       string   `json:"report_id"`
+		ActorID        string   `json:"actor_id,omitempty"`
+		Note           string   `json:"note"`
+		Photos         []string `json:"photos"`
+		Latitude       *float64 `json:"latitude"`
+		Longitude      *float64 `json:"longitude"`
+		DistanceMeters *float64 `json:"distance_meters"`
+		Outcome        string   `json:"outcome"`
+		ConfirmBy      string   `json:"confirm_by"`
+		RespondedAt    string   `json:"responded_at,omitempty"`
+		DisputeReason  string   `json:"dispute_reason,omitempty"`
+		CreatedAt      string   `json:"created_at"`
+	}
+)
@@ -0,0 +1,49 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_REGISTER_DEVICE   = "gagal mendaftarkan perangkat"
+	MESSAGE_FAILED_UNREGISTER_DEVICE = "gagal menghapus perangkat"
+	MESSAGE_FAILED_GET_DEVICES       = "gagal mendapatkan daftar perangkat"
+
+	// Success
+	MESSAGE_SUCCESS_REGISTER_DEVICE   = "berhasil mendaftarkan perangkat"
+	MESSAGE_SUCCESS_UNREGISTER_DEVICE = "berhasil menghapus perangkat"
+	MESSAGE_SUCCESS_GET_DEVICES       = "berhasil mendapatkan daftar perangkat"
+)
+
+var (
+	ErrRegisterDevice = errors.New("gagal mendaftarkan perangkat")
+	ErrDeviceNotFound = errors.New("perangkat tidak ditemukan")
+	ErrGetDevices     = errors.New("gagal mendapatkan daftar perangkat")
+)
+
+type (
+	// RegisterDeviceRequest is sent on every app start and whenever the push
+	// provider rotates the token; PreviousToken is dropped in that case.
+	RegisterDeviceRequest struct {
+		Token         string `json:"token" form:"token" binding:"required,max=512"`
+		Platform      string `json:"platform" form:"platform" binding:"required,oneof=android ios web"`
+		PreviousToken string `json:"previous_token" form:"previous_token" binding:"omitempty,max=512"`
+	}
+
+	UnregisterDeviceRequest struct {
+		Token string `json:"token" form:"token" binding:"required,max=512"`
+	}
+
+	DeviceResponse struct {
+		ID         string `json:"id"`
+		Platform   string `json:"platform"`
+		LastSeenAt string `json:"last_seen_at"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	SendPushJob struct {
+		Token string            `json:"token"`
+		Title string            `json:"title"`
+		Body  string            `json:"body"`
+		Data  map[string]string `json:"data,omitempty"`
+	}
+)
@@ -0,0 +1,23 @@
+package dto
+
+const (
+	EVENT_REPORT_CREATED      = "report_created"
+	EVENT_INFERENCE_COMPLETED = "inference_completed"
+	EVENT_STATUS_CHANGED      = "status_changed"
+
+	// Postgres NOTIFY channel carrying report events between instances.
+	EVENT_CHANNEL = "report_events"
+)
+
+type (
+	// ReportEvent is kept small: NOTIFY payloads are limited to 8000 bytes.
+	ReportEvent struct {
+		ID        int64  `json:"id"`
+		Type      string `json:"type"`
+		ReportID  string `json:"report_id"`
+		UserID    string `json:"user_id"`
+		Status    string `json:"status,omitempty"`
+		Class     string `json:"class,omitempty"`
+		CreatedAt string `json:"created_at"`
+	}
+)
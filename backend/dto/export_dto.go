@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_EXPORT_REPORTS    = "gagal mengekspor laporan"
+	MESSAGE_FAILED_GET_REPORT_EXPORT = "gagal mendapatkan ekspor laporan"
+
+	// Success
+	MESSAGE_SUCCESS_START_REPORT_EXPORT = "ekspor laporan sedang diproses"
+	MESSAGE_SUCCESS_GET_REPORT_EXPORT   = "berhasil mendapatkan ekspor laporan"
+
+	EXPORT_BATCH_SIZE = 500
+	// Spreadsheet exports with more rows than this run in the background.
+	EXPORT_SYNC_LIMIT = 5000
+	// Background exports and their files are purged after this long.
+	EXPORT_RETENTION = 7 * 24 * time.Hour
+)
+
+var (
+	ErrExportReports       = errors.New("gagal mengekspor laporan")
+	ErrInvalidExportFormat = errors.New("format ekspor tidak valid")
+	ErrInvalidExportColumn = errors.New("kolom ekspor tidak valid")
+	ErrExportNotFound      = errors.New("ekspor laporan tidak ditemukan")
+	ErrExportNotReady      = errors.New("ekspor laporan belum selesai")
+)
+
+type (
+	// SpreadsheetExportRequest takes every report filter plus the output
+	// format and a comma separated column list (empty means all columns).
+	SpreadsheetExportRequest struct {
+		ReportFilterRequest
+		Format  string `form:"format" json:"format"`
+		Columns string `form:"columns" json:"columns"`
+		Async   bool   `form:"async" json:"async"`
+	}
+
+	ReportExportResponse struct {
+		ID          string `json:"id"`
+		Format      string `json:"format"`
+		Status      string `json:"status"`
+		RowCount    int    `json:"row_count"`
+		Error       string `json:"error,omitempty"`
+		DownloadURL string `json:"download_url,omitempty"`
+		CreatedAt   string `json:"created_at"`
+		CompletedAt string `json:"completed_at,omitempty"`
+	}
+
+	GeoJSONGeometry struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}
+
+	GeoJSONReportProperties struct {
+		Status    string `json:"status"`
+		Class     string `json:"class"`
+		Upvotes   int    `json:"upvotes"`
+		Location  string `json:"location"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	// GeoJSONFeature has a null geometry when the report has no coordinates,
+	// which RFC 7946 allows.
+	GeoJSONFeature struct {
+		Type       string                  `json:"type"`
+		ID         string                  `json:"id"`
+		Geometry   *GeoJSONGeometry        `json:"geometry"`
+		Properties GeoJSONReportProperties `json:"properties"`
+	}
+
+	KMLData struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value"`
+	}
+
+	KMLPlacemark struct {
+		XMLName      xml.Name  `xml:"Placemark"`
+		ID           string    `xml:"id,attr"`
+		Name         string    `xml:"name"`
+		Description  string    `xml:"description,omitempty"`
+		ExtendedData []KMLData `xml:"ExtendedData>Data"`
+		Coordinates  string    `xml:"Point>coordinates"`
+	}
+)
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+)
+
+const (
+	// Job types
+	JOB_SEND_EMAIL    = "send_email"
+	JOB_SEND_PUSH     = "send_push"
+	JOB_REPORT_EXPORT = "report_export"
+
+	JOB_MATCH_SUBSCRIPTIONS = "match_subscriptions"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_JOBS  = "gagal mendapatkan daftar job"
+	MESSAGE_FAILED_GET_JOB   = "gagal mendapatkan job"
+	MESSAGE_FAILED_RETRY_JOB = "gagal mengulang job"
+
+	// Success
+	MESSAGE_SUCCESS_GET_JOBS  = "berhasil mendapatkan daftar job"
+	MESSAGE_SUCCESS_GET_JOB   = "berhasil mendapatkan job"
+	MESSAGE_SUCCESS_RETRY_JOB = "berhasil mengulang job"
+)
+
+var (
+	ErrEnqueueJob       = errors.New("gagal menambahkan job ke antrean")
+	ErrGetJobs          = errors.New("gagal mendapatkan daftar job")
+	ErrJobNotFound      = errors.New("job tidak ditemukan")
+	ErrJobNotRetryable  = errors.New("hanya job dead atau pending yang dapat diulang")
+	ErrInvalidJobStatus = errors.New("status job tidak valid")
+)
+
+type (
+	JobListRequest struct {
+		PaginationRequest
+		Status string `form:"status"`
+		Type   string `form:"type"`
+	}
+
+	JobResponse struct {
+		ID          string `json:"id"`
+		Type        string `json:"type"`
+		Payload     string `json:"payload"`
+		Status      string `json:"status"`
+		RunAt       string `json:"run_at"`
+		Attempts    int    `json:"attempts"`
+		MaxAttempts int    `json:"max_attempts"`
+		LockedBy    string `json:"locked_by,omitempty"`
+		LastError   string `json:"last_error,omitempty"`
+		CreatedAt   string `json:"created_at"`
+		CompletedAt string `json:"completed_at,omitempty"`
+	}
+
+	JobPaginationResponse struct {
+		Data []JobResponse `json:"data"`
+		PaginationResponse
+	}
+
+	ReportExportJob struct {
+		ExportID string `json:"export_id"`
+	}
+
+	GetAllJobRepositoryResponse struct {
+		Jobs []entity.Job
+		PaginationResponse
+	}
+)
+
+func (r JobListRequest) Validate() error {
+	switch entity.JobStatus(r.Status) {
+	case "", entity.JobPending, entity.JobRunning, entity.JobCompleted, entity.JobDead:
+		return nil
+	}
+	return ErrInvalidJobStatus
+}
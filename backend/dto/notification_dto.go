@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_NOTIFICATIONS              = "gagal mendapatkan notifikasi"
+	MESSAGE_FAILED_COUNT_UNREAD_NOTIFICATIONS     = "gagal menghitung notifikasi yang belum dibaca"
+	MESSAGE_FAILED_READ_NOTIFICATION              = "gagal menandai notifikasi sebagai dibaca"
+	MESSAGE_FAILED_GET_NOTIFICATION_PREFERENCES   = "gagal mendapatkan preferensi notifikasi"
+	MESSAGE_FAILED_UPDATE_NOTIFICATION_PREFERENCE = "gagal memperbarui preferensi notifikasi"
+
+	// Success
+	MESSAGE_SUCCESS_GET_NOTIFICATIONS              = "berhasil mendapatkan notifikasi"
+	MESSAGE_SUCCESS_COUNT_UNREAD_NOTIFICATIONS     = "berhasil menghitung notifikasi yang belum dibaca"
+	MESSAGE_SUCCESS_READ_NOTIFICATION              = "berhasil menandai notifikasi sebagai dibaca"
+	MESSAGE_SUCCESS_GET_NOTIFICATION_PREFERENCES   = "berhasil mendapatkan preferensi notifikasi"
+	MESSAGE_SUCCESS_UPDATE_NOTIFICATION_PREFERENCE = "berhasil memperbarui preferensi notifikasi"
+)
+
+var (
+	ErrGetNotifications             = errors.New("gagal mendapatkan notifikasi")
+	ErrNotificationNotFound         = errors.New("notifikasi tidak ditemukan")
+	ErrReadNotification             = errors.New("gagal menandai notifikasi sebagai dibaca")
+	ErrUpdateNotificationPreference = errors.New("gagal memperbarui preferensi notifikasi")
+)
+
+type (
+	NotificationListRequest struct {
+		PaginationRequest
+		UnreadOnly bool `form:"unread_only"`
+	}
+
+	// NotificationMessage is what other services hand to Notify; the
+	// notification service decides which channels it goes out on.
+	NotificationMessage struct {
+		Type     entity.NotificationType
+		Title    string
+		Body     string
+		ReportID string
+	}
+
+	NotificationResponse struct {
+		ID        string `json:"id"`
+		Type      string `json:"type"`
+		Title     string `json:"title"`
+		Body      string `json:"body"`
+		ReportID  string `json:"report_id,omitempty"`
+		IsRead    bool   `json:"is_read"`
+		ReadAt    string `json:"read_at,omitempty"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	NotificationPaginationResponse struct {
+		Data []NotificationResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllNotificationRepositoryResponse struct {
+		Notifications []entity.Notification
+		PaginationResponse
+	}
+
+	UnreadNotificationCountResponse struct {
+		Unread int64 `json:"unread"`
+	}
+
+	ReadAllNotificationResponse struct {
+		Updated int64 `json:"updated"`
+	}
+
+	// NotificationPreferencesRequest is a partial update; omitted fields keep
+	// their current value.
+	NotificationPreferencesRequest struct {
+		InApp *bool `json:"in_app" form:"in_app"`
+		Email *bool `json:"email" form:"email"`
+		Push  *bool `json:"push" form:"push"`
+	}
+
+	NotificationPreferencesResponse struct {
+		InApp bool `json:"in_app"`
+		Email bool `json:"email"`
+		Push  bool `json:"push"`
+	}
+)
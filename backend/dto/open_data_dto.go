@@ -0,0 +1,62 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_OPEN_DATA = "gagal mendapatkan data terbuka"
+
+	// Success
+	MESSAGE_SUCCESS_GET_OPEN_DATA = "berhasil mendapatkan data terbuka"
+
+	OPEN_DATA_FILE_CSV      = "reports.csv"
+	OPEN_DATA_FILE_JSON     = "reports.json"
+	OPEN_DATA_FILE_SCHEMA   = "schema.json"
+	OPEN_DATA_FILE_METADATA = "metadata.json"
+	OPEN_DATA_LATEST        = "latest"
+)
+
+var (
+	ErrGenerateOpenData     = errors.New("gagal membuat snapshot data terbuka")
+	ErrOpenDataNotFound     = errors.New("snapshot data terbuka tidak ditemukan")
+	ErrInvalidOpenDataFile  = errors.New("berkas data terbuka tidak valid")
+	ErrGetOpenDataSnapshots = errors.New("gagal mendapatkan daftar snapshot data terbuka")
+)
+
+type (
+	// OpenDataRecord is one published report. It deliberately has no
+	// report or user identifiers and only a coarse location.
+	OpenDataRecord struct {
+		CreatedDate string   `json:"created_date"`
+		Status      string   `json:"status"`
+		Class       string   `json:"class"`
+		GridLat     *float64 `json:"grid_lat"`
+		GridLng     *float64 `json:"grid_lng"`
+		Upvotes     int      `json:"upvotes"`
+		Text        *string  `json:"text,omitempty"`
+	}
+
+	OpenDataSnapshotResponse struct {
+		Version         string            `json:"version"`
+		GeneratedAt     string            `json:"generated_at"`
+		RecordCount     int               `json:"record_count"`
+		SuppressedCount int               `json:"suppressed_count"`
+		GridDegrees     float64           `json:"grid_degrees"`
+		K               int               `json:"k"`
+		IncludesText    bool              `json:"includes_text"`
+		Files           map[string]string `json:"files"`
+	}
+
+	TableSchemaField struct {
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Format      string `json:"format,omitempty"`
+		Description string `json:"description"`
+	}
+
+	// TableSchema follows the Frictionless Data table schema spec.
+	TableSchema struct {
+		Fields       []TableSchemaField `json:"fields"`
+		MissingValue []string           `json:"missingValues"`
+	}
+)
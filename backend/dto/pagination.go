@@ -1,17 +1,50 @@
 package dto
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	PAGING_OFFSET = "offset"
+	PAGING_CURSOR = "cursor"
+)
+
+var (
+	ErrInvalidCursor     = errors.New("cursor tidak valid")
+	ErrInvalidCursorSort = errors.New("pagination cursor hanya mendukung urutan created_at")
+)
+
 type (
 	PaginationRequest struct {
 		Search  string `form:"search"`
 		Page    int    `form:"page"`
 		PerPage int    `form:"per_page"`
+
+		// Keyset pagination. Paging=cursor (or any non-empty Cursor) switches
+		// from OFFSET/LIMIT to seeking on (created_at, id).
+		Paging       string `form:"paging"`
+		Cursor       string `form:"cursor"`
+		IncludeCount *bool  `form:"include_count"`
 	}
 
 	PaginationResponse struct {
-		Page    int   `json:"page"`
-		PerPage int   `json:"per_page"`
-		MaxPage int64 `json:"max_page"`
-		Count   int64 `json:"count"`
+		Page       int    `json:"page"`
+		PerPage    int    `json:"per_page"`
+		MaxPage    int64  `json:"max_page"`
+		Count      int64  `json:"count"`
+		NextCursor string `json:"next_cursor,omitempty"`
+		PrevCursor string `json:"prev_cursor,omitempty"`
+	}
+
+	// Cursor is the decoded form of the opaque cursor string handed to
+	// clients. Prev marks a cursor that pages backwards from its position.
+	Cursor struct {
+		CreatedAt time.Time `json:"t"`
+		ID        string    `json:"id"`
+		Prev      bool      `json:"p,omitempty"`
 	}
 )
 
@@ -35,4 +68,41 @@ func (p *PaginationRequest) Default() {
 	if p.PerPage == 0 {
 		p.PerPage = 10
 	}
-}
\ No newline at end of file
+}
+
+func (p *PaginationRequest) IsCursor() bool {
+	return p.Paging == PAGING_CURSOR || p.Cursor != ""
+}
+
+// WantsCount reports whether the total count should be computed. Offset
+// pagination counts by default, cursor pagination does not.
+func (p *PaginationRequest) WantsCount() bool {
+	if p.IncludeCount != nil {
+		return *p.IncludeCount
+	}
+	return !p.IsCursor()
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor string. An empty string is the first page.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
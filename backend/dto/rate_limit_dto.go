@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_RATE_LIMITED = "terlalu banyak permintaan"
+)
+
+var (
+	ErrRateLimited = errors.New("terlalu banyak permintaan, silakan coba lagi nanti")
+)
+
+type (
+	// TokenBucket is the state of one bucket: the tokens it held when it
+	// was last touched.
+	TokenBucket struct {
+		Tokens    float64
+		UpdatedAt time.Time
+	}
+
+	RateLimitResult struct {
+		Allowed   bool
+		Limit     int
+		Remaining int
+		// Reset is how long until the bucket is full again.
+		Reset time.Duration
+		// RetryAfter is how long until the next request would be allowed;
+		// zero when this one was.
+		RetryAfter time.Duration
+	}
+)
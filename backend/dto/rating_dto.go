@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	RATING_MIN_SCORE = 1
+	RATING_MAX_SCORE = 5
+	// RATING_REMINDER_DELAY is how long after completion an unrated report
+	// gets its one reminder.
+	RATING_REMINDER_DELAY      = 3 * 24 * time.Hour
+	RATING_REMINDER_BATCH_SIZE = 100
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_RATE_REPORT      = "gagal memberi penilaian laporan"
+	MESSAGE_FAILED_GET_RATING       = "gagal mendapatkan penilaian laporan"
+	MESSAGE_FAILED_GET_SATISFACTION = "gagal mendapatkan skor kepuasan"
+
+	// Success
+	MESSAGE_SUCCESS_RATE_REPORT      = "berhasil memberi penilaian laporan"
+	MESSAGE_SUCCESS_GET_RATING       = "berhasil mendapatkan penilaian laporan"
+	MESSAGE_SUCCESS_GET_SATISFACTION = "berhasil mendapatkan skor kepuasan"
+)
+
+var (
+	ErrRateReport         = errors.New("gagal memberi penilaian laporan")
+	ErrReportNotCompleted = errors.New("hanya laporan selesai yang dapat dinilai")
+	ErrReportAlreadyRated = errors.New("laporan sudah dinilai")
+	ErrRatingNotFound     = errors.New("penilaian laporan tidak ditemukan")
+	ErrNotReportReporter  = errors.New("hanya pelapor yang dapat menilai laporan")
+	ErrGetSatisfaction    = errors.New("gagal mendapatkan skor kepuasan")
+)
+
+type (
+	RateReportRequest struct {
+		Score   int    `json:"score" form:"score" binding:"required,min=1,max=5"`
+		Comment string `json:"comment" form:"comment" binding:"max=1000"`
+	}
+
+	RatingResponse struct {
+		ID        string `json:"id"`
+		ReportID  string `json:"report_id"`
+		Score     int    `json:"score"`
+		Comment   string `json:"comment,omitempty"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	// SatisfactionRequest defaults to the last 90 days, one quarter.
+	SatisfactionRequest struct {
+		From time.Time `form:"from" time_format:"2006-01-02"`
+		To   time.Time `form:"to" time_format:"2006-01-02"`
+	}
+
+	SatisfactionCount struct {
+		Key   string
+		Score int
+		Count int64
+	}
+
+	// SatisfactionScore summarises the ratings of one group. Distribution
+	// holds the number of ratings for scores 1 to 5.
+	SatisfactionScore struct {
+		Key          string   `json:"key"`
+		Average      *float64 `json:"average"`
+		Count        int64    `json:"count"`
+		Distribution []int64  `json:"distribution"`
+	}
+
+	SatisfactionResponse struct {
+		From       string              `json:"from"`
+		To         string              `json:"to"`
+		Overall    SatisfactionScore   `json:"overall"`
+		ByClass    []SatisfactionScore `json:"by_class"`
+		ByLocation []SatisfactionScore `json:"by_location"`
+	}
+)
@@ -3,6 +3,8 @@ package dto
 import (
 	"errors"
 	"mime/multipart"
+	"strings"
+	"time"
 
 	"github.com/Caknoooo/go-gin-clean-starter/entity"
 )
@@ -14,12 +16,20 @@ const (
 	MESSAGE_FAILED_GET_REPORT_BY_ID       = "gagal mendapatkan laporan dari id"
 	MESSAGE_FAILED_GET_REPORTS_BY_USER_ID = "gagal mendapatkan laporan berdasarkan user id"
 	MESSAGE_FAILED_DENIED                 = "akses ditolak"
+	MESSAGE_FAILED_GET_REPORT_HISTORY     = "gagal mendapatkan riwayat laporan"
+	MESSAGE_FAILED_ASSIGN_REPORT          = "gagal menugaskan laporan"
+	MESSAGE_FAILED_UNASSIGN_REPORT        = "gagal mencabut penugasan laporan"
+	MESSAGE_FAILED_GET_ASSIGNMENTS        = "gagal mendapatkan daftar tugas"
 
 	// Success
 	MESSAGE_SUCCESS_SEND_REPORT            = "berhasil mengirim laporan"
 	MESSAGE_SUCCESS_GET_REPORTS            = "berhasil mendapatkan laporan"
 	MESSAGE_SUCCESS_GET_REPORT_BY_ID       = "berhasil mendapatkan laporan dari id"
 	MESSAGE_SUCCESS_GET_REPORTS_BY_USER_ID = "berhasil mendapatkan laporan berdasarkan user id"
+	MESSAGE_SUCCESS_GET_REPORT_HISTORY     = "berhasil mendapatkan riwayat laporan"
+	MESSAGE_SUCCESS_ASSIGN_REPORT          = "berhasil menugaskan laporan"
+	MESSAGE_SUCCESS_UNASSIGN_REPORT        = "berhasil mencabut penugasan laporan"
+	MESSAGE_SUCCESS_GET_ASSIGNMENTS        = "berhasil mendapatkan daftar tugas"
 )
 
 var (
@@ -34,38 +44,93 @@ var (
 	ErrGetReportById         = errors.New("gagal mendapatkan laporan dari id")
 	ErrUpdateReportStatus    = errors.New("gagal memperbarui status laporan")
 	ErrUpdateReportInference = errors.New("gagal memperbarui inferensi laporan")
+	ErrInvalidReportStatus   = errors.New("status laporan tidak valid")
+	ErrInvalidReportSort     = errors.New("urutan laporan tidak valid")
+	ErrInvalidDateRange      = errors.New("rentang tanggal tidak valid")
+	ErrGetReportHistory      = errors.New("gagal mendapatkan riwayat laporan")
+	ErrInvalidCoordinates    = errors.New("koordinat tidak valid")
+	ErrStatusChangeForbidden = errors.New("anda tidak berhak mengubah status laporan ini")
+	ErrAssignReport          = errors.New("gagal menugaskan laporan")
+	ErrReportNotAssignable   = errors.New("hanya laporan terverifikasi atau sedang ditangani yang dapat ditugaskan")
+	ErrReportNotAssigned     = errors.New("laporan belum ditugaskan")
+	ErrAssigneeNotOfficer    = errors.New("pengguna bukan petugas")
+	ErrInvalidDueDate        = errors.New("tenggat harus di masa depan")
 
 // ErrCreateUser             = errors.New("failed to create user")
 )
 
 type (
 	CreateReportRequest struct {
-		Text     string                `json:"text" form:"text"`
-		Location string                `json:"location" form:"location"`
-		Image    *multipart.FileHeader `json:"image" form:"image"`
+		Text      string                `json:"text" form:"text"`
+		Location  string                `json:"location" form:"location"`
+		Latitude  *float64              `json:"latitude" form:"latitude"`
+		Longitude *float64              `json:"longitude" form:"longitude"`
+		Image     *multipart.FileHeader `json:"image" form:"image"`
 	}
 	CreateReportResponse struct {
-		ID       string `json:"id"`
-		Text     string `json:"text"`
-		Image    string `json:"image"`
-		Location string `json:"location"`
+		ID        string   `json:"id"`
+		Text      string   `json:"text"`
+		Image     string   `json:"image"`
+		Location  string   `json:"location"`
+		Latitude  *float64 `json:"latitude,omitempty"`
+		Longitude *float64 `json:"longitude,omitempty"`
+		Status    string   `json:"status"`
 	}
 
 	ReportResponse struct {
-		ID             string      `json:"id"`
-		Text           string      `json:"text"`
-		Image          string      `json:"image"`
-		Location       string      `json:"location"`
-		Status         string      `json:"status"`
-		Upvotes        int         `json:"upvotes"`
-		ShareCount     int         `json:"share_count"`
-		TagID          string      `json:"tag_id"`
-		UserID         string      `json:"user_id"`
-		Username       string      `json:"username"`
-		PredConfidence int         `json:"pred_confidence"`
-		CreatedAt      string      `json:"created_at"`
-		User           entity.User `json:"user,omitempty"`
-		Tag            entity.Tag  `json:"tag,omitempty"`
+		ID             string   `json:"id"`
+		Text           string   `json:"text"`
+		Image          string   `json:"image"`
+		Location       string   `json:"location"`
+		Latitude       *float64 `json:"latitude"`
+		Longitude      *float64 `json:"longitude"`
+		Status         string   `json:"status"`
+		Upvotes        int      `json:"upvotes"`
+		ShareCount     int      `json:"share_count"`
+		TagID          string   `json:"tag_id"`
+		UserID         string   `json:"user_id"`
+		Username       string   `json:"username"`
+		PredConfidence int      `json:"pred_confidence"`
+		CreatedAt      string   `json:"created_at"`
+		// Highlight is the text around the search match, HTML-escaped with
+		// the matches wrapped in <mark>, so it is safe to render as HTML.
+		Highlight     string      `json:"highlight,omitempty"`
+		AgencyID      string      `json:"agency_id,omitempty"`
+		AssigneeID    string      `json:"assignee_id,omitempty"`
+		DueAt         string      `json:"due_at,omitempty"`
+		SLADueAt      string      `json:"sla_due_at,omitempty"`
+		RejectionCode string      `json:"rejection_code,omitempty"`
+		Overdue       bool        `json:"overdue"`
+		VoteFlagged   bool        `json:"vote_flagged"`
+		User          entity.User `json:"user,omitempty"`
+		Tag           entity.Tag  `json:"tag,omitempty"`
+	}
+
+	// ReportFilterRequest is the single query object behind every report
+	// listing. Zero values mean "no filter".
+	ReportFilterRequest struct {
+		PaginationRequest
+		Status      []string  `form:"status"`
+		Class       string    `form:"class"`
+		TagID       string    `form:"tag_id"`
+		UserID      string    `form:"user_id"`
+		AssigneeID  string    `form:"assignee_id"`
+		AgencyID    string    `form:"agency_id"`
+		Overdue     bool      `form:"overdue"`
+		Location    string    `form:"location"`
+		CreatedFrom time.Time `form:"created_from" time_format:"2006-01-02"`
+		CreatedTo   time.Time `form:"created_to" time_format:"2006-01-02"`
+		MinUpvotes  int       `form:"min_upvotes"`
+		VoteFlagged bool      `form:"vote_flagged"`
+		SortBy      string    `form:"sort_by"`
+		SortDir     string    `form:"sort_dir"`
+
+		// AgencyIDs limits agency staff to their own agencies. It is set by
+		// the server, never bound from the query.
+		AgencyIDs []string `form:"-" json:"-"`
+		// IncludeHeld lists reports pending review, which are otherwise
+		// hidden. It is set by the server for admins.
+		IncludeHeld bool `form:"-" json:"-"`
 	}
 
 	ReportPaginationResponse struct {
@@ -78,8 +143,16 @@ type (
 		PaginationResponse
 	}
 
+	// UpdateStatusReportRequest is sent as multipart when completing a
+	// report: the "after" photos and note are required then, the geotag is
+	// optional. Rejecting requires a RejectionReason code.
 	UpdateStatusReportRequest struct {
-		Status entity.ReportStatus `json:"status"`
+		Status     entity.ReportStatus     `json:"status" form:"status"`
+		ReasonCode string                  `json:"reason_code" form:"reason_code"`
+		Note       string                  `json:"note" form:"note" binding:"max=2000"`
+		Photos     []*multipart.FileHeader `json:"-" form:"photos"`
+		Latitude   *float64                `json:"latitude" form:"latitude"`
+		Longitude  *float64                `json:"longitude" form:"longitude"`
 	}
 
 	UpdateStatusReportResponse struct {
@@ -87,17 +160,40 @@ type (
 		Status entity.ReportStatus `json:"status"`
 	}
 
+	AssignReportRequest struct {
+		OfficerID string    `json:"officer_id" form:"officer_id" binding:"required,uuid"`
+		DueAt     time.Time `json:"due_at" form:"due_at" binding:"required"`
+	}
+
+	AssignmentResponse struct {
+		ReportID   string              `json:"report_id"`
+		AssigneeID string              `json:"assignee_id,omitempty"`
+		DueAt      string              `json:"due_at,omitempty"`
+		Status     entity.ReportStatus `json:"status"`
+	}
+
+	ReportHistoryResponse struct {
+		ID         string `json:"id"`
+		Event      string `json:"event"`
+		FromStatus string `json:"from_status,omitempty"`
+		ToStatus   string `json:"to_status,omitempty"`
+		ActorID    string `json:"actor_id,omitempty"`
+		Note       string `json:"note,omitempty"`
+		CreatedAt  string `json:"created_at"`
+	}
+
 	StatusCount struct {
 		Status string
 		Count  int64
 	}
 	CountReportResponse struct {
-		Total      int64 `json:"total"`
-		Unverified int64 `json:"unverified"`
-		Verified   int64 `json:"verified"`
-		Rejected   int64 `json:"rejected"`
-		Handled    int64 `json:"handled"`
-		Completed  int64 `json:"completed"`
+		Total         int64 `json:"total"`
+		PendingReview int64 `json:"pending_review"`
+		Unverified    int64 `json:"unverified"`
+		Verified      int64 `json:"verified"`
+		Rejected      int64 `json:"rejected"`
+		Handled       int64 `json:"handled"`
+		Completed     int64 `json:"completed"`
 	}
 	InferenceRequest struct {
 		ReportID string `json:"report_id"`
@@ -113,3 +209,78 @@ type (
 		Data []InferenceTag `json:"data"`
 	}
 )
+
+const (
+	REPORT_SORT_CREATED_AT = "created_at"
+	REPORT_SORT_UPVOTES    = "upvotes"
+	REPORT_SORT_CONFIDENCE = "confidence"
+	REPORT_SORT_RELEVANCE  = "relevance"
+	REPORT_SORT_DUE_AT     = "due_at"
+	REPORT_SORT_ASC        = "asc"
+	REPORT_SORT_DESC       = "desc"
+)
+
+// Statuses flattens the status filter so both `status=a&status=b` and
+// `status=a,b` are accepted.
+func (f *ReportFilterRequest) Statuses() []entity.ReportStatus {
+	var statuses []entity.ReportStatus
+	for _, raw := range f.Status {
+		for _, status := range strings.Split(raw, ",") {
+			status = strings.TrimSpace(status)
+			if status != "" {
+				statuses = append(statuses, entity.ReportStatus(status))
+			}
+		}
+	}
+	return statuses
+}
+
+func (f *ReportFilterRequest) Default() {
+	f.PaginationRequest.Default()
+
+	if f.SortBy == "" {
+		if f.Search != "" && !f.IsCursor() {
+			f.SortBy = REPORT_SORT_RELEVANCE
+		} else {
+			f.SortBy = REPORT_SORT_CREATED_AT
+		}
+	}
+
+	if f.SortDir == "" {
+		f.SortDir = REPORT_SORT_DESC
+	}
+}
+
+func (f *ReportFilterRequest) Validate() error {
+	for _, status := range f.Statuses() {
+		if !status.IsValid() {
+			return ErrInvalidReportStatus
+		}
+	}
+
+	switch f.SortBy {
+	case "", REPORT_SORT_CREATED_AT, REPORT_SORT_UPVOTES, REPORT_SORT_CONFIDENCE, REPORT_SORT_RELEVANCE, REPORT_SORT_DUE_AT:
+	default:
+		return ErrInvalidReportSort
+	}
+
+	switch strings.ToLower(f.SortDir) {
+	case "", REPORT_SORT_ASC, REPORT_SORT_DESC:
+	default:
+		return ErrInvalidReportSort
+	}
+
+	if f.IsCursor() && f.SortBy != "" && f.SortBy != REPORT_SORT_CREATED_AT {
+		return ErrInvalidCursorSort
+	}
+
+	if _, err := DecodeCursor(f.Cursor); err != nil {
+		return err
+	}
+
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && f.CreatedTo.Before(f.CreatedFrom) {
+		return ErrInvalidDateRange
+	}
+
+	return nil
+}
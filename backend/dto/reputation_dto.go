@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+)
+
+// Points each ledger reason is worth.
+const (
+	REPUTATION_POINTS_VERIFIED  = 10
+	REPUTATION_POINTS_COMPLETED = 20
+	REPUTATION_POINTS_UPVOTED   = 1
+	REPUTATION_POINTS_SPAM      = -25
+)
+
+const (
+	LEADERBOARD_WEEKLY   = "weekly"
+	LEADERBOARD_MONTHLY  = "monthly"
+	LEADERBOARD_ALL_TIME = "all_time"
+
+	LEADERBOARD_DEFAULT_LIMIT = 10
+	LEADERBOARD_MAX_LIMIT     = 100
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_REPUTATION  = "gagal mendapatkan reputasi"
+	MESSAGE_FAILED_GET_LEADERBOARD = "gagal mendapatkan papan peringkat"
+	MESSAGE_FAILED_UPVOTE_REPORT   = "gagal mendukung laporan"
+
+	// Success
+	MESSAGE_SUCCESS_GET_REPUTATION  = "berhasil mendapatkan reputasi"
+	MESSAGE_SUCCESS_GET_LEADERBOARD = "berhasil mendapatkan papan peringkat"
+	MESSAGE_SUCCESS_UPVOTE_REPORT   = "berhasil mendukung laporan"
+	MESSAGE_SUCCESS_REMOVE_UPVOTE   = "berhasil membatalkan dukungan laporan"
+)
+
+var (
+	ErrGetReputation           = errors.New("gagal mendapatkan reputasi")
+	ErrGetLeaderboard          = errors.New("gagal mendapatkan papan peringkat")
+	ErrInvalidLeaderboardRange = errors.New("periode papan peringkat harus weekly, monthly atau all_time")
+	ErrUpvoteReport            = errors.New("gagal mendukung laporan")
+	ErrUpvoteOwnReport         = errors.New("pelapor tidak dapat mendukung laporannya sendiri")
+)
+
+type (
+	// ReputationReasonCount sums the ledger entries of one reason.
+	ReputationReasonCount struct {
+		Reason entity.ReputationReason
+		Count  int64
+		Points int64
+	}
+
+	BadgeResponse struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}
+
+	ReputationResponse struct {
+		Score  int64           `json:"score"`
+		Badges []BadgeResponse `json:"badges"`
+	}
+
+	ReputationEntryResponse struct {
+		ID        string `json:"id"`
+		ReportID  string `json:"report_id,omitempty"`
+		Reason    string `json:"reason"`
+		Points    int    `json:"points"`
+		Region    string `json:"region,omitempty"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	ReputationLedgerResponse struct {
+		Score int64                     `json:"score"`
+		Data  []ReputationEntryResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllReputationEntryRepositoryResponse struct {
+		Entries []entity.ReputationEntry
+		PaginationResponse
+	}
+
+	LeaderboardRequest struct {
+		Period string `form:"period"`
+		Region string `form:"region"`
+		Limit  int    `form:"limit"`
+	}
+
+	LeaderboardRow struct {
+		Name  string
+		Score int64
+	}
+
+	// LeaderboardEntry shows display names only, never ids or contacts.
+	LeaderboardEntry struct {
+		Rank  int    `json:"rank"`
+		Name  string `json:"name"`
+		Score int64  `json:"score"`
+	}
+
+	LeaderboardResponse struct {
+		Period  string             `json:"period"`
+		Region  string             `json:"region,omitempty"`
+		Since   string             `json:"since,omitempty"`
+		Entries []LeaderboardEntry `json:"entries"`
+	}
+
+	UpvoteResponse struct {
+		ReportID string `json:"report_id"`
+		Upvotes  int    `json:"upvotes"`
+		Upvoted  bool   `json:"upvoted"`
+	}
+
+	// MeResponse is the signed-in user with their reputation.
+	MeResponse struct {
+		UserResponse
+		Reputation ReputationResponse `json:"reputation"`
+	}
+)
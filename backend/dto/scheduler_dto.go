@@ -0,0 +1,47 @@
+package dto
+
+import "errors"
+
+const (
+	// Task names
+	TASK_PURGE_REFRESH_TOKENS = "purge_refresh_tokens"
+	TASK_OPEN_DATA_SNAPSHOT   = "open_data_snapshot"
+	TASK_SLA_CHECK            = "sla_check"
+	TASK_EXPIRE_COMPLETIONS   = "expire_completions"
+	TASK_RATING_REMINDERS     = "rating_reminders"
+	TASK_PURGE_RATE_LIMITS    = "purge_rate_limits"
+	TASK_PURGE_REPORT_EXPORTS = "purge_report_exports"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_SCHEDULED_TASKS = "gagal mendapatkan daftar tugas terjadwal"
+	MESSAGE_FAILED_RUN_SCHEDULED_TASK  = "gagal menjalankan tugas terjadwal"
+
+	// Success
+	MESSAGE_SUCCESS_GET_SCHEDULED_TASKS = "berhasil mendapatkan daftar tugas terjadwal"
+	MESSAGE_SUCCESS_RUN_SCHEDULED_TASK  = "tugas terjadwal sedang dijalankan"
+)
+
+var (
+	ErrGetScheduledTasks      = errors.New("gagal mendapatkan daftar tugas terjadwal")
+	ErrScheduledTaskNotFound  = errors.New("tugas terjadwal tidak ditemukan")
+	ErrScheduledTaskRunning   = errors.New("tugas terjadwal sedang berjalan")
+	ErrInvalidScheduleSpec    = errors.New("jadwal cron tidak valid")
+	ErrDuplicateScheduledTask = errors.New("tugas terjadwal sudah terdaftar")
+)
+
+type (
+	ScheduledTaskResponse struct {
+		Name            string `json:"name"`
+		Description     string `json:"description"`
+		Schedule        string `json:"schedule"`
+		NextRunAt       string `json:"next_run_at"`
+		LastScheduledAt string `json:"last_scheduled_at,omitempty"`
+		LastStartedAt   string `json:"last_started_at,omitempty"`
+		LastFinishedAt  string `json:"last_finished_at,omitempty"`
+		LastStatus      string `json:"last_status,omitempty"`
+		LastError       string `json:"last_error,omitempty"`
+		LastDurationMs  int64  `json:"last_duration_ms"`
+	}
+)
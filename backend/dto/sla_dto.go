@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	SLA_BREACH_BATCH_SIZE = 100
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_SLA_POLICIES  = "gagal mendapatkan daftar kebijakan SLA"
+	MESSAGE_FAILED_SAVE_SLA_POLICY   = "gagal menyimpan kebijakan SLA"
+	MESSAGE_FAILED_DELETE_SLA_POLICY = "gagal menghapus kebijakan SLA"
+
+	// Success
+	MESSAGE_SUCCESS_GET_SLA_POLICIES  = "berhasil mendapatkan daftar kebijakan SLA"
+	MESSAGE_SUCCESS_SAVE_SLA_POLICY   = "berhasil menyimpan kebijakan SLA"
+	MESSAGE_SUCCESS_DELETE_SLA_POLICY = "berhasil menghapus kebijakan SLA"
+)
+
+var (
+	ErrGetSLAPolicies    = errors.New("gagal mendapatkan daftar kebijakan SLA")
+	ErrSaveSLAPolicy     = errors.New("gagal menyimpan kebijakan SLA")
+	ErrSLAPolicyNotFound = errors.New("kebijakan SLA tidak ditemukan")
+	ErrSLAPolicyStatus   = errors.New("kebijakan SLA hanya untuk status unverified, verified atau handled")
+)
+
+type (
+	// SaveSLAPolicyRequest creates or replaces the policy for a class and
+	// status. Leave the class empty for the default of that status.
+	SaveSLAPolicyRequest struct {
+		Class            string   `json:"class" form:"class" binding:"max=20"`
+		Status           string   `json:"status" form:"status" binding:"required"`
+		DurationMinutes  int      `json:"duration_minutes" form:"duration_minutes" binding:"required,min=1"`
+		SupervisorEmails []string `json:"supervisor_emails" form:"supervisor_emails" binding:"dive,email"`
+	}
+
+	SLAPolicyResponse struct {
+		ID               string   `json:"id"`
+		Class            string   `json:"class"`
+		Status           string   `json:"status"`
+		DurationMinutes  int      `json:"duration_minutes"`
+		SupervisorEmails []string `json:"supervisor_emails"`
+	}
+
+	SLAComplianceCount struct {
+		Status string
+		Met    int64
+		Total  int64
+	}
+
+	SLAComplianceMetric struct {
+		Status  string   `json:"status"`
+		Met     int64    `json:"met"`
+		Total   int64    `json:"total"`
+		Percent *float64 `json:"percent"`
+	}
+
+	SLAEscalationMail struct {
+		ReportID string
+		Class    string
+		Status   string
+		Location string
+		DueAt    time.Time
+	}
+)
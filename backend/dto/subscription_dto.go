@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	SUBSCRIPTION_MIN_RADIUS   = 100
+	SUBSCRIPTION_MAX_RADIUS   = 50000
+	SUBSCRIPTION_MAX_PER_USER = 20
+	SUBSCRIPTION_MAX_CLASSES  = 10
+
+	// Report events subscriptions are matched on
+	SUBSCRIPTION_EVENT_CREATED   = "created"
+	SUBSCRIPTION_EVENT_VERIFIED  = "verified"
+	SUBSCRIPTION_EVENT_COMPLETED = "completed"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_SUBSCRIPTION = "gagal membuat langganan"
+	MESSAGE_FAILED_GET_SUBSCRIPTIONS   = "gagal mendapatkan daftar langganan"
+	MESSAGE_FAILED_DELETE_SUBSCRIPTION = "gagal menghapus langganan"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_SUBSCRIPTION = "berhasil membuat langganan"
+	MESSAGE_SUCCESS_GET_SUBSCRIPTIONS   = "berhasil mendapatkan daftar langganan"
+	MESSAGE_SUCCESS_DELETE_SUBSCRIPTION = "berhasil menghapus langganan"
+)
+
+var (
+	ErrCreateSubscription     = errors.New("gagal membuat langganan")
+	ErrGetSubscriptions       = errors.New("gagal mendapatkan daftar langganan")
+	ErrSubscriptionNotFound   = errors.New("langganan tidak ditemukan")
+	ErrSubscriptionTarget     = errors.New("isi tag_id atau latitude, longitude dan radius_meters, tidak keduanya")
+	ErrSubscriptionRadius     = errors.New("radius_meters harus antara 100 dan 50000")
+	ErrSubscriptionClasses    = errors.New("terlalu banyak kelas pada langganan")
+	ErrSubscriptionLimit      = errors.New("jumlah langganan sudah mencapai batas")
+	ErrSubscriptionTagMissing = errors.New("tag tidak ditemukan")
+)
+
+type (
+	// CreateSubscriptionRequest follows either a tag or an area, never both.
+	CreateSubscriptionRequest struct {
+		Label        string   `json:"label" form:"label" binding:"max=100"`
+		TagID        string   `json:"tag_id" form:"tag_id" binding:"omitempty,uuid"`
+		Latitude     *float64 `json:"latitude" form:"latitude"`
+		Longitude    *float64 `json:"longitude" form:"longitude"`
+		RadiusMeters int      `json:"radius_meters" form:"radius_meters"`
+		Classes      []string `json:"classes" form:"classes"`
+	}
+
+	SubscriptionResponse struct {
+		ID           string   `json:"id"`
+		Kind         string   `json:"kind"`
+		Label        string   `json:"label"`
+		TagID        string   `json:"tag_id,omitempty"`
+		TagLocation  string   `json:"tag_location,omitempty"`
+		Latitude     *float64 `json:"latitude,omitempty"`
+		Longitude    *float64 `json:"longitude,omitempty"`
+		RadiusMeters *int     `json:"radius_meters,omitempty"`
+		Classes      []string `json:"classes"`
+		CreatedAt    string   `json:"created_at"`
+	}
+
+	MatchSubscriptionsJob struct {
+		ReportID string `json:"report_id"`
+		Event    string `json:"event"`
+	}
+)
+
+func (r CreateSubscriptionRequest) Validate() error {
+	hasArea := r.Latitude != nil || r.Longitude != nil || r.RadiusMeters != 0
+	if (r.TagID != "") == hasArea {
+		return ErrSubscriptionTarget
+	}
+
+	if hasArea {
+		if r.Latitude == nil || r.Longitude == nil ||
+			*r.Latitude < -90 || *r.Latitude > 90 || *r.Longitude < -180 || *r.Longitude > 180 {
+			return ErrInvalidCoordinates
+		}
+		if r.RadiusMeters < SUBSCRIPTION_MIN_RADIUS || r.RadiusMeters > SUBSCRIPTION_MAX_RADIUS {
+			return ErrSubscriptionRadius
+		}
+	}
+
+	if len(r.Classes) > SUBSCRIPTION_MAX_CLASSES {
+		return ErrSubscriptionClasses
+	}
+
+	return nil
+}
+
+// NormalizedClasses trims and dedupes the class filter. Class names are
+// compared as the inference service returns them, so case is kept.
+func (r CreateSubscriptionRequest) NormalizedClasses() []string {
+	seen := make(map[string]bool, len(r.Classes))
+	classes := make([]string, 0, len(r.Classes))
+	for _, class := range r.Classes {
+		class = strings.TrimSpace(class)
+		if class == "" || strings.Contains(class, ",") || seen[class] {
+			continue
+		}
+		seen[class] = true
+		classes = append(classes, class)
+	}
+	return classes
+}
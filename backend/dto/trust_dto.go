@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Trust scores run from 0 to 100. Below TRUST_LOW_BELOW a user is low
+	// trust, from TRUST_HIGH_FROM high trust, normal in between.
+	TRUST_LOW_BELOW = 30
+	TRUST_HIGH_FROM = 70
+
+	// TRUST_QUOTA_WINDOW is the rolling window report quotas count in.
+	TRUST_QUOTA_WINDOW = 24 * time.Hour
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_TRUST      = "gagal mendapatkan tingkat kepercayaan pengguna"
+	MESSAGE_FAILED_SET_TRUST      = "gagal mengubah tingkat kepercayaan pengguna"
+	MESSAGE_FAILED_GET_HELD       = "gagal mendapatkan laporan yang ditahan"
+	MESSAGE_FAILED_RELEASE_REPORT = "gagal merilis laporan"
+	MESSAGE_FAILED_CLEAR_TRUST    = "gagal menghapus penyesuaian tingkat kepercayaan"
+
+	// Success
+	MESSAGE_SUCCESS_GET_TRUST      = "berhasil mendapatkan tingkat kepercayaan pengguna"
+	MESSAGE_SUCCESS_SET_TRUST      = "berhasil mengubah tingkat kepercayaan pengguna"
+	MESSAGE_SUCCESS_GET_HELD       = "berhasil mendapatkan laporan yang ditahan"
+	MESSAGE_SUCCESS_RELEASE_REPORT = "berhasil merilis laporan"
+	MESSAGE_SUCCESS_CLEAR_TRUST    = "berhasil menghapus penyesuaian tingkat kepercayaan"
+)
+
+var (
+	ErrGetTrust            = errors.New("gagal mendapatkan tingkat kepercayaan pengguna")
+	ErrSetTrust            = errors.New("gagal mengubah tingkat kepercayaan pengguna")
+	ErrInvalidTrustLevel   = errors.New("tingkat kepercayaan harus low, normal atau high")
+	ErrReportQuotaExceeded = errors.New("batas jumlah laporan harian tercapai, coba lagi nanti")
+	ErrReportCooldown      = errors.New("tunggu sebentar sebelum mengirim laporan berikutnya")
+	ErrReportNotHeld       = errors.New("laporan tidak sedang ditahan untuk ditinjau")
+	ErrReportHeld          = errors.New("laporan sedang ditahan untuk ditinjau")
+	ErrReleaseReport       = errors.New("gagal merilis laporan")
+)
+
+type (
+	// TrustInput is everything a trust score is computed from.
+	TrustInput struct {
+		EmailVerified bool
+		AccountAge    time.Duration
+		Record        VoterRecord
+	}
+
+	// TrustPolicy is what a trust level allows when creating reports.
+	TrustPolicy struct {
+		MaxReportsPerDay int           `json:"max_reports_per_day"`
+		Cooldown         time.Duration `json:"-"`
+		CooldownSeconds  int64         `json:"cooldown_seconds"`
+		HoldForReview    bool          `json:"hold_for_review"`
+	}
+
+	// ReportActivity is how many reports a user filed in the quota window
+	// and when they filed the last one.
+	ReportActivity struct {
+		Count  int64
+		LastAt *time.Time
+	}
+
+	SetTrustRequest struct {
+		Level string `json:"level" form:"level" binding:"required"`
+		Note  string `json:"note" form:"note" binding:"max=500"`
+	}
+
+	TrustResponse struct {
+		UserID        string      `json:"user_id"`
+		Score         int         `json:"score"`
+		ComputedLevel string      `json:"computed_level"`
+		Override      string      `json:"override,omitempty"`
+		OverrideNote  string      `json:"override_note,omitempty"`
+		Level         string      `json:"level"`
+		Policy        TrustPolicy `json:"policy"`
+		ReportsToday  int64       `json:"reports_today"`
+		Accepted      int64       `json:"accepted_reports"`
+		Rejected      int64       `json:"rejected_reports"`
+		Upvotes       int64       `json:"upvotes_received"`
+	}
+)
@@ -21,6 +21,7 @@ const (
 	MESSAGE_FAILED_PROSES_REQUEST     = "failed proses request"
 	MESSAGE_FAILED_DENIED_ACCESS      = "denied access"
 	MESSAGE_FAILED_VERIFY_EMAIL       = "failed verify email"
+	MESSAGE_FAILED_UPDATE_ROLE        = "failed update role"
 
 	// Success
 	MESSAGE_SUCCESS_REGISTER_USER           = "success create user"
@@ -31,6 +32,7 @@ const (
 	MESSAGE_SUCCESS_DELETE_USER             = "success delete user"
 	MESSAGE_SEND_VERIFICATION_EMAIL_SUCCESS = "success send verification email"
 	MESSAGE_SUCCESS_VERIFY_EMAIL            = "success verify email"
+	MESSAGE_SUCCESS_UPDATE_ROLE             = "success update role"
 )
 
 var (
@@ -96,6 +98,10 @@ type (
 		Email string `json:"email" form:"email" binding:"required"`
 	}
 
+	UpdateUserRoleRequest struct {
+		Role string `json:"role" form:"role" binding:"required,oneof=user officer admin"`
+	}
+
 	VerifyEmailRequest struct {
 		Token string `json:"token" form:"token" binding:"required"`
 	}
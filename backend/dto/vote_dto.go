@@ -0,0 +1,54 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_VOTE_REPORT = "gagal memberi suara pada laporan"
+	MESSAGE_FAILED_GET_VOTES   = "gagal mendapatkan suara laporan"
+
+	// Success
+	MESSAGE_SUCCESS_VOTE_REPORT = "berhasil memberi suara pada laporan"
+	MESSAGE_SUCCESS_GET_VOTES   = "berhasil mendapatkan suara laporan"
+)
+
+var (
+	ErrVoteReport          = errors.New("gagal memberi suara pada laporan")
+	ErrGetVotes            = errors.New("gagal mendapatkan suara laporan")
+	ErrInvalidVoteKind     = errors.New("jenis suara harus confirm atau dispute")
+	ErrVoterNotVerified    = errors.New("hanya pengguna terverifikasi yang dapat memberi suara")
+	ErrVoteOwnReport       = errors.New("pelapor tidak dapat memberi suara pada laporannya sendiri")
+	ErrReportNotUnverified = errors.New("hanya laporan yang belum diverifikasi yang dapat diberi suara")
+)
+
+type (
+	VoteReportRequest struct {
+		Kind string `json:"kind" form:"kind" binding:"required"`
+	}
+
+	// VoterRecord is how the reports a user filed turned out: accepted
+	// ones were verified at some point, rejected ones never were. Upvotes
+	// is the support they gathered.
+	VoterRecord struct {
+		Accepted int64
+		Rejected int64
+		Upvotes  int64
+	}
+
+	VoteTally struct {
+		ConfirmWeight float64 `json:"confirm_weight"`
+		DisputeWeight float64 `json:"dispute_weight"`
+		ConfirmCount  int     `json:"confirm_count"`
+		DisputeCount  int     `json:"dispute_count"`
+	}
+
+	ReportVotesResponse struct {
+		ReportID string `json:"report_id"`
+		Status   string `json:"status"`
+		VoteTally
+		Quorum   float64 `json:"quorum"`
+		Flagged  bool    `json:"flagged"`
+		MyVote   string  `json:"my_vote,omitempty"`
+		MyWeight float64 `json:"my_weight,omitempty"`
+	}
+)
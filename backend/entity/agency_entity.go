@@ -0,0 +1,46 @@
+package entity
+
+import "github.com/google/uuid"
+
+type AgencyMemberRole string
+
+const (
+	AgencyStaff      AgencyMemberRole = "staff"
+	AgencySupervisor AgencyMemberRole = "supervisor"
+)
+
+// Agency is a government body reports are routed to, e.g. public works.
+type Agency struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Name        string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+
+	Members []AgencyMember `gorm:"foreignKey:AgencyID" json:"members,omitempty"`
+
+	Timestamp
+}
+
+type AgencyMember struct {
+	AgencyID uuid.UUID        `gorm:"type:uuid;primary_key" json:"agency_id"`
+	UserID   uuid.UUID        `gorm:"type:uuid;primary_key;index" json:"user_id"`
+	Role     AgencyMemberRole `gorm:"type:varchar(20);not null;default:'staff'" json:"role"`
+
+	Agency Agency `gorm:"foreignKey:AgencyID;constraint:OnDelete:CASCADE" json:"-"`
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user"`
+
+	Timestamp
+}
+
+// AgencyRoute sends reports of an inference class to an agency. Region is
+// matched against the report location; an empty region is the fallback
+// for the class.
+type AgencyRoute struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Class    string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_agency_routes_class_region" json:"class"`
+	Region   string    `gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_agency_routes_class_region" json:"region"`
+	AgencyID uuid.UUID `gorm:"type:uuid;not null;index" json:"agency_id"`
+
+	Agency Agency `gorm:"foreignKey:AgencyID;constraint:OnDelete:CASCADE" json:"agency"`
+
+	Timestamp
+}
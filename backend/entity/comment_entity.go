@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CommentVisibility string
+
+const (
+	// CommentPublic is visible to the reporter and every citizen.
+	CommentPublic CommentVisibility = "public"
+	// CommentInternal is only visible to admins.
+	CommentInternal CommentVisibility = "internal"
+)
+
+func (v CommentVisibility) IsValid() bool {
+	return v == CommentPublic || v == CommentInternal
+}
+
+// Comment is a message on a report. Threads are one level deep: a reply
+// always points at the top-level comment it belongs to.
+type Comment struct {
+	ID         uuid.UUID         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReportID   uuid.UUID         `gorm:"type:uuid;not null;index:idx_comments_report_parent" json:"report_id"`
+	ParentID   *uuid.UUID        `gorm:"type:uuid;index:idx_comments_report_parent" json:"parent_id"`
+	UserID     uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	Visibility CommentVisibility `gorm:"type:varchar(20);not null;default:'public'" json:"visibility"`
+	Body       string            `gorm:"type:text;not null" json:"body"`
+	EditedAt   *time.Time        `gorm:"type:timestamp with time zone" json:"edited_at"`
+	// Deleted comments keep their row, without the body, so replies stay
+	// in their thread.
+	DeletedAt *time.Time `gorm:"type:timestamp with time zone" json:"deleted_at"`
+
+	// Set by an admin; hidden bodies are only shown to admins.
+	Hidden           bool       `gorm:"not null;default:false" json:"hidden"`
+	ModeratedBy      *uuid.UUID `gorm:"type:uuid" json:"moderated_by"`
+	ModerationReason string     `gorm:"type:text" json:"moderation_reason"`
+
+	Report Report `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"-"`
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
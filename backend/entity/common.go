@@ -11,5 +11,5 @@ type Timestamp struct {
 
 type Authorization struct {
 	Token string `json:"token" binding:"required"`
-	Role  string `json:"role" binding:"required,oneof=user admin"`
+	Role  string `json:"role" binding:"required,oneof=user officer admin"`
 }
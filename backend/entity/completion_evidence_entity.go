@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CompletionOutcome string
+
+const (
+	// CompletionPending waits for the reporter until ConfirmBy.
+	CompletionPending   CompletionOutcome = "pending"
+	CompletionConfirmed CompletionOutcome = "confirmed"
+	// CompletionDisputed reopened the report.
+	CompletionDisputed CompletionOutcome = "disputed"
+	// CompletionExpired was accepted because the reporter did not respond.
+	CompletionExpired CompletionOutcome = "expired"
+)
+
+// CompletionEvidence is the proof attached when a report is marked
+// completed. A report gets a new one every time it is completed again
+// after a dispute.
+type CompletionEvidence struct {
+	ID       uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReportID uuid.UUID  `gorm:"type:uuid;not null;index" json:"report_id"`
+	ActorID  *uuid.UUID `gorm:"type:uuid" json:"actor_id"`
+	Note     string     `gorm:"type:text;not null" json:"note"`
+	// Comma separated asset paths of the "after" photos.
+	Photos    string   `gorm:"type:text;not null" json:"photos"`
+	Latitude  *float64 `gorm:"type:double precision" json:"latitude"`
+	Longitude *float64 `gorm:"type:double precision" json:"longitude"`
+	// DistanceMeters from the report location, when both are known.
+	DistanceMeters *float64 `gorm:"type:double precision" json:"distance_meters"`
+
+	Outcome       CompletionOutcome `gorm:"type:varchar(20);not null;default:'pending';index" json:"outcome"`
+	ConfirmBy     time.Time         `gorm:"type:timestamp with time zone;not null" json:"confirm_by"`
+	RespondedAt   *time.Time        `gorm:"type:timestamp with time zone" json:"responded_at"`
+	DisputeReason string            `gorm:"type:text" json:"dispute_reason"`
+
+	Report Report `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
+
+func (e CompletionEvidence) PhotoList() []string {
+	if e.Photos == "" {
+		return nil
+	}
+	return strings.Split(e.Photos, ",")
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceToken is a push token for one installation of the mobile app. A
+// user may have several; a token belongs to whoever registered it last.
+type DeviceToken struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Token      string    `gorm:"type:varchar(512);not null;uniqueIndex" json:"token"`
+	Platform   string    `gorm:"type:varchar(20);not null" json:"platform"`
+	LastSeenAt time.Time `gorm:"type:timestamp with time zone;not null" json:"last_seen_at"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	// JobDead jobs used up their attempts and wait for an admin to retry them.
+	JobDead JobStatus = "dead"
+)
+
+// Job is one unit of background work. Workers claim pending jobs whose
+// RunAt has passed with FOR UPDATE SKIP LOCKED, so any number of workers
+// can share the table. A failed attempt goes back to pending with a later
+// RunAt until MaxAttempts is reached.
+type Job struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Type        string     `gorm:"type:varchar(100);not null;index" json:"type"`
+	Payload     string     `gorm:"type:jsonb;not null;default:'{}'" json:"payload"`
+	Status      JobStatus  `gorm:"type:varchar(20);not null;default:'pending';index:idx_jobs_status_run_at" json:"status"`
+	RunAt       time.Time  `gorm:"type:timestamp with time zone;not null;index:idx_jobs_status_run_at" json:"run_at"`
+	Attempts    int        `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int        `gorm:"not null;default:5" json:"max_attempts"`
+	LockedAt    *time.Time `gorm:"type:timestamp with time zone" json:"locked_at,omitempty"`
+	LockedBy    string     `gorm:"type:varchar(100)" json:"locked_by,omitempty"`
+	LastError   string     `gorm:"type:text" json:"last_error,omitempty"`
+	CompletedAt *time.Time `gorm:"type:timestamp with time zone" json:"completed_at,omitempty"`
+
+	Timestamp
+}
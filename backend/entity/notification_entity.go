@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationStatusChanged      NotificationType = "status_changed"
+	NotificationInferenceCompleted NotificationType = "inference_completed"
+	NotificationComment            NotificationType = "comment"
+	NotificationSubscription       NotificationType = "subscription"
+	NotificationAssigned           NotificationType = "assigned"
+	NotificationCompletion         NotificationType = "completion"
+	NotificationDisputed           NotificationType = "disputed"
+	NotificationRatingReminder     NotificationType = "rating_reminder"
+	NotificationAppeal             NotificationType = "appeal"
+	NotificationVoteFlagged        NotificationType = "vote_flagged"
+)
+
+// Notification is a single entry in a user's in-app notification center.
+// ReadAt stays nil until the user opens it.
+type Notification struct {
+	ID       uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID   uuid.UUID        `gorm:"type:uuid;not null;index:idx_notifications_user_read" json:"user_id"`
+	Type     NotificationType `gorm:"type:varchar(50);not null" json:"type"`
+	Title    string           `gorm:"type:varchar(255);not null" json:"title"`
+	Body     string           `gorm:"type:text" json:"body"`
+	ReportID *uuid.UUID       `gorm:"type:uuid;index" json:"report_id,omitempty"`
+	ReadAt   *time.Time       `gorm:"type:timestamp with time zone;index:idx_notifications_user_read" json:"read_at,omitempty"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
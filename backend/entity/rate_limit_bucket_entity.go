@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// RateLimitBucket is the shared state of one token bucket when rate limits
+// are kept in postgres. Key is the policy name followed by what is being
+// limited, e.g. "login:ip:203.0.113.7".
+type RateLimitBucket struct {
+	Key       string    `gorm:"type:varchar(255);primary_key" json:"key"`
+	Tokens    float64   `gorm:"not null" json:"tokens"`
+	UpdatedAt time.Time `gorm:"type:timestamp with time zone;not null;index" json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "github.com/Caknoooo/go-gin-clean-starter/utils"
+
+// RejectionReason is a managed code an admin must pick when rejecting a
+// report. Codes are deactivated rather than deleted so old rejections keep
+// their explanation.
+type RejectionReason struct {
+	Code          string `gorm:"type:varchar(50);primary_key" json:"code"`
+	DescriptionID string `gorm:"type:text;not null" json:"description_id"`
+	DescriptionEN string `gorm:"type:text" json:"description_en"`
+	Active        bool   `gorm:"not null;default:true" json:"active"`
+	// Spam rejections cost the reporter reputation points.
+	Spam bool `gorm:"not null;default:false" json:"spam"`
+
+	Timestamp
+}
+
+// Description falls back to Indonesian when there is no translation.
+func (r RejectionReason) Description(lang string) string {
+	if lang == utils.LANG_EN && r.DescriptionEN != "" {
+		return r.DescriptionEN
+	}
+	return r.DescriptionID
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AppealStatus string
+
+const (
+	AppealPending AppealStatus = "pending"
+	// AppealAccepted reverted the report to unverified.
+	AppealAccepted AppealStatus = "accepted"
+	// AppealUpheld kept the rejection.
+	AppealUpheld AppealStatus = "upheld"
+)
+
+func (s AppealStatus) IsValid() bool {
+	switch s {
+	case AppealPending, AppealAccepted, AppealUpheld:
+		return true
+	}
+	return false
+}
+
+// ReportAppeal is the reporter's single appeal against a rejection.
+type ReportAppeal struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReportID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"report_id"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	Text     string    `gorm:"type:text" json:"text"`
+	// Comma separated asset paths of the supporting images.
+	Images string `gorm:"type:text" json:"images"`
+	// RejectionCode is the code the appeal was filed against.
+	RejectionCode string       `gorm:"type:varchar(50)" json:"rejection_code"`
+	Status        AppealStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	ReviewerID    *uuid.UUID   `gorm:"type:uuid" json:"reviewer_id"`
+	ReviewNote    string       `gorm:"type:text" json:"review_note"`
+	ReviewedAt    *time.Time   `gorm:"type:timestamp with time zone" json:"reviewed_at"`
+
+	Report Report `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
+
+func (a ReportAppeal) ImageList() []string {
+	if a.Images == "" {
+		return nil
+	}
+	return strings.Split(a.Images, ",")
+}
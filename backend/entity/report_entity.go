@@ -1,17 +1,33 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+)
 
 type ReportStatus string
 
 const (
-	StatusUnverified ReportStatus = "unverified"
-	StatusVerified   ReportStatus = "verified"
-	StatusRejected   ReportStatus = "rejected"
-	StatusHandled    ReportStatus = "handled"
-	StatusCompleted  ReportStatus = "completed"
+	// StatusPendingReview holds a report of a low-trust reporter out of
+	// sight until an admin releases it.
+	StatusPendingReview ReportStatus = "pending_review"
+	StatusUnverified    ReportStatus = "unverified"
+	StatusVerified      ReportStatus = "verified"
+	StatusRejected      ReportStatus = "rejected"
+	StatusHandled       ReportStatus = "handled"
+	StatusCompleted     ReportStatus = "completed"
 )
 
+func (s ReportStatus) IsValid() bool {
+	switch s {
+	case StatusPendingReview, StatusUnverified, StatusVerified, StatusRejected, StatusHandled, StatusCompleted:
+		return true
+	}
+	return false
+}
+
 type Report struct {
 	ID             uuid.UUID    `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	Text           string       `gorm:"type:text" json:"text"`
@@ -21,6 +37,8 @@ type Report struct {
 	Upvotes        int          `gorm:"default:0" json:"upvotes"`
 	ShareCount     int          `gorm:"default:0" json:"share_count"`
 	Location       string       `gorm:"type:varchar(255)" json:"location"`
+	Latitude       *float64     `gorm:"type:double precision" json:"latitude"`
+	Longitude      *float64     `gorm:"type:double precision" json:"longitude"`
 
 	UserID string `gorm:"type:char(32);not null" json:"user_id"`
 	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user"`
@@ -28,5 +46,42 @@ type Report struct {
 	TagID uuid.UUID `gorm:"type:uuid" json:"tag_id"`
 	Tag   Tag       `gorm:"foreignKey:TagID" json:"tag"`
 
+	// The agency responsible for the report, routed by class on inference.
+	AgencyID *uuid.UUID `gorm:"type:uuid;index" json:"agency_id"`
+	Agency   *Agency    `gorm:"foreignKey:AgencyID;constraint:OnDelete:SET NULL" json:"-"`
+
+	// The officer handling the report, set by an admin together with DueAt.
+	AssigneeID *uuid.UUID `gorm:"type:uuid;index" json:"assignee_id"`
+	Assignee   *User      `gorm:"foreignKey:AssigneeID;constraint:OnDelete:SET NULL" json:"-"`
+	DueAt      *time.Time `gorm:"type:timestamp with time zone" json:"due_at"`
+
+	// Why the report was rejected, a RejectionReason code.
+	RejectionCode string `gorm:"type:varchar(50)" json:"rejection_code"`
+
+	// Deadline for leaving the current status under the matching SLA policy.
+	SLAPolicyID    *uuid.UUID `gorm:"type:uuid" json:"sla_policy_id"`
+	SLADueAt       *time.Time `gorm:"type:timestamp with time zone;index" json:"sla_due_at"`
+	SLAEscalatedAt *time.Time `gorm:"type:timestamp with time zone" json:"sla_escalated_at"`
+
+	// Set when community votes reached the quorum without confirming the
+	// report, which then waits for an admin.
+	VoteFlaggedAt *time.Time `gorm:"type:timestamp with time zone;index" json:"vote_flagged_at"`
+
+	// Set once the reporter has been reminded to rate the resolution.
+	RatingRemindedAt *time.Time `gorm:"type:timestamp with time zone" json:"-"`
+
+	// Only filled by search queries, never stored.
+	SearchRank float64 `gorm:"->;-:migration" json:"-"`
+	Highlight  string  `gorm:"->;-:migration" json:"-"`
+
 	Timestamp
 }
+
+// Coordinates prefers the explicit latitude/longitude and falls back to a
+// "lat,lng" location string.
+func (r Report) Coordinates() (float64, float64, bool) {
+	if r.Latitude != nil && r.Longitude != nil {
+		return *r.Latitude, *r.Longitude, true
+	}
+	return utils.ParseLatLng(r.Location)
+}
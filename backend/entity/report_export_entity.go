@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportStatus string
+
+const (
+	ExportPending   ExportStatus = "pending"
+	ExportRunning   ExportStatus = "running"
+	ExportCompleted ExportStatus = "completed"
+	ExportFailed    ExportStatus = "failed"
+)
+
+// ReportExport tracks a spreadsheet export that is too large to build
+// inside the HTTP request. Filter holds the JSON encoded request and
+// FileKey the StoredFile the finished spreadsheet was saved as.
+type ReportExport struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	RequestedBy uuid.UUID    `gorm:"type:uuid;not null;index" json:"requested_by"`
+	Format      string       `gorm:"type:varchar(10);not null" json:"format"`
+	Filter      string       `gorm:"type:jsonb;not null" json:"-"`
+	Status      ExportStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	FileKey     string       `gorm:"type:varchar(255)" json:"-"`
+	RowCount    int          `gorm:"default:0" json:"row_count"`
+	Error       string       `gorm:"type:text" json:"error,omitempty"`
+	CompletedAt *time.Time   `gorm:"type:timestamp with time zone" json:"completed_at"`
+
+	User User `gorm:"foreignKey:RequestedBy;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
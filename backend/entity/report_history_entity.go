@@ -0,0 +1,42 @@
+package entity
+
+import "github.com/google/uuid"
+
+type ReportHistoryEvent string
+
+const (
+	HistoryStatusChanged       ReportHistoryEvent = "status_changed"
+	HistoryInferenceCompleted  ReportHistoryEvent = "inference_completed"
+	HistoryAssigned            ReportHistoryEvent = "assigned"
+	HistoryUnassigned          ReportHistoryEvent = "unassigned"
+	HistoryRouted              ReportHistoryEvent = "routed"
+	HistorySLAEscalated        ReportHistoryEvent = "sla_escalated"
+	HistoryCompletionConfirmed ReportHistoryEvent = "completion_confirmed"
+	HistoryCompletionExpired   ReportHistoryEvent = "completion_expired"
+	HistoryRated               ReportHistoryEvent = "rated"
+	HistoryAppealFiled         ReportHistoryEvent = "appeal_filed"
+	HistoryAppealAccepted      ReportHistoryEvent = "appeal_accepted"
+	HistoryAppealUpheld        ReportHistoryEvent = "appeal_upheld"
+	HistoryVoteFlagged         ReportHistoryEvent = "vote_flagged"
+	HistoryHeldForReview       ReportHistoryEvent = "held_for_review"
+)
+
+// ReportHistory is an append-only log of everything that happened to a
+// report. Status changes carry FromStatus/ToStatus; other events leave them
+// empty and describe themselves in Note.
+type ReportHistory struct {
+	ID         uuid.UUID          `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReportID   uuid.UUID          `gorm:"type:uuid;not null;index:idx_report_histories_report_event" json:"report_id"`
+	Event      ReportHistoryEvent `gorm:"type:varchar(50);not null;index:idx_report_histories_report_event" json:"event"`
+	FromStatus ReportStatus       `gorm:"type:varchar(50)" json:"from_status,omitempty"`
+	ToStatus   ReportStatus       `gorm:"type:varchar(50);index" json:"to_status,omitempty"`
+	ActorID    *uuid.UUID         `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Note       string             `gorm:"type:text" json:"note,omitempty"`
+	// SLAMet tells whether a status change beat the SLA deadline of the
+	// status it left; nil when there was no deadline.
+	SLAMet *bool `gorm:"" json:"sla_met,omitempty"`
+
+	Report Report `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ReportRating is the reporter's satisfaction with how a completed report
+// was resolved, at most one per report.
+type ReportRating struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReportID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"report_id"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Score    int       `gorm:"not null;check:score BETWEEN 1 AND 5" json:"score"`
+	Comment  string    `gorm:"type:text" json:"comment"`
+
+	Report Report `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"-"`
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ReportUpvote records who supports a report, so Report.Upvotes counts
+// each user once.
+type ReportUpvote struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReportID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_report_upvotes_report_user" json:"report_id"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_report_upvotes_report_user" json:"user_id"`
+
+	Report Report `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"-"`
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "github.com/google/uuid"
+
+type VoteKind string
+
+const (
+	VoteConfirm VoteKind = "confirm"
+	VoteDispute VoteKind = "dispute"
+)
+
+func (k VoteKind) IsValid() bool {
+	return k == VoteConfirm || k == VoteDispute
+}
+
+// ReportVote is a verified citizen saying whether the problem of an
+// unverified report really exists. Each citizen has one vote per report;
+// its weight is fixed when the vote is cast.
+type ReportVote struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReportID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_report_votes_report_user" json:"report_id"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_report_votes_report_user" json:"user_id"`
+	Kind     VoteKind  `gorm:"type:varchar(20);not null" json:"kind"`
+	Weight   float64   `gorm:"not null" json:"weight"`
+
+	Report Report `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"-"`
+	User   User   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReputationReason string
+
+const (
+	ReputationReportVerified  ReputationReason = "report_verified"
+	ReputationReportCompleted ReputationReason = "report_completed"
+	ReputationReportUpvoted   ReputationReason = "report_upvoted"
+	ReputationReportSpam      ReputationReason = "report_spam"
+	ReputationSpamReversed    ReputationReason = "spam_reversed"
+)
+
+// ReputationEntry is one line of the append-only reputation ledger; a
+// user's score is the sum of their entries. Key names the event an entry
+// pays for, so replaying that event never counts it twice. Corrections are
+// new entries, never edits.
+type ReputationEntry struct {
+	ID       uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID   uuid.UUID        `gorm:"type:uuid;not null;index:idx_reputation_entries_user_created" json:"user_id"`
+	ReportID *uuid.UUID       `gorm:"type:uuid;index" json:"report_id,omitempty"`
+	Reason   ReputationReason `gorm:"type:varchar(50);not null" json:"reason"`
+	Points   int              `gorm:"not null" json:"points"`
+	// Region is the report location when the entry was written.
+	Region    string    `gorm:"type:varchar(255);index" json:"region,omitempty"`
+	Key       string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
+	CreatedAt time.Time `gorm:"type:timestamp with time zone;not null;index:idx_reputation_entries_user_created;index" json:"created_at"`
+
+	User   User    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Report *Report `gorm:"foreignKey:ReportID;constraint:OnDelete:SET NULL" json:"-"`
+}
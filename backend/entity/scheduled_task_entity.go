@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type TaskRunStatus string
+
+const (
+	TaskRunning   TaskRunStatus = "running"
+	TaskSucceeded TaskRunStatus = "succeeded"
+	TaskFailed    TaskRunStatus = "failed"
+)
+
+// ScheduledTask records the last run of a scheduler task, one row per task
+// name. LastScheduledAt is the cron slot that run belonged to, which keeps
+// replicas from running the same slot twice.
+type ScheduledTask struct {
+	Name            string        `gorm:"type:varchar(100);primary_key" json:"name"`
+	LastScheduledAt *time.Time    `gorm:"type:timestamp with time zone" json:"last_scheduled_at,omitempty"`
+	LastStartedAt   *time.Time    `gorm:"type:timestamp with time zone" json:"last_started_at,omitempty"`
+	LastFinishedAt  *time.Time    `gorm:"type:timestamp with time zone" json:"last_finished_at,omitempty"`
+	LastStatus      TaskRunStatus `gorm:"type:varchar(20)" json:"last_status,omitempty"`
+	LastError       string        `gorm:"type:text" json:"last_error,omitempty"`
+	LastDurationMs  int64         `gorm:"default:0" json:"last_duration_ms"`
+
+	Timestamp
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLAPolicy is a service-level target: a report in Status has to move on
+// within DurationMinutes. An empty Class covers every class without a
+// policy of its own.
+type SLAPolicy struct {
+	ID              uuid.UUID    `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Class           string       `gorm:"type:varchar(20);not null;default:'';uniqueIndex:idx_sla_policies_class_status" json:"class"`
+	Status          ReportStatus `gorm:"type:varchar(50);not null;uniqueIndex:idx_sla_policies_class_status" json:"status"`
+	DurationMinutes int          `gorm:"not null" json:"duration_minutes"`
+	// Comma separated addresses emailed when a report breaches the policy.
+	SupervisorEmails string `gorm:"type:text" json:"supervisor_emails"`
+
+	Timestamp
+}
+
+func (p SLAPolicy) Duration() time.Duration {
+	return time.Duration(p.DurationMinutes) * time.Minute
+}
+
+func (p SLAPolicy) Emails() []string {
+	if p.SupervisorEmails == "" {
+		return nil
+	}
+	return strings.Split(p.SupervisorEmails, ",")
+}
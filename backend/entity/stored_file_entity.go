@@ -0,0 +1,12 @@
+package entity
+
+// StoredFile is a generated file kept in the database rather than on the
+// disk of the instance that wrote it, so every replica can serve it. Keys
+// are slash separated paths such as "exports/<id>.csv".
+type StoredFile struct {
+	Key     string `gorm:"type:varchar(255);primary_key" json:"key"`
+	Content []byte `gorm:"type:bytea;not null" json:"-"`
+	Size    int64  `gorm:"not null" json:"size"`
+
+	Timestamp
+}
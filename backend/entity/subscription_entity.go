@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+)
+
+type SubscriptionKind string
+
+const (
+	// SubscriptionTag follows a single incident (tag).
+	SubscriptionTag SubscriptionKind = "tag"
+	// SubscriptionArea follows every report within a radius of a point.
+	SubscriptionArea SubscriptionKind = "area"
+)
+
+type Subscription struct {
+	ID           uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID       uuid.UUID        `gorm:"type:uuid;not null;index" json:"user_id"`
+	Kind         SubscriptionKind `gorm:"type:varchar(10);not null" json:"kind"`
+	Label        string           `gorm:"type:varchar(100)" json:"label"`
+	TagID        *uuid.UUID       `gorm:"type:uuid;index" json:"tag_id"`
+	Latitude     *float64         `gorm:"type:double precision" json:"latitude"`
+	Longitude    *float64         `gorm:"type:double precision" json:"longitude"`
+	RadiusMeters *int             `gorm:"" json:"radius_meters"`
+	// Comma separated classes, empty for every class.
+	Classes string `gorm:"type:varchar(255)" json:"classes"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Tag  *Tag `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE" json:"tag,omitempty"`
+
+	Timestamp
+}
+
+func (s Subscription) ClassList() []string {
+	if s.Classes == "" {
+		return nil
+	}
+	return strings.Split(s.Classes, ",")
+}
+
+// Matches reports whether the report falls inside the subscription. Reports
+// without a class yet never match a class-filtered subscription.
+func (s Subscription) Matches(report Report) bool {
+	if classes := s.ClassList(); len(classes) > 0 {
+		found := false
+		for _, class := range classes {
+			if class == report.Tag.Class {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	switch s.Kind {
+	case SubscriptionTag:
+		return s.TagID != nil && *s.TagID == report.TagID
+	case SubscriptionArea:
+		if s.Latitude == nil || s.Longitude == nil || s.RadiusMeters == nil {
+			return false
+		}
+		lat, lng, ok := report.Coordinates()
+		if !ok {
+			return false
+		}
+		return utils.DistanceMeters(*s.Latitude, *s.Longitude, lat, lng) <= float64(*s.RadiusMeters)
+	}
+	return false
+}
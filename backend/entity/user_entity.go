@@ -12,13 +12,23 @@ type User struct {
 	Email      string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"email" validate:"required,email"`
 	TelpNumber string    `gorm:"type:varchar(20);index" json:"telp_number" validate:"omitempty,required,min=8,max=20"`
 	Password   string    `gorm:"type:varchar(255);not null" json:"-" validate:"required,min=8"`
-	Role       string    `gorm:"type:varchar(50);not null;default:'user'" json:"role" validate:"required,oneof=user admin"`
+	Role       string    `gorm:"type:varchar(50);not null;default:'user'" json:"role" validate:"required,oneof=user officer admin"`
 	ImageUrl   string    `gorm:"type:varchar(255)" json:"image_url" validate:"omitempty,url"`
 	IsVerified bool      `gorm:"default:false" json:"is_verified"`
 
+	NotificationPreferences NotificationPreferences `gorm:"embedded;embeddedPrefix:notify_" json:"notification_preferences"`
+
 	Timestamp
 }
 
+// NotificationPreferences decides which channels a user is reached on.
+// Everything is on until the user opts out.
+type NotificationPreferences struct {
+	InApp bool `gorm:"not null;default:true" json:"in_app"`
+	Email bool `gorm:"not null;default:true" json:"email"`
+	Push  bool `gorm:"not null;default:true" json:"push"`
+}
+
 // BeforeCreate hook to hash password and set defaults
 func (u *User) BeforeCreate(_ *gorm.DB) (err error) {
 	// Hash password
@@ -0,0 +1,32 @@
+package entity
+
+import "github.com/google/uuid"
+
+type TrustLevel string
+
+const (
+	TrustLow    TrustLevel = "low"
+	TrustNormal TrustLevel = "normal"
+	TrustHigh   TrustLevel = "high"
+)
+
+func (l TrustLevel) IsValid() bool {
+	switch l {
+	case TrustLow, TrustNormal, TrustHigh:
+		return true
+	}
+	return false
+}
+
+// UserTrustOverride pins a user's trust level, replacing the one computed
+// from their trust score until an admin clears it.
+type UserTrustOverride struct {
+	UserID  uuid.UUID  `gorm:"type:uuid;primary_key" json:"user_id"`
+	Level   TrustLevel `gorm:"type:varchar(20);not null" json:"level"`
+	Note    string     `gorm:"type:text" json:"note"`
+	SetByID *uuid.UUID `gorm:"type:uuid" json:"set_by_id"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Timestamp
+}
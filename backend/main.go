@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
 	"github.com/Caknoooo/go-gin-clean-starter/command"
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/middleware"
 	"github.com/Caknoooo/go-gin-clean-starter/provider"
 	"github.com/Caknoooo/go-gin-clean-starter/routes"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
 	"github.com/samber/do"
 
 	"github.com/common-nighthawk/go-figure"
@@ -61,11 +65,29 @@ func main() {
 		return
 	}
 
-	server := gin.Default()
+	server := gin.New()
+	server.Use(middleware.Logger(), gin.Recovery())
+	if err := server.SetTrustedProxies(config.TrustedProxies()); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
 	server.Use(middleware.CORSMiddleware())
 
 	// routes
 	routes.RegisterRoutes(server, injector)
 
+	// background jobs
+	eventBroker := do.MustInvokeNamed[service.EventBroker](injector, constants.EventBroker)
+	go eventBroker.Run(context.Background())
+
+	if config.NewJobConfig().InProcess {
+		jobWorker := do.MustInvoke[service.JobWorker](injector)
+		go jobWorker.Run(context.Background())
+	}
+
+	if config.NewSchedulerConfig().Enabled {
+		scheduler := do.MustInvoke[service.Scheduler](injector)
+		go scheduler.Run(context.Background())
+	}
+
 	run(server)
 }
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// AgencyScope must run after Authenticate. It stores the agencies of agency
+// staff under "agency_ids"; everyone else is left unscoped.
+func AgencyScope(agencyService service.AgencyService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.MustGet("user_id").(string)
+
+		agencyIds, err := agencyService.Scope(ctx.Request.Context(), userId)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+
+		if len(agencyIds) > 0 {
+			ctx.Set("agency_ids", agencyIds)
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole must run after Authenticate. The role is read from the
+// database rather than the token so a demoted user loses access at once.
+func RequireRole(userService service.UserService, roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.MustGet("user_id").(string)
+
+		user, err := userService.GetUserById(ctx.Request.Context(), userId)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		if !slices.Contains(roles, user.Role) {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DENIED_ACCESS, dto.MESSAGE_FAILED_DENIED, nil)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+
+		ctx.Set("role", user.Role)
+		ctx.Next()
+	}
+}
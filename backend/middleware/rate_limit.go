@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit limits requests by client IP and, when it runs after
+// Authenticate, by user as well, so a user cannot dodge the limit by
+// switching networks. The tightest bucket is reported in the RateLimit
+// headers. If the limiter fails the request is let through.
+func RateLimit(limiter service.RateLimiter, policy string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		keys := []string{"ip:" + ctx.ClientIP()}
+		if userId := ctx.GetString("user_id"); userId != "" {
+			keys = append(keys, "user:"+userId)
+		}
+
+		// the request takes a token from every bucket or from none, so a
+		// refusal by the user bucket does not also drain the IP bucket
+		tightest, err := limiter.Allow(ctx.Request.Context(), policy, keys...)
+		if err != nil {
+			log.Printf("rate limit %s: %v", policy, err)
+			ctx.Next()
+			return
+		}
+		if tightest.Limit == 0 {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		ctx.Header("RateLimit-Reset", ceilSeconds(tightest.Reset))
+
+		if !tightest.Allowed {
+			ctx.Header("Retry-After", ceilSeconds(tightest.RetryAfter))
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RATE_LIMITED, dto.ErrRateLimited.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, response)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// TokenFromQuery lets clients that cannot set headers, such as the browser
+// EventSource API, pass the access token as ?access_token=. It only fills
+// the Authorization header when none was sent and must run before
+// Authenticate. Logger keeps the token out of the request log.
+func TokenFromQuery() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") == "" {
+			if token := ctx.Query("access_token"); token != "" {
+				ctx.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		ctx.Next()
+	}
+}
@@ -11,9 +11,40 @@ func Migrate(db *gorm.DB) error {
 		&entity.RefreshToken{},
 		&entity.Report{},
 		&entity.Tag{},
+		&entity.ReportHistory{},
+		&entity.ReportExport{},
+		&entity.StoredFile{},
+		&entity.Notification{},
+		&entity.Job{},
+		&entity.ScheduledTask{},
+		&entity.DeviceToken{},
+		&entity.Subscription{},
+		&entity.Agency{},
+		&entity.AgencyMember{},
+		&entity.AgencyRoute{},
+		&entity.SLAPolicy{},
+		&entity.CompletionEvidence{},
+		&entity.ReportRating{},
+		&entity.RejectionReason{},
+		&entity.ReportAppeal{},
+		&entity.Comment{},
+		&entity.ReportVote{},
+		&entity.ReportUpvote{},
+		&entity.ReputationEntry{},
+		&entity.UserTrustOverride{},
+		&entity.RateLimitBucket{},
 	); err != nil {
 		return err
 	}
 
+	if err := ReportSearch(db, false); err != nil {
+		return err
+	}
+
+	// ids for real-time report events, shared by every API instance
+	if err := db.Exec(`CREATE SEQUENCE IF NOT EXISTS report_event_seq;`).Error; err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"gorm.io/gorm"
+)
+
+// ReportSearch adds the generated tsvector column and the indexes used by
+// report search. When rebuild is true the column is dropped first so a
+// changed REPORT_SEARCH_CONFIG takes effect.
+func ReportSearch(db *gorm.DB, rebuild bool) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm;`,
+	}
+
+	if rebuild {
+		statements = append(statements, `ALTER TABLE reports DROP COLUMN IF EXISTS search_vector;`)
+	}
+
+	statements = append(statements,
+		fmt.Sprintf(`ALTER TABLE reports ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (to_tsvector('%s', coalesce(text, '') || ' ' || coalesce(location, ''))) STORED;`,
+			config.ReportSearchConfig()),
+		`CREATE INDEX IF NOT EXISTS idx_reports_search_vector ON reports USING GIN (search_vector);`,
+		`CREATE INDEX IF NOT EXISTS idx_reports_text_trgm ON reports USING GIN (text gin_trgm_ops);`,
+	)
+
+	if rebuild {
+		statements = append(statements,
+			`REINDEX INDEX idx_reports_search_vector;`,
+			`REINDEX INDEX idx_reports_text_trgm;`,
+		)
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
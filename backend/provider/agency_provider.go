@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideAgencyDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	agencyRepository := repository.NewAgencyRepository(db)
+	userRepository := repository.NewUserRepository(db)
+
+	// Service
+	agencyService := service.NewAgencyService(agencyRepository, userRepository)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.AgencyService, error) {
+			return agencyService, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.AgencyController, error) {
+			return controller.NewAgencyController(agencyService), nil
+		},
+	)
+}
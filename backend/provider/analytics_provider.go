@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideAnalyticsDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	analyticsRepository := repository.NewAnalyticsRepository(db)
+
+	// Service
+	analyticsService := service.NewAnalyticsService(analyticsRepository)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.AnalyticsController, error) {
+			return controller.NewAnalyticsController(analyticsService), nil
+		},
+	)
+}
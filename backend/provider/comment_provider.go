@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideCommentDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	commentRepository := repository.NewCommentRepository(db)
+	reportRepository := repository.NewReportRepository(db)
+	userRepository := repository.NewUserRepository(db)
+
+	// Service
+	notificationService := do.MustInvoke[service.NotificationService](injector)
+	commentService := service.NewCommentService(
+		commentRepository,
+		reportRepository,
+		userRepository,
+		notificationService,
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.CommentController, error) {
+			return controller.NewCommentController(commentService), nil
+		},
+	)
+}
@@ -1,9 +1,12 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/Caknoooo/go-gin-clean-starter/config"
 	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
 	"github.com/samber/do"
 	"gorm.io/gorm"
 )
@@ -21,11 +24,47 @@ func RegisterDependencies(injector *do.Injector) {
 		return service.NewJWTService(), nil
 	})
 
+	do.ProvideNamed(injector, constants.EventBroker, func(i *do.Injector) (service.EventBroker, error) {
+		db := do.MustInvokeNamed[*gorm.DB](i, constants.DB)
+		return service.NewEventBroker(db, config.DatabaseDSN()), nil
+	})
+
+	do.ProvideNamed(injector, constants.Mailer, func(i *do.Injector) (utils.Mailer, error) {
+		return utils.DefaultMailer()
+	})
+
+	do.ProvideNamed(injector, constants.PushSender, func(i *do.Injector) (utils.PushSender, error) {
+		return utils.NewPushSender(context.Background(), config.NewPushConfig())
+	})
+
 	// Initialize
 	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	eventBroker := do.MustInvokeNamed[service.EventBroker](injector, constants.EventBroker)
+	mailer := do.MustInvokeNamed[utils.Mailer](injector, constants.Mailer)
+	pushSender := do.MustInvokeNamed[utils.PushSender](injector, constants.PushSender)
 
 	// Provide Dependencies
+	ProvideRateLimitDependencies(injector, db)
+	ProvideReputationDependencies(injector, db)
 	ProvideUserDependencies(injector, db, jwtService)
-	ProvideReportDependencies(injector, db, jwtService)
+	ProvideJobDependencies(injector, db, mailer)
+	ProvideDeviceDependencies(injector, db, pushSender)
+	ProvideNotificationDependencies(injector, db)
+	ProvideSubscriptionDependencies(injector, db)
+	ProvideAgencyDependencies(injector, db)
+	ProvideTrustDependencies(injector, db)
+	ProvideReportDependencies(injector, db, eventBroker)
+	ProvideSLADependencies(injector, db)
+	ProvideRatingDependencies(injector, db)
+	ProvideRejectionReasonDependencies(injector, db)
+	ProvideCommentDependencies(injector, db)
+	ProvideEventDependencies(injector, db, jwtService, eventBroker)
+	ProvideAnalyticsDependencies(injector, db)
+	ProvideExportDependencies(injector, db)
+	ProvideOpenDataDependencies(injector, db)
+
+	// Workers
+	ProvideJobWorker(injector, db)
+	ProvideSchedulerDependencies(injector, db)
 }
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideDeviceDependencies(injector *do.Injector, db *gorm.DB, pushSender utils.PushSender) {
+	// Repository
+	deviceTokenRepository := repository.NewDeviceTokenRepository(db)
+
+	// Service
+	deviceService := service.NewDeviceService(deviceTokenRepository, pushSender, db)
+
+	// Handlers
+	do.ProvideNamed(
+		injector, service.JobHandlerName(dto.JOB_SEND_PUSH), func(i *do.Injector) (service.JobHandler, error) {
+			return service.NewJobHandler(deviceService.SendPush), nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.DeviceController, error) {
+			return controller.NewDeviceController(deviceService), nil
+		},
+	)
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideEventDependencies(injector *do.Injector, db *gorm.DB, jwtService service.JWTService, eventBroker service.EventBroker) {
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+
+	// Service
+	userService := service.NewUserService(userRepository, refreshTokenRepository, jwtService, db)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.EventController, error) {
+			return controller.NewEventController(eventBroker, userService), nil
+		},
+	)
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideExportDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	reportRepository := repository.NewReportRepository(db)
+	reportHistoryRepository := repository.NewReportHistoryRepository(db)
+	reportExportRepository := repository.NewReportExportRepository(db)
+	storedFileRepository := repository.NewStoredFileRepository(db)
+
+	// Service
+	jobService := do.MustInvoke[service.JobService](injector)
+	exportService := service.NewExportService(
+		reportRepository,
+		reportHistoryRepository,
+		reportExportRepository,
+		storedFileRepository,
+		jobService,
+	)
+
+	// Handlers
+	do.ProvideNamed(
+		injector, service.JobHandlerName(dto.JOB_REPORT_EXPORT), func(i *do.Injector) (service.JobHandler, error) {
+			return service.NewJobHandler(exportService.RunSpreadsheetExport), nil
+		},
+	)
+
+	// Scheduled tasks
+	do.ProvideNamed(
+		injector, constants.ScheduledTask+dto.TASK_PURGE_REPORT_EXPORTS, func(i *do.Injector) (service.ScheduledTask, error) {
+			return service.ScheduledTask{
+				Name:        dto.TASK_PURGE_REPORT_EXPORTS,
+				Description: "Hapus ekspor laporan yang sudah kedaluwarsa",
+				Schedule:    config.TaskSchedule(dto.TASK_PURGE_REPORT_EXPORTS, "0 3 * * *"),
+				Run:         exportService.PurgeExports,
+			}, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.ExportController, error) {
+			return controller.NewExportController(exportService), nil
+		},
+	)
+}
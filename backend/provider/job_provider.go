@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideJobDependencies(injector *do.Injector, db *gorm.DB, mailer utils.Mailer) {
+	// Repository
+	jobRepository := repository.NewJobRepository(db)
+
+	// Service
+	jobService := service.NewJobService(jobRepository)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.JobService, error) {
+			return jobService, nil
+		},
+	)
+
+	// Handlers
+	do.ProvideNamed(
+		injector, service.JobHandlerName(dto.JOB_SEND_EMAIL), func(i *do.Injector) (service.JobHandler, error) {
+			return service.NewJobHandler(mailer.Send), nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.JobController, error) {
+			return controller.NewJobController(jobService), nil
+		},
+	)
+}
+
+// ProvideJobWorker collects every JobHandler registered so far, so it has
+// to run after the providers that register them.
+func ProvideJobWorker(injector *do.Injector, db *gorm.DB) {
+	handlers := map[string]service.JobHandler{}
+	for _, name := range injector.ListProvidedServices() {
+		if jobType, ok := strings.CutPrefix(name, constants.JobHandler); ok {
+			handlers[jobType] = do.MustInvokeNamed[service.JobHandler](injector, name)
+		}
+	}
+
+	jobWorker := service.NewJobWorker(repository.NewJobRepository(db), handlers, config.NewJobConfig())
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.JobWorker, error) {
+			return jobWorker, nil
+		},
+	)
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideNotificationDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	notificationRepository := repository.NewNotificationRepository(db)
+	deviceTokenRepository := repository.NewDeviceTokenRepository(db)
+
+	// Service
+	jobService := do.MustInvoke[service.JobService](injector)
+	notificationService := service.NewNotificationService(
+		userRepository,
+		notificationRepository,
+		service.NewEmailNotificationChannel(jobService),
+		service.NewPushNotificationChannel(deviceTokenRepository, jobService),
+	)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.NotificationService, error) {
+			return notificationService, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.NotificationController, error) {
+			return controller.NewNotificationController(notificationService), nil
+		},
+	)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideOpenDataDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	reportRepository := repository.NewReportRepository(db)
+	storedFileRepository := repository.NewStoredFileRepository(db)
+
+	// Service
+	openDataConfig := config.NewOpenDataConfig()
+	openDataService := service.NewOpenDataService(reportRepository, storedFileRepository, openDataConfig)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.OpenDataService, error) {
+			return openDataService, nil
+		},
+	)
+
+	// Scheduled tasks
+	if openDataConfig.Enabled {
+		do.ProvideNamed(
+			injector, constants.ScheduledTask+dto.TASK_OPEN_DATA_SNAPSHOT, func(i *do.Injector) (service.ScheduledTask, error) {
+				return service.ScheduledTask{
+					Name:        dto.TASK_OPEN_DATA_SNAPSHOT,
+					Description: "Terbitkan snapshot data terbuka harian",
+					Schedule:    config.TaskSchedule(dto.TASK_OPEN_DATA_SNAPSHOT, "0 1 * * *"),
+					Run: func(ctx context.Context) error {
+						snapshot, err := openDataService.GenerateSnapshot(ctx)
+						if err != nil {
+							return err
+						}
+						log.Printf("open data snapshot %s: %d records, %d suppressed", snapshot.Version, snapshot.RecordCount, snapshot.SuppressedCount)
+						return nil
+					},
+				}, nil
+			},
+		)
+	}
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.OpenDataController, error) {
+			return controller.NewOpenDataController(openDataService), nil
+		},
+	)
+}
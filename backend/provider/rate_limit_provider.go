@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideRateLimitDependencies(injector *do.Injector, db *gorm.DB) {
+	cfg := config.NewRateLimitConfig()
+
+	// Repository
+	rateLimitRepository := repository.NewRateLimitRepository(db)
+
+	// Service
+	rateLimiter := service.NewRateLimiter(cfg, rateLimitRepository, db)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.RateLimiter, error) {
+			return rateLimiter, nil
+		},
+	)
+
+	// Scheduled tasks; the in-memory limiter cleans up after itself
+	if cfg.Enabled && cfg.Backend == config.RATE_LIMIT_BACKEND_POSTGRES {
+		do.ProvideNamed(
+			injector, constants.ScheduledTask+dto.TASK_PURGE_RATE_LIMITS, func(i *do.Injector) (service.ScheduledTask, error) {
+				return service.ScheduledTask{
+					Name:        dto.TASK_PURGE_RATE_LIMITS,
+					Description: "Hapus bucket rate limit yang sudah tidak terpakai",
+					Schedule:    config.TaskSchedule(dto.TASK_PURGE_RATE_LIMITS, "*/30 * * * *"),
+					Run:         rateLimiter.PurgeIdleBuckets,
+				}, nil
+			},
+		)
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideRatingDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	reportRatingRepository := repository.NewReportRatingRepository(db)
+	reportRepository := repository.NewReportRepository(db)
+	reportHistoryRepository := repository.NewReportHistoryRepository(db)
+	userRepository := repository.NewUserRepository(db)
+
+	// Service
+	notificationService := do.MustInvoke[service.NotificationService](injector)
+	ratingService := service.NewRatingService(
+		reportRatingRepository,
+		reportRepository,
+		reportHistoryRepository,
+		userRepository,
+		notificationService,
+		db,
+	)
+
+	// Scheduled tasks
+	do.ProvideNamed(
+		injector, constants.ScheduledTask+dto.TASK_RATING_REMINDERS, func(i *do.Injector) (service.ScheduledTask, error) {
+			return service.ScheduledTask{
+				Name:        dto.TASK_RATING_REMINDERS,
+				Description: "Ingatkan pelapor untuk menilai laporan yang selesai",
+				Schedule:    config.TaskSchedule(dto.TASK_RATING_REMINDERS, "30 * * * *"),
+				Run:         ratingService.SendReminders,
+			}, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.RatingController, error) {
+			return controller.NewRatingController(ratingService), nil
+		},
+	)
+}
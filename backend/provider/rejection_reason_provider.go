@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideRejectionReasonDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	rejectionReasonRepository := repository.NewRejectionReasonRepository(db)
+
+	// Service
+	rejectionReasonService := service.NewRejectionReasonService(rejectionReasonRepository)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.RejectionReasonController, error) {
+			return controller.NewRejectionReasonController(rejectionReasonService), nil
+		},
+	)
+}
@@ -1,26 +1,96 @@
 package provider
 
 import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
 	"github.com/Caknoooo/go-gin-clean-starter/repository"
 	"github.com/Caknoooo/go-gin-clean-starter/service"
 	"github.com/samber/do"
 	"gorm.io/gorm"
 )
 
-func ProvideReportDependencies(injector *do.Injector, db *gorm.DB, jwtService service.JWTService) {
+func ProvideReportDependencies(injector *do.Injector, db *gorm.DB, eventBroker service.EventBroker) {
 	// Repository
 	reportRepository := repository.NewReportRepository(db)
 	userRepository := repository.NewUserRepository(db)
-	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	reportHistoryRepository := repository.NewReportHistoryRepository(db)
+	agencyRepository := repository.NewAgencyRepository(db)
+	slaPolicyRepository := repository.NewSLAPolicyRepository(db)
+	completionEvidenceRepository := repository.NewCompletionEvidenceRepository(db)
+	rejectionReasonRepository := repository.NewRejectionReasonRepository(db)
+	reportAppealRepository := repository.NewReportAppealRepository(db)
+	reportVoteRepository := repository.NewReportVoteRepository(db)
+	reportUpvoteRepository := repository.NewReportUpvoteRepository(db)
+	reputationRepository := repository.NewReputationRepository(db)
 	// Service
-	reportService := service.NewReportService(userRepository, reportRepository, db)
-	userService := service.NewUserService(userRepository, refreshTokenRepository, jwtService, db)
+	notificationService := do.MustInvoke[service.NotificationService](injector)
+	jobService := do.MustInvoke[service.JobService](injector)
+	trustService := do.MustInvoke[service.TrustService](injector)
+	reportWorkflow := service.NewReportWorkflow(
+		userRepository,
+		reportRepository,
+		reportHistoryRepository,
+		slaPolicyRepository,
+		reputationRepository,
+		eventBroker,
+		notificationService,
+		jobService,
+		db,
+	)
+	reportService := service.NewReportService(
+		reportWorkflow,
+		agencyRepository,
+		completionEvidenceRepository,
+		rejectionReasonRepository,
+		trustService,
+	)
+	assignmentService := service.NewAssignmentService(reportWorkflow, agencyRepository)
+	appealService := service.NewAppealService(reportWorkflow, reportAppealRepository)
+	voteService := service.NewVoteService(reportWorkflow, reportVoteRepository, reportUpvoteRepository, config.NewVerificationConfig())
+	heldReportService := service.NewHeldReportService(reportWorkflow)
+
+	// Scheduled tasks
+	do.ProvideNamed(
+		injector, constants.ScheduledTask+dto.TASK_EXPIRE_COMPLETIONS, func(i *do.Injector) (service.ScheduledTask, error) {
+			return service.ScheduledTask{
+				Name:        dto.TASK_EXPIRE_COMPLETIONS,
+				Description: "Terima penyelesaian yang tidak ditanggapi pelapor",
+				Schedule:    config.TaskSchedule(dto.TASK_EXPIRE_COMPLETIONS, "15 * * * *"),
+				Run:         reportService.ExpireCompletions,
+			}, nil
+		},
+	)
 
 	// Controller
 	do.Provide(
 		injector, func(i *do.Injector) (controller.ReportController, error) {
-			return controller.NewReportController(reportService, userService), nil
+			return controller.NewReportController(reportService), nil
+		},
+	)
+
+	do.Provide(
+		injector, func(i *do.Injector) (controller.AssignmentController, error) {
+			return controller.NewAssignmentController(assignmentService), nil
+		},
+	)
+
+	do.Provide(
+		injector, func(i *do.Injector) (controller.AppealController, error) {
+			return controller.NewAppealController(appealService), nil
+		},
+	)
+
+	do.Provide(
+		injector, func(i *do.Injector) (controller.VoteController, error) {
+			return controller.NewVoteController(voteService), nil
+		},
+	)
+
+	do.Provide(
+		injector, func(i *do.Injector) (controller.HeldReportController, error) {
+			return controller.NewHeldReportController(heldReportService), nil
 		},
 	)
 }
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideReputationDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	reputationRepository := repository.NewReputationRepository(db)
+
+	// Service
+	reputationService := service.NewReputationService(reputationRepository)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.ReputationService, error) {
+			return reputationService, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.ReputationController, error) {
+			return controller.NewReputationController(reputationService), nil
+		},
+	)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+// ProvideSchedulerDependencies collects every ScheduledTask registered so
+// far, so it has to run after the providers that register them.
+func ProvideSchedulerDependencies(injector *do.Injector, db *gorm.DB) {
+	var tasks []service.ScheduledTask
+	for _, name := range injector.ListProvidedServices() {
+		if strings.HasPrefix(name, constants.ScheduledTask) {
+			tasks = append(tasks, do.MustInvokeNamed[service.ScheduledTask](injector, name))
+		}
+	}
+
+	// Repository
+	scheduledTaskRepository := repository.NewScheduledTaskRepository(db)
+
+	// Service
+	scheduler, err := service.NewScheduler(db, scheduledTaskRepository, tasks)
+	if err != nil {
+		panic(err)
+	}
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.Scheduler, error) {
+			return scheduler, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.SchedulerController, error) {
+			return controller.NewSchedulerController(scheduler), nil
+		},
+	)
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideSLADependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	slaPolicyRepository := repository.NewSLAPolicyRepository(db)
+	reportRepository := repository.NewReportRepository(db)
+	reportHistoryRepository := repository.NewReportHistoryRepository(db)
+	agencyRepository := repository.NewAgencyRepository(db)
+
+	// Service
+	jobService := do.MustInvoke[service.JobService](injector)
+	slaService := service.NewSLAService(
+		slaPolicyRepository,
+		reportRepository,
+		reportHistoryRepository,
+		agencyRepository,
+		jobService,
+		db,
+	)
+
+	// Scheduled tasks
+	do.ProvideNamed(
+		injector, constants.ScheduledTask+dto.TASK_SLA_CHECK, func(i *do.Injector) (service.ScheduledTask, error) {
+			return service.ScheduledTask{
+				Name:        dto.TASK_SLA_CHECK,
+				Description: "Eskalasi laporan yang melewati tenggat SLA",
+				Schedule:    config.TaskSchedule(dto.TASK_SLA_CHECK, "*/5 * * * *"),
+				Run:         slaService.CheckBreaches,
+			}, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.SLAController, error) {
+			return controller.NewSLAController(slaService), nil
+		},
+	)
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideSubscriptionDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	subscriptionRepository := repository.NewSubscriptionRepository(db)
+	reportRepository := repository.NewReportRepository(db)
+
+	// Service
+	notificationService := do.MustInvoke[service.NotificationService](injector)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepository, reportRepository, notificationService)
+
+	// Handlers
+	do.ProvideNamed(
+		injector, service.JobHandlerName(dto.JOB_MATCH_SUBSCRIPTIONS), func(i *do.Injector) (service.JobHandler, error) {
+			return service.NewJobHandler(subscriptionService.MatchSubscriptions), nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.SubscriptionController, error) {
+			return controller.NewSubscriptionController(subscriptionService), nil
+		},
+	)
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideTrustDependencies(injector *do.Injector, db *gorm.DB) {
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	reportRepository := repository.NewReportRepository(db)
+	userTrustOverrideRepository := repository.NewUserTrustOverrideRepository(db)
+
+	// Service
+	trustService := service.NewTrustService(userRepository, reportRepository, userTrustOverrideRepository)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.TrustService, error) {
+			return trustService, nil
+		},
+	)
+
+	// Controller
+	do.Provide(
+		injector, func(i *do.Injector) (controller.TrustController, error) {
+			return controller.NewTrustController(trustService), nil
+		},
+	)
+}
@@ -1,7 +1,10 @@
 package provider
 
 import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
 	"github.com/Caknoooo/go-gin-clean-starter/repository"
 	"github.com/Caknoooo/go-gin-clean-starter/service"
 	"github.com/samber/do"
@@ -15,11 +18,30 @@ func ProvideUserDependencies(injector *do.Injector, db *gorm.DB, jwtService serv
 
 	// Service
 	userService := service.NewUserService(userRepository, refreshTokenRepository, jwtService, db)
+	reputationService := do.MustInvoke[service.ReputationService](injector)
+
+	do.Provide(
+		injector, func(i *do.Injector) (service.UserService, error) {
+			return userService, nil
+		},
+	)
+
+	// Scheduled tasks
+	do.ProvideNamed(
+		injector, constants.ScheduledTask+dto.TASK_PURGE_REFRESH_TOKENS, func(i *do.Injector) (service.ScheduledTask, error) {
+			return service.ScheduledTask{
+				Name:        dto.TASK_PURGE_REFRESH_TOKENS,
+				Description: "Hapus refresh token yang sudah kedaluwarsa",
+				Schedule:    config.TaskSchedule(dto.TASK_PURGE_REFRESH_TOKENS, "0 * * * *"),
+				Run:         userService.PurgeExpiredRefreshTokens,
+			}, nil
+		},
+	)
 
 	// Controller
 	do.Provide(
 		injector, func(i *do.Injector) (controller.UserController, error) {
-			return controller.NewUserController(userService), nil
+			return controller.NewUserController(userService, reputationService), nil
 		},
 	)
 }
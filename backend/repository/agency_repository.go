@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	AgencyRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, agency entity.Agency) (entity.Agency, error)
+		GetAll(ctx context.Context, tx *gorm.DB) ([]entity.Agency, error)
+		GetById(ctx context.Context, tx *gorm.DB, agencyId string) (entity.Agency, error)
+		GetByName(ctx context.Context, tx *gorm.DB, name string) (entity.Agency, error)
+		Delete(ctx context.Context, tx *gorm.DB, agencyId string) (int64, error)
+
+		SaveMember(ctx context.Context, tx *gorm.DB, member entity.AgencyMember) error
+		DeleteMember(ctx context.Context, tx *gorm.DB, agencyId string, userId string) (int64, error)
+		GetMembers(ctx context.Context, tx *gorm.DB, agencyId string) ([]entity.AgencyMember, error)
+		GetMemberships(ctx context.Context, tx *gorm.DB, userId string) ([]entity.AgencyMember, error)
+		IsMember(ctx context.Context, tx *gorm.DB, agencyId string, userId string) (bool, error)
+
+		GetRoutes(ctx context.Context, tx *gorm.DB) ([]entity.AgencyRoute, error)
+		GetRoutesByClass(ctx context.Context, tx *gorm.DB, classes []string) ([]entity.AgencyRoute, error)
+		SaveRoute(ctx context.Context, tx *gorm.DB, route entity.AgencyRoute) (entity.AgencyRoute, error)
+		DeleteRoute(ctx context.Context, tx *gorm.DB, routeId string) (int64, error)
+	}
+
+	agencyRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAgencyRepository(db *gorm.DB) AgencyRepository {
+	return &agencyRepository{
+		db: db,
+	}
+}
+
+func (r *agencyRepository) Create(ctx context.Context, tx *gorm.DB, agency entity.Agency) (entity.Agency, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&agency).Error; err != nil {
+		return entity.Agency{}, err
+	}
+
+	return agency, nil
+}
+
+func (r *agencyRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]entity.Agency, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var agencies []entity.Agency
+	if err := tx.WithContext(ctx).Preload("Members.User").Order("name").Find(&agencies).Error; err != nil {
+		return nil, err
+	}
+
+	return agencies, nil
+}
+
+func (r *agencyRepository) GetById(ctx context.Context, tx *gorm.DB, agencyId string) (entity.Agency, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var agency entity.Agency
+	if err := tx.WithContext(ctx).Preload("Members.User").First(&agency, "id = ?", agencyId).Error; err != nil {
+		return entity.Agency{}, err
+	}
+
+	return agency, nil
+}
+
+func (r *agencyRepository) GetByName(ctx context.Context, tx *gorm.DB, name string) (entity.Agency, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var agency entity.Agency
+	if err := tx.WithContext(ctx).Where("lower(name) = lower(?)", name).Take(&agency).Error; err != nil {
+		return entity.Agency{}, err
+	}
+
+	return agency, nil
+}
+
+func (r *agencyRepository) Delete(ctx context.Context, tx *gorm.DB, agencyId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Delete(&entity.Agency{}, "id = ?", agencyId)
+	return result.RowsAffected, result.Error
+}
+
+// SaveMember adds the user to the agency or updates their member role.
+func (r *agencyRepository) SaveMember(ctx context.Context, tx *gorm.DB, member entity.AgencyMember) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "agency_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(&member).Error
+}
+
+func (r *agencyRepository) DeleteMember(ctx context.Context, tx *gorm.DB, agencyId string, userId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("agency_id = ? AND user_id = ?", agencyId, userId).Delete(&entity.AgencyMember{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *agencyRepository) GetMembers(ctx context.Context, tx *gorm.DB, agencyId string) ([]entity.AgencyMember, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var members []entity.AgencyMember
+	if err := tx.WithContext(ctx).Preload("User").Where("agency_id = ?", agencyId).Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *agencyRepository) GetMemberships(ctx context.Context, tx *gorm.DB, userId string) ([]entity.AgencyMember, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var members []entity.AgencyMember
+	if err := tx.WithContext(ctx).Where("user_id = ?", userId).Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *agencyRepository) IsMember(ctx context.Context, tx *gorm.DB, agencyId string, userId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.AgencyMember{}).
+		Where("agency_id = ? AND user_id = ?", agencyId, userId).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *agencyRepository) GetRoutes(ctx context.Context, tx *gorm.DB) ([]entity.AgencyRoute, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var routes []entity.AgencyRoute
+	if err := tx.WithContext(ctx).Preload("Agency").Order("class").Order("region").Find(&routes).Error; err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+func (r *agencyRepository) GetRoutesByClass(ctx context.Context, tx *gorm.DB, classes []string) ([]entity.AgencyRoute, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var routes []entity.AgencyRoute
+	if err := tx.WithContext(ctx).Preload("Agency").Where("class IN ?", classes).Find(&routes).Error; err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// SaveRoute replaces the agency of an existing class and region pair.
+func (r *agencyRepository) SaveRoute(ctx context.Context, tx *gorm.DB, route entity.AgencyRoute) (entity.AgencyRoute, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "class"}, {Name: "region"}},
+		DoUpdates: clause.AssignmentColumns([]string{"agency_id", "updated_at"}),
+	}).Create(&route).Error; err != nil {
+		return entity.AgencyRoute{}, err
+	}
+
+	var saved entity.AgencyRoute
+	if err := tx.WithContext(ctx).Preload("Agency").
+		Where("class = ? AND region = ?", route.Class, route.Region).
+		Take(&saved).Error; err != nil {
+		return entity.AgencyRoute{}, err
+	}
+
+	return saved, nil
+}
+
+func (r *agencyRepository) DeleteRoute(ctx context.Context, tx *gorm.DB, routeId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Delete(&entity.AgencyRoute{}, "id = ?", routeId)
+	return result.RowsAffected, result.Error
+}
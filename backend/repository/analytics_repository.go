@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	AnalyticsRepository interface {
+		CountReportsByBucket(
+			ctx context.Context,
+			tx *gorm.DB,
+			interval string,
+			groupBy string,
+			from time.Time,
+			to time.Time,
+		) ([]dto.ReportBucketCount, error)
+		MedianTimeToStatus(
+			ctx context.Context,
+			tx *gorm.DB,
+			status entity.ReportStatus,
+			from time.Time,
+			to time.Time,
+		) (dto.ReportTransitionMedian, error)
+		SLACompliance(ctx context.Context, tx *gorm.DB, from time.Time, to time.Time) ([]dto.SLAComplianceCount, error)
+		CountRatings(
+			ctx context.Context,
+			tx *gorm.DB,
+			groupBy string,
+			from time.Time,
+			to time.Time,
+		) ([]dto.SatisfactionCount, error)
+	}
+
+	analyticsRepository struct {
+		db *gorm.DB
+	}
+)
+
+var analyticsGroupColumns = map[string]string{
+	"":                           "'total'",
+	dto.ANALYTICS_GROUP_CLASS:    "COALESCE(tags.class, 'unclassified')",
+	dto.ANALYTICS_GROUP_STATUS:   "reports.status",
+	dto.ANALYTICS_GROUP_LOCATION: "COALESCE(NULLIF(reports.location, ''), 'unknown')",
+}
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{
+		db: db,
+	}
+}
+
+// CountReportsByBucket counts reports created in [from, to) per interval
+// bucket (in Asia/Jakarta time) and group key. Like the report list, it
+// leaves out reports held for review. Empty buckets are not returned; the
+// service fills them.
+func (r *analyticsRepository) CountReportsByBucket(
+	ctx context.Context,
+	tx *gorm.DB,
+	interval string,
+	groupBy string,
+	from time.Time,
+	to time.Time,
+) ([]dto.ReportBucketCount, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	groupColumn, ok := analyticsGroupColumns[groupBy]
+	if !ok {
+		return nil, dto.ErrInvalidAnalyticsGroup
+	}
+
+	var counts []dto.ReportBucketCount
+	err := tx.WithContext(ctx).Table("reports").
+		Select(
+			fmt.Sprintf("to_char(date_trunc(?, reports.created_at AT TIME ZONE ?), 'YYYY-MM-DD') AS bucket, %s AS key, COUNT(*) AS count", groupColumn),
+			interval, constants.TIMEZONE,
+		).
+		Joins("LEFT JOIN tags ON tags.id = reports.tag_id").
+		Where("reports.created_at >= ? AND reports.created_at < ?", from, to).
+		Where("reports.status <> ?", entity.StatusPendingReview).
+		Group("bucket, key").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// MedianTimeToStatus is the median number of hours between a report being
+// created and it first reaching status, over reports created in [from, to).
+func (r *analyticsRepository) MedianTimeToStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	status entity.ReportStatus,
+	from time.Time,
+	to time.Time,
+) (dto.ReportTransitionMedian, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	reached := tx.Session(&gorm.Session{NewDB: true}).Model(&entity.ReportHistory{}).
+		Select("report_id, MIN(created_at) AS reached_at").
+		Where("event = ? AND to_status = ?", entity.HistoryStatusChanged, status).
+		Group("report_id")
+
+	var median dto.ReportTransitionMedian
+	err := tx.WithContext(ctx).Table("reports").
+		Select("percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (reached.reached_at - reports.created_at)) / 3600) AS median_hours, COUNT(*) AS count").
+		Joins("JOIN (?) AS reached ON reached.report_id = reports.id", reached).
+		Where("reports.created_at >= ? AND reports.created_at < ?", from, to).
+		Scan(&median).Error
+	if err != nil {
+		return dto.ReportTransitionMedian{}, err
+	}
+
+	return median, nil
+}
+
+// SLACompliance counts, per status, how many reports left it within their
+// SLA deadline in [from, to). Reports whose deadline fell in the range and
+// are still waiting count as misses.
+func (r *analyticsRepository) SLACompliance(ctx context.Context, tx *gorm.DB, from time.Time, to time.Time) ([]dto.SLAComplianceCount, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var counts []dto.SLAComplianceCount
+	err := tx.WithContext(ctx).Raw(`
+		SELECT status, SUM(met) AS met, COUNT(*) AS total
+		FROM (
+			SELECT from_status AS status, CASE WHEN sla_met THEN 1 ELSE 0 END AS met
+			FROM report_histories
+			WHERE event = ? AND sla_met IS NOT NULL AND created_at >= ? AND created_at < ?
+			UNION ALL
+			SELECT status, 0 AS met
+			FROM reports
+			WHERE sla_due_at >= ? AND sla_due_at < ? AND sla_due_at < now()
+		) AS outcomes
+		GROUP BY status
+		ORDER BY status
+	`, entity.HistoryStatusChanged, from, to, from, to).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// CountRatings counts ratings given in [from, to) per group key and score.
+func (r *analyticsRepository) CountRatings(
+	ctx context.Context,
+	tx *gorm.DB,
+	groupBy string,
+	from time.Time,
+	to time.Time,
+) ([]dto.SatisfactionCount, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	groupColumn, ok := analyticsGroupColumns[groupBy]
+	if !ok {
+		return nil, dto.ErrInvalidAnalyticsGroup
+	}
+
+	var counts []dto.SatisfactionCount
+	err := tx.WithContext(ctx).Table("report_ratings").
+		Select(fmt.Sprintf("%s AS key, report_ratings.score AS score, COUNT(*) AS count", groupColumn)).
+		Joins("JOIN reports ON reports.id = report_ratings.report_id").
+		Joins("LEFT JOIN tags ON tags.id = reports.tag_id").
+		Where("report_ratings.created_at >= ? AND report_ratings.created_at < ?", from, to).
+		Group("key, score").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	CommentRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, comment entity.Comment) (entity.Comment, error)
+		GetById(ctx context.Context, tx *gorm.DB, commentId string) (entity.Comment, error)
+		GetThreads(
+			ctx context.Context,
+			tx *gorm.DB,
+			reportId string,
+			includeInternal bool,
+			req dto.PaginationRequest,
+		) (dto.GetAllCommentRepositoryResponse, error)
+		GetReplies(ctx context.Context, tx *gorm.DB, parentIds []uuid.UUID, includeInternal bool) ([]entity.Comment, error)
+		GetParticipants(ctx context.Context, tx *gorm.DB, reportId string) ([]uuid.UUID, error)
+		UpdateBody(ctx context.Context, tx *gorm.DB, commentId string, body string, at time.Time) error
+		MarkDeleted(ctx context.Context, tx *gorm.DB, commentId string, at time.Time) error
+		Moderate(
+			ctx context.Context,
+			tx *gorm.DB,
+			commentId string,
+			hidden bool,
+			reason string,
+			moderatorId uuid.UUID,
+		) error
+	}
+
+	commentRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{
+		db: db,
+	}
+}
+
+func (r *commentRepository) Create(ctx context.Context, tx *gorm.DB, comment entity.Comment) (entity.Comment, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&comment).Error; err != nil {
+		return entity.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+func (r *commentRepository) GetById(ctx context.Context, tx *gorm.DB, commentId string) (entity.Comment, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var comment entity.Comment
+	if err := tx.WithContext(ctx).Preload("User").First(&comment, "id = ?", commentId).Error; err != nil {
+		return entity.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+// GetThreads pages through the top-level comments of a report, oldest
+// first like a conversation.
+func (r *commentRepository) GetThreads(
+	ctx context.Context,
+	tx *gorm.DB,
+	reportId string,
+	includeInternal bool,
+	req dto.PaginationRequest,
+) (dto.GetAllCommentRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var comments []entity.Comment
+	var count int64
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Model(&entity.Comment{}).Where("report_id = ? AND parent_id IS NULL", reportId)
+	if !includeInternal {
+		query = query.Where("visibility = ?", entity.CommentPublic)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllCommentRepositoryResponse{}, err
+	}
+
+	if err := query.Preload("User").Order("created_at, id").Scopes(Paginate(req)).Find(&comments).Error; err != nil {
+		return dto.GetAllCommentRepositoryResponse{}, err
+	}
+
+	return dto.GetAllCommentRepositoryResponse{
+		Comments: comments,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: TotalPage(count, int64(req.PerPage)),
+		},
+	}, nil
+}
+
+func (r *commentRepository) GetReplies(ctx context.Context, tx *gorm.DB, parentIds []uuid.UUID, includeInternal bool) ([]entity.Comment, error) {
+	if tx == nil {
+		tx = r.db
+	}
+	if len(parentIds) == 0 {
+		return nil, nil
+	}
+
+	query := tx.WithContext(ctx).Preload("User").Where("parent_id IN ?", parentIds)
+	if !includeInternal {
+		query = query.Where("visibility = ?", entity.CommentPublic)
+	}
+
+	var replies []entity.Comment
+	if err := query.Order("created_at, id").Find(&replies).Error; err != nil {
+		return nil, err
+	}
+
+	return replies, nil
+}
+
+// GetParticipants returns everyone who wrote a public comment on the
+// report, the watchers of its conversation.
+func (r *commentRepository) GetParticipants(ctx context.Context, tx *gorm.DB, reportId string) ([]uuid.UUID, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var userIds []uuid.UUID
+	if err := tx.WithContext(ctx).Model(&entity.Comment{}).
+		Where("report_id = ? AND visibility = ? AND deleted_at IS NULL", reportId, entity.CommentPublic).
+		Distinct().
+		Pluck("user_id", &userIds).Error; err != nil {
+		return nil, err
+	}
+
+	return userIds, nil
+}
+
+func (r *commentRepository) UpdateBody(ctx context.Context, tx *gorm.DB, commentId string, body string, at time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Comment{}).Where("id = ?", commentId).Updates(map[string]any{
+		"body":      body,
+		"edited_at": at,
+	}).Error
+}
+
+func (r *commentRepository) MarkDeleted(ctx context.Context, tx *gorm.DB, commentId string, at time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Comment{}).Where("id = ?", commentId).Updates(map[string]any{
+		"body":       "",
+		"deleted_at": at,
+	}).Error
+}
+
+func (r *commentRepository) Moderate(
+	ctx context.Context,
+	tx *gorm.DB,
+	commentId string,
+	hidden bool,
+	reason string,
+	moderatorId uuid.UUID,
+) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Comment{}).Where("id = ?", commentId).Updates(map[string]any{
+		"hidden":            hidden,
+		"moderation_reason": reason,
+		"moderated_by":      moderatorId,
+	}).Error
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"fmt"
 	"math"
+	"slices"
 
 	"github.com/Caknoooo/go-gin-clean-starter/dto"
 	"gorm.io/gorm"
@@ -19,3 +21,62 @@ func TotalPage(count, perPage int64) int64 {
 	
 	return totalPage
 }
+
+// KeysetPaginate seeks past the cursor on (created_at, id) instead of using
+// OFFSET, and fetches one extra row so KeysetPage can tell if more remain.
+// A backwards cursor flips the comparison and order; KeysetPage restores it.
+func KeysetPaginate(table string, cursor *dto.Cursor, limit int, desc bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		ascending := !desc
+		if cursor != nil && cursor.Prev {
+			ascending = !ascending
+		}
+
+		direction, comparator := "DESC", "<"
+		if ascending {
+			direction, comparator = "ASC", ">"
+		}
+
+		if cursor != nil {
+			db = db.Where(
+				fmt.Sprintf("(%s.created_at, %s.id) %s (?, ?)", table, table, comparator),
+				cursor.CreatedAt, cursor.ID,
+			)
+		}
+
+		return db.
+			Order(fmt.Sprintf("%s.created_at %s", table, direction)).
+			Order(fmt.Sprintf("%s.id %s", table, direction)).
+			Limit(limit + 1)
+	}
+}
+
+// KeysetPage trims the extra row fetched by KeysetPaginate, puts a backwards
+// page back into display order and builds the next/prev cursors.
+func KeysetPage[T any](rows []T, cursor *dto.Cursor, limit int, key func(T) dto.Cursor) ([]T, string, string) {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	backwards := cursor != nil && cursor.Prev
+	if backwards {
+		slices.Reverse(rows)
+	}
+
+	if len(rows) == 0 {
+		return rows, "", ""
+	}
+
+	var next, prev string
+	if hasMore || backwards {
+		next = key(rows[len(rows)-1]).Encode()
+	}
+	if cursor != nil && (!backwards || hasMore) {
+		first := key(rows[0])
+		first.Prev = true
+		prev = first.Encode()
+	}
+
+	return rows, next, prev
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	CompletionEvidenceRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, evidence entity.CompletionEvidence) (entity.CompletionEvidence, error)
+		GetLatestByReportId(ctx context.Context, tx *gorm.DB, reportId string) (entity.CompletionEvidence, error)
+		GetExpired(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.CompletionEvidence, error)
+		Resolve(
+			ctx context.Context,
+			tx *gorm.DB,
+			evidenceId string,
+			outcome entity.CompletionOutcome,
+			reason string,
+			at time.Time,
+		) (bool, error)
+	}
+
+	completionEvidenceRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewCompletionEvidenceRepository(db *gorm.DB) CompletionEvidenceRepository {
+	return &completionEvidenceRepository{
+		db: db,
+	}
+}
+
+func (r *completionEvidenceRepository) Create(ctx context.Context, tx *gorm.DB, evidence entity.CompletionEvidence) (entity.CompletionEvidence, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&evidence).Error; err != nil {
+		return entity.CompletionEvidence{}, err
+	}
+
+	return evidence, nil
+}
+
+func (r *completionEvidenceRepository) GetLatestByReportId(ctx context.Context, tx *gorm.DB, reportId string) (entity.CompletionEvidence, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var evidence entity.CompletionEvidence
+	if err := tx.WithContext(ctx).Where("report_id = ?", reportId).Order("created_at DESC").First(&evidence).Error; err != nil {
+		return entity.CompletionEvidence{}, err
+	}
+
+	return evidence, nil
+}
+
+// GetExpired returns pending evidence whose confirmation window has closed.
+func (r *completionEvidenceRepository) GetExpired(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.CompletionEvidence, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var evidences []entity.CompletionEvidence
+	if err := tx.WithContext(ctx).
+		Where("outcome = ? AND confirm_by < ?", entity.CompletionPending, now).
+		Order("confirm_by").
+		Limit(limit).
+		Find(&evidences).Error; err != nil {
+		return nil, err
+	}
+
+	return evidences, nil
+}
+
+// Resolve settles pending evidence. It returns false when the evidence was
+// no longer pending, e.g. the reporter and the expiry task raced.
+func (r *completionEvidenceRepository) Resolve(
+	ctx context.Context,
+	tx *gorm.DB,
+	evidenceId string,
+	outcome entity.CompletionOutcome,
+	reason string,
+	at time.Time,
+) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.CompletionEvidence{}).
+		Where("id = ? AND outcome = ?", evidenceId, entity.CompletionPending).
+		Updates(map[string]any{
+			"outcome":        outcome,
+			"dispute_reason": reason,
+			"responded_at":   at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	DeviceTokenRepository interface {
+		Upsert(ctx context.Context, tx *gorm.DB, device entity.DeviceToken) (entity.DeviceToken, error)
+		GetByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.DeviceToken, error)
+		DeleteByUserAndToken(ctx context.Context, tx *gorm.DB, userId string, token string) (int64, error)
+		DeleteByToken(ctx context.Context, tx *gorm.DB, token string) error
+	}
+
+	deviceTokenRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewDeviceTokenRepository(db *gorm.DB) DeviceTokenRepository {
+	return &deviceTokenRepository{
+		db: db,
+	}
+}
+
+// Upsert registers a token, moving it to device.UserID if another account
+// registered it before (a shared phone after logging out and in).
+func (r *deviceTokenRepository) Upsert(ctx context.Context, tx *gorm.DB, device entity.DeviceToken) (entity.DeviceToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "last_seen_at", "updated_at"}),
+	}).Create(&device).Error; err != nil {
+		return entity.DeviceToken{}, err
+	}
+
+	var saved entity.DeviceToken
+	if err := tx.WithContext(ctx).Where("token = ?", device.Token).Take(&saved).Error; err != nil {
+		return entity.DeviceToken{}, err
+	}
+
+	return saved, nil
+}
+
+func (r *deviceTokenRepository) GetByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.DeviceToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var devices []entity.DeviceToken
+	if err := tx.WithContext(ctx).Where("user_id = ?", userId).Order("last_seen_at DESC").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+func (r *deviceTokenRepository) DeleteByUserAndToken(ctx context.Context, tx *gorm.DB, userId string, token string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("user_id = ? AND token = ?", userId, token).Delete(&entity.DeviceToken{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *deviceTokenRepository) DeleteByToken(ctx context.Context, tx *gorm.DB, token string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Where("token = ?", token).Delete(&entity.DeviceToken{}).Error
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	JobRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, job entity.Job) (entity.Job, error)
+		GetById(ctx context.Context, tx *gorm.DB, jobId string) (entity.Job, error)
+		GetJobs(ctx context.Context, tx *gorm.DB, req dto.JobListRequest) (dto.GetAllJobRepositoryResponse, error)
+		Claim(ctx context.Context, tx *gorm.DB, workerId string, types []string, limit int) ([]entity.Job, error)
+		Complete(ctx context.Context, tx *gorm.DB, jobId string) error
+		Fail(ctx context.Context, tx *gorm.DB, job entity.Job, lastError string, retryAt *time.Time) error
+		Retry(ctx context.Context, tx *gorm.DB, jobId string) (entity.Job, error)
+		ReleaseStale(ctx context.Context, tx *gorm.DB, lockedBefore time.Time) (int64, error)
+	}
+
+	jobRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{
+		db: db,
+	}
+}
+
+func (r *jobRepository) Create(ctx context.Context, tx *gorm.DB, job entity.Job) (entity.Job, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&job).Error; err != nil {
+		return entity.Job{}, err
+	}
+
+	return job, nil
+}
+
+func (r *jobRepository) GetById(ctx context.Context, tx *gorm.DB, jobId string) (entity.Job, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var job entity.Job
+	if err := tx.WithContext(ctx).Where("id = ?", jobId).Take(&job).Error; err != nil {
+		return entity.Job{}, err
+	}
+
+	return job, nil
+}
+
+func (r *jobRepository) GetJobs(ctx context.Context, tx *gorm.DB, req dto.JobListRequest) (dto.GetAllJobRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var jobs []entity.Job
+	var count int64
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Model(&entity.Job{})
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.Type != "" {
+		query = query.Where("type = ?", req.Type)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllJobRepositoryResponse{}, err
+	}
+
+	if err := query.Order("created_at DESC").Scopes(Paginate(req.PaginationRequest)).Find(&jobs).Error; err != nil {
+		return dto.GetAllJobRepositoryResponse{}, err
+	}
+
+	return dto.GetAllJobRepositoryResponse{
+		Jobs: jobs,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: TotalPage(count, int64(req.PerPage)),
+		},
+	}, nil
+}
+
+// Claim locks up to limit due jobs of the given types for workerId and
+// marks them running in one statement. SKIP LOCKED lets concurrent workers
+// claim disjoint batches without waiting on each other.
+func (r *jobRepository) Claim(ctx context.Context, tx *gorm.DB, workerId string, types []string, limit int) ([]entity.Job, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var jobs []entity.Job
+	if len(types) == 0 || limit <= 0 {
+		return jobs, nil
+	}
+
+	err := tx.WithContext(ctx).Raw(`
+		UPDATE jobs SET
+			status = ?,
+			attempts = attempts + 1,
+			locked_at = now(),
+			locked_by = ?,
+			updated_at = now()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE status = ? AND run_at <= now() AND type IN ?
+			ORDER BY run_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		entity.JobRunning, workerId, entity.JobPending, types, limit,
+	).Scan(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *jobRepository) Complete(ctx context.Context, tx *gorm.DB, jobId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Job{}).Where("id = ?", jobId).Updates(map[string]any{
+		"status":       entity.JobCompleted,
+		"completed_at": time.Now(),
+		"locked_at":    nil,
+		"locked_by":    "",
+		"last_error":   "",
+	}).Error
+}
+
+// Fail records a failed attempt. With retryAt the job goes back to pending
+// for another attempt, without it the job is dead.
+func (r *jobRepository) Fail(ctx context.Context, tx *gorm.DB, job entity.Job, lastError string, retryAt *time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	updates := map[string]any{
+		"status":     entity.JobDead,
+		"last_error": lastError,
+		"locked_at":  nil,
+		"locked_by":  "",
+	}
+	if retryAt != nil {
+		updates["status"] = entity.JobPending
+		updates["run_at"] = *retryAt
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Job{}).Where("id = ?", job.ID).Updates(updates).Error
+}
+
+// Retry makes a dead (or still pending) job due now with a fresh set of
+// attempts.
+func (r *jobRepository) Retry(ctx context.Context, tx *gorm.DB, jobId string) (entity.Job, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.Job{}).
+		Where("id = ? AND status IN ?", jobId, []entity.JobStatus{entity.JobDead, entity.JobPending}).
+		Updates(map[string]any{
+			"status":   entity.JobPending,
+			"attempts": 0,
+			"run_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return entity.Job{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.Job{}, gorm.ErrRecordNotFound
+	}
+
+	return r.GetById(ctx, tx, jobId)
+}
+
+// ReleaseStale hands running jobs locked before lockedBefore back to the
+// queue. Their worker is assumed dead; the attempt still counts.
+func (r *jobRepository) ReleaseStale(ctx context.Context, tx *gorm.DB, lockedBefore time.Time) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.Job{}).
+		Where("status = ? AND locked_at < ?", entity.JobRunning, lockedBefore).
+		Updates(map[string]any{
+			"status":     gorm.Expr("CASE WHEN attempts >= max_attempts THEN ? ELSE ? END", entity.JobDead, entity.JobPending),
+			"last_error": "worker lock expired",
+			"run_at":     time.Now(),
+			"locked_at":  nil,
+			"locked_by":  "",
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
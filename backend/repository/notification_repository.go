@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	NotificationRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, notification entity.Notification) (entity.Notification, error)
+		GetByUserId(
+			ctx context.Context,
+			tx *gorm.DB,
+			userId string,
+			req dto.NotificationListRequest,
+		) (dto.GetAllNotificationRepositoryResponse, error)
+		CountUnread(ctx context.Context, tx *gorm.DB, userId string) (int64, error)
+		MarkRead(ctx context.Context, tx *gorm.DB, userId string, notificationId string) (entity.Notification, error)
+		MarkAllRead(ctx context.Context, tx *gorm.DB, userId string) (int64, error)
+	}
+
+	notificationRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, tx *gorm.DB, notification entity.Notification) (entity.Notification, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&notification).Error; err != nil {
+		return entity.Notification{}, err
+	}
+
+	return notification, nil
+}
+
+func (r *notificationRepository) GetByUserId(
+	ctx context.Context,
+	tx *gorm.DB,
+	userId string,
+	req dto.NotificationListRequest,
+) (dto.GetAllNotificationRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var notifications []entity.Notification
+	var count int64
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ?", userId)
+	if req.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllNotificationRepositoryResponse{}, err
+	}
+
+	if err := query.Order("created_at DESC").Scopes(Paginate(req.PaginationRequest)).Find(&notifications).Error; err != nil {
+		return dto.GetAllNotificationRepositoryResponse{}, err
+	}
+
+	return dto.GetAllNotificationRepositoryResponse{
+		Notifications: notifications,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: TotalPage(count, int64(req.PerPage)),
+		},
+	}, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, tx *gorm.DB, userId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkRead only touches notifications owned by userId, so one user cannot
+// mark another's as read. Reading twice keeps the first read_at.
+func (r *notificationRepository) MarkRead(ctx context.Context, tx *gorm.DB, userId string, notificationId string) (entity.Notification, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var notification entity.Notification
+	if err := tx.WithContext(ctx).Where("id = ? AND user_id = ?", notificationId, userId).Take(&notification).Error; err != nil {
+		return entity.Notification{}, err
+	}
+
+	if notification.ReadAt != nil {
+		return notification, nil
+	}
+
+	now := time.Now()
+	if err := tx.WithContext(ctx).Model(&notification).Update("read_at", now).Error; err != nil {
+		return entity.Notification{}, err
+	}
+	notification.ReadAt = &now
+
+	return notification, nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, tx *gorm.DB, userId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	RateLimitRepository interface {
+		// GetBucketForUpdate creates the bucket from initial if it does not
+		// exist yet, then locks it until tx ends.
+		GetBucketForUpdate(ctx context.Context, tx *gorm.DB, initial entity.RateLimitBucket) (entity.RateLimitBucket, error)
+		SaveBucket(ctx context.Context, tx *gorm.DB, bucket entity.RateLimitBucket) error
+		DeleteIdleBuckets(ctx context.Context, tx *gorm.DB, before time.Time) error
+	}
+
+	rateLimitRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewRateLimitRepository(db *gorm.DB) RateLimitRepository {
+	return &rateLimitRepository{
+		db: db,
+	}
+}
+
+func (r *rateLimitRepository) GetBucketForUpdate(ctx context.Context, tx *gorm.DB, initial entity.RateLimitBucket) (entity.RateLimitBucket, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&initial).Error; err != nil {
+		return entity.RateLimitBucket{}, err
+	}
+
+	var bucket entity.RateLimitBucket
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&bucket, "key = ?", initial.Key).Error; err != nil {
+		return entity.RateLimitBucket{}, err
+	}
+
+	return bucket, nil
+}
+
+func (r *rateLimitRepository) SaveBucket(ctx context.Context, tx *gorm.DB, bucket entity.RateLimitBucket) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.RateLimitBucket{}).Where("key = ?", bucket.Key).
+		Updates(map[string]any{"tokens": bucket.Tokens, "updated_at": bucket.UpdatedAt}).Error
+}
+
+// DeleteIdleBuckets removes buckets untouched since before. A bucket that
+// has been idle for a whole period is full again, so forgetting it changes
+// nothing.
+func (r *rateLimitRepository) DeleteIdleBuckets(ctx context.Context, tx *gorm.DB, before time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Delete(&entity.RateLimitBucket{}, "updated_at < ?", before).Error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	RejectionReasonRepository interface {
+		GetAll(ctx context.Context, tx *gorm.DB, activeOnly bool) ([]entity.RejectionReason, error)
+		GetByCode(ctx context.Context, tx *gorm.DB, code string) (entity.RejectionReason, error)
+		Save(ctx context.Context, tx *gorm.DB, reason entity.RejectionReason) (entity.RejectionReason, error)
+		Deactivate(ctx context.Context, tx *gorm.DB, code string) (int64, error)
+	}
+
+	rejectionReasonRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewRejectionReasonRepository(db *gorm.DB) RejectionReasonRepository {
+	return &rejectionReasonRepository{
+		db: db,
+	}
+}
+
+func (r *rejectionReasonRepository) GetAll(ctx context.Context, tx *gorm.DB, activeOnly bool) ([]entity.RejectionReason, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Order("code")
+	if activeOnly {
+		query = query.Where("active")
+	}
+
+	var reasons []entity.RejectionReason
+	if err := query.Find(&reasons).Error; err != nil {
+		return nil, err
+	}
+
+	return reasons, nil
+}
+
+func (r *rejectionReasonRepository) GetByCode(ctx context.Context, tx *gorm.DB, code string) (entity.RejectionReason, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var reason entity.RejectionReason
+	if err := tx.WithContext(ctx).First(&reason, "code = ?", code).Error; err != nil {
+		return entity.RejectionReason{}, err
+	}
+
+	return reason, nil
+}
+
+// Save creates the code or rewrites its descriptions and spam flag,
+// reactivating it.
+func (r *rejectionReasonRepository) Save(ctx context.Context, tx *gorm.DB, reason entity.RejectionReason) (entity.RejectionReason, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	reason.Active = true
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description_id", "description_en", "spam", "active", "updated_at"}),
+	}).Create(&reason).Error; err != nil {
+		return entity.RejectionReason{}, err
+	}
+
+	return r.GetByCode(ctx, tx, reason.Code)
+}
+
+func (r *rejectionReasonRepository) Deactivate(ctx context.Context, tx *gorm.DB, code string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.RejectionReason{}).Where("code = ?", code).Update("active", false)
+	return result.RowsAffected, result.Error
+}
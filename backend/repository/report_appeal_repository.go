@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ReportAppealRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, appeal entity.ReportAppeal) (entity.ReportAppeal, error)
+		GetById(ctx context.Context, tx *gorm.DB, appealId string) (entity.ReportAppeal, error)
+		GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) (entity.ReportAppeal, error)
+		GetAppeals(ctx context.Context, tx *gorm.DB, req dto.AppealListRequest) (dto.GetAllAppealRepositoryResponse, error)
+		Review(
+			ctx context.Context,
+			tx *gorm.DB,
+			appealId string,
+			status entity.AppealStatus,
+			reviewerId *uuid.UUID,
+			note string,
+			at time.Time,
+		) (bool, error)
+	}
+
+	reportAppealRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReportAppealRepository(db *gorm.DB) ReportAppealRepository {
+	return &reportAppealRepository{
+		db: db,
+	}
+}
+
+func (r *reportAppealRepository) Create(ctx context.Context, tx *gorm.DB, appeal entity.ReportAppeal) (entity.ReportAppeal, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&appeal).Error; err != nil {
+		return entity.ReportAppeal{}, err
+	}
+
+	return appeal, nil
+}
+
+func (r *reportAppealRepository) GetById(ctx context.Context, tx *gorm.DB, appealId string) (entity.ReportAppeal, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var appeal entity.ReportAppeal
+	if err := tx.WithContext(ctx).First(&appeal, "id = ?", appealId).Error; err != nil {
+		return entity.ReportAppeal{}, err
+	}
+
+	return appeal, nil
+}
+
+func (r *reportAppealRepository) GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) (entity.ReportAppeal, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var appeal entity.ReportAppeal
+	if err := tx.WithContext(ctx).First(&appeal, "report_id = ?", reportId).Error; err != nil {
+		return entity.ReportAppeal{}, err
+	}
+
+	return appeal, nil
+}
+
+// GetAppeals lists appeals of one status, the oldest first so the queue is
+// worked in order.
+func (r *reportAppealRepository) GetAppeals(ctx context.Context, tx *gorm.DB, req dto.AppealListRequest) (dto.GetAllAppealRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var appeals []entity.ReportAppeal
+	var count int64
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Model(&entity.ReportAppeal{}).Where("status = ?", req.Status)
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllAppealRepositoryResponse{}, err
+	}
+
+	if err := query.Order("created_at").Scopes(Paginate(req.PaginationRequest)).Find(&appeals).Error; err != nil {
+		return dto.GetAllAppealRepositoryResponse{}, err
+	}
+
+	return dto.GetAllAppealRepositoryResponse{
+		Appeals: appeals,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: TotalPage(count, int64(req.PerPage)),
+		},
+	}, nil
+}
+
+// Review settles a pending appeal. It returns false when another admin got
+// there first.
+func (r *reportAppealRepository) Review(
+	ctx context.Context,
+	tx *gorm.DB,
+	appealId string,
+	status entity.AppealStatus,
+	reviewerId *uuid.UUID,
+	note string,
+	at time.Time,
+) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.ReportAppeal{}).
+		Where("id = ? AND status = ?", appealId, entity.AppealPending).
+		Updates(map[string]any{
+			"status":      status,
+			"reviewer_id": reviewerId,
+			"review_note": note,
+			"reviewed_at": at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	ReportExportRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, export entity.ReportExport) (entity.ReportExport, error)
+		GetById(ctx context.Context, tx *gorm.DB, exportId string) (entity.ReportExport, error)
+		Update(ctx context.Context, tx *gorm.DB, export entity.ReportExport) (entity.ReportExport, error)
+		DeleteBefore(ctx context.Context, tx *gorm.DB, before time.Time) (int64, error)
+	}
+
+	reportExportRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReportExportRepository(db *gorm.DB) ReportExportRepository {
+	return &reportExportRepository{
+		db: db,
+	}
+}
+
+func (r *reportExportRepository) Create(ctx context.Context, tx *gorm.DB, export entity.ReportExport) (entity.ReportExport, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&export).Error; err != nil {
+		return entity.ReportExport{}, err
+	}
+
+	return export, nil
+}
+
+func (r *reportExportRepository) GetById(ctx context.Context, tx *gorm.DB, exportId string) (entity.ReportExport, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var export entity.ReportExport
+	if err := tx.WithContext(ctx).Where("id = ?", exportId).Take(&export).Error; err != nil {
+		return entity.ReportExport{}, err
+	}
+
+	return export, nil
+}
+
+func (r *reportExportRepository) Update(ctx context.Context, tx *gorm.DB, export entity.ReportExport) (entity.ReportExport, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Save(&export).Error; err != nil {
+		return entity.ReportExport{}, err
+	}
+
+	return export, nil
+}
+
+// DeleteBefore deletes the exports requested before the given time.
+func (r *reportExportRepository) DeleteBefore(ctx context.Context, tx *gorm.DB, before time.Time) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("created_at < ?", before).Delete(&entity.ReportExport{})
+	return result.RowsAffected, result.Error
+}
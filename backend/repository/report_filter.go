@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+var reportSortColumns = map[string]string{
+	dto.REPORT_SORT_CREATED_AT: "reports.created_at",
+	dto.REPORT_SORT_UPVOTES:    "reports.upvotes",
+	dto.REPORT_SORT_CONFIDENCE: "reports.pred_confidence",
+	dto.REPORT_SORT_DUE_AT:     "reports.due_at",
+}
+
+// FilterReports applies every non-empty field of the filter to a query on
+// the reports table.
+func FilterReports(req dto.ReportFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.Search != "" {
+			// full-text match, with trigram word similarity to catch typos
+			db = db.Where(
+				"(reports.search_vector @@ websearch_to_tsquery(?::regconfig, ?) OR ? <% reports.text)",
+				config.ReportSearchConfig(), req.Search, req.Search,
+			)
+		}
+
+		if statuses := req.Statuses(); len(statuses) > 0 {
+			db = db.Where("reports.status IN ?", statuses)
+		}
+
+		if !req.IncludeHeld {
+			db = db.Where("reports.status <> ?", entity.StatusPendingReview)
+		}
+
+		if req.Class != "" {
+			db = db.Where("reports.tag_id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+				Table("tags").Select("id").Where("class = ?", req.Class))
+		}
+
+		if req.TagID != "" {
+			db = db.Where("reports.tag_id = ?", req.TagID)
+		}
+
+		if req.UserID != "" {
+			db = db.Where("reports.user_id = ?", req.UserID)
+		}
+
+		if req.AgencyID != "" {
+			db = db.Where("reports.agency_id = ?", req.AgencyID)
+		}
+
+		if len(req.AgencyIDs) > 0 {
+			db = db.Where("reports.agency_id IN ?", req.AgencyIDs)
+		}
+
+		if req.Overdue {
+			db = db.Where("reports.sla_due_at < now()")
+		}
+
+		if req.AssigneeID != "" {
+			db = db.Where("reports.assignee_id = ?", req.AssigneeID)
+		}
+
+		if req.Location != "" {
+			db = db.Where("reports.location ILIKE ?", "%"+req.Location+"%")
+		}
+
+		if !req.CreatedFrom.IsZero() {
+			db = db.Where("reports.created_at >= ?", req.CreatedFrom)
+		}
+
+		if !req.CreatedTo.IsZero() {
+			// created_to is a whole day, so include everything before the next one
+			db = db.Where("reports.created_at < ?", req.CreatedTo.AddDate(0, 0, 1))
+		}
+
+		if req.MinUpvotes > 0 {
+			db = db.Where("reports.upvotes >= ?", req.MinUpvotes)
+		}
+
+		if req.VoteFlagged {
+			db = db.Where("reports.vote_flagged_at IS NOT NULL")
+		}
+
+		return db
+	}
+}
+
+// escapedReportText is the report text with the HTML special characters
+// escaped, so the only markup in a highlight is the <mark> tags that
+// ts_headline adds around the matches.
+const escapedReportText = `replace(replace(replace(replace(replace(coalesce(reports.text, ''),
+	'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+
+// SearchReports selects the rank and a highlighted snippet of the text
+// when the filter carries a search term. It must not be combined with Count.
+func SearchReports(req dto.ReportFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.Search == "" {
+			return db
+		}
+
+		searchConfig := config.ReportSearchConfig()
+		return db.Select(
+			`reports.*,
+			ts_rank(reports.search_vector, websearch_to_tsquery(?::regconfig, ?)) + word_similarity(?, reports.text) AS search_rank,
+			ts_headline(?::regconfig, `+escapedReportText+`, websearch_to_tsquery(?::regconfig, ?),
+				'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS highlight`,
+			searchConfig, req.Search, req.Search,
+			searchConfig, searchConfig, req.Search,
+		)
+	}
+}
+
+func SortReports(req dto.ReportFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.SortBy == dto.REPORT_SORT_RELEVANCE && req.Search != "" {
+			return db.Order("search_rank DESC").Order("reports.created_at DESC").Order("reports.id DESC")
+		}
+
+		column, ok := reportSortColumns[req.SortBy]
+		if !ok {
+			column = reportSortColumns[dto.REPORT_SORT_CREATED_AT]
+		}
+
+		direction := "DESC"
+		if strings.EqualFold(req.SortDir, dto.REPORT_SORT_ASC) {
+			direction = "ASC"
+		}
+
+		nulls := ""
+		if req.SortBy == dto.REPORT_SORT_CONFIDENCE || req.SortBy == dto.REPORT_SORT_DUE_AT {
+			nulls = " NULLS LAST"
+		}
+
+		return db.Order(column + " " + direction + nulls).Order("reports.id " + direction)
+	}
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ReportHistoryRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, history entity.ReportHistory) (entity.ReportHistory, error)
+		GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) ([]entity.ReportHistory, error)
+		FirstStatusTimes(ctx context.Context, tx *gorm.DB, reportIds []uuid.UUID) (map[uuid.UUID]map[entity.ReportStatus]time.Time, error)
+	}
+
+	reportHistoryRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReportHistoryRepository(db *gorm.DB) ReportHistoryRepository {
+	return &reportHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *reportHistoryRepository) Create(ctx context.Context, tx *gorm.DB, history entity.ReportHistory) (entity.ReportHistory, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&history).Error; err != nil {
+		return entity.ReportHistory{}, err
+	}
+
+	return history, nil
+}
+
+func (r *reportHistoryRepository) GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) ([]entity.ReportHistory, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var histories []entity.ReportHistory
+	if err := tx.WithContext(ctx).Where("report_id = ?", reportId).Order("created_at ASC").Find(&histories).Error; err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+// FirstStatusTimes returns, per report, when it first reached each status.
+func (r *reportHistoryRepository) FirstStatusTimes(
+	ctx context.Context,
+	tx *gorm.DB,
+	reportIds []uuid.UUID,
+) (map[uuid.UUID]map[entity.ReportStatus]time.Time, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	times := make(map[uuid.UUID]map[entity.ReportStatus]time.Time, len(reportIds))
+	if len(reportIds) == 0 {
+		return times, nil
+	}
+
+	var rows []struct {
+		ReportID  uuid.UUID
+		ToStatus  entity.ReportStatus
+		ReachedAt time.Time
+	}
+	if err := tx.WithContext(ctx).Model(&entity.ReportHistory{}).
+		Select("report_id, to_status, MIN(created_at) AS reached_at").
+		Where("event = ? AND report_id IN ?", entity.HistoryStatusChanged, reportIds).
+		Group("report_id, to_status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if times[row.ReportID] == nil {
+			times[row.ReportID] = map[entity.ReportStatus]time.Time{}
+		}
+		times[row.ReportID][row.ToStatus] = row.ReachedAt
+	}
+
+	return times, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	ReportRatingRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, rating entity.ReportRating) (entity.ReportRating, error)
+		GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) (entity.ReportRating, error)
+	}
+
+	reportRatingRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReportRatingRepository(db *gorm.DB) ReportRatingRepository {
+	return &reportRatingRepository{
+		db: db,
+	}
+}
+
+func (r *reportRatingRepository) Create(ctx context.Context, tx *gorm.DB, rating entity.ReportRating) (entity.ReportRating, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&rating).Error; err != nil {
+		return entity.ReportRating{}, err
+	}
+
+	return rating, nil
+}
+
+func (r *reportRatingRepository) GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) (entity.ReportRating, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var rating entity.ReportRating
+	if err := tx.WithContext(ctx).First(&rating, "report_id = ?", reportId).Error; err != nil {
+		return entity.ReportRating{}, err
+	}
+
+	return rating, nil
+}
@@ -5,26 +5,40 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/pubsub/v2"
 	"github.com/Caknoooo/go-gin-clean-starter/dto"
 	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	ReportRepository interface {
 		CreateReport(ctx context.Context, tx *gorm.DB, report entity.Report) (entity.Report, error)
-		GetAllReportsWithPagination(
-			ctx context.Context,
-			tx *gorm.DB,
-			req dto.PaginationRequest,
-		) (dto.GetAllReportResponse, error)
+		GetReports(ctx context.Context, tx *gorm.DB, req dto.ReportFilterRequest) (dto.GetAllReportResponse, error)
+		CountReports(ctx context.Context, tx *gorm.DB, req dto.ReportFilterRequest) (int64, error)
+		StreamReports(ctx context.Context, tx *gorm.DB, req dto.ReportFilterRequest, batchSize int, fn func([]entity.Report) error) error
 		GetReportById(ctx context.Context, tx *gorm.DB, reportId string) (entity.Report, error)
-		GetReportsByUserId(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllReportResponse, error)
+		GetTagById(ctx context.Context, tx *gorm.DB, tagId string) (entity.Tag, error)
+		UpdateAssignment(ctx context.Context, tx *gorm.DB, reportId string, assigneeId *uuid.UUID, dueAt *time.Time) error
+		UpdateAgency(ctx context.Context, tx *gorm.DB, reportId string, agencyId *uuid.UUID) error
+		GetReportForUpdate(ctx context.Context, tx *gorm.DB, reportId string) (entity.Report, error)
+		UpdateRejectionCode(ctx context.Context, tx *gorm.DB, reportId string, code string) error
+		AddUpvotes(ctx context.Context, tx *gorm.DB, reportId string, delta int) (int, error)
+		LockUserReports(ctx context.Context, tx *gorm.DB, userId string) error
+		GetReportActivity(ctx context.Context, tx *gorm.DB, userId string, since time.Time) (dto.ReportActivity, error)
+		GetVoterRecord(ctx context.Context, tx *gorm.DB, userId string) (dto.VoterRecord, error)
+		MarkVoteFlagged(ctx context.Context, tx *gorm.DB, reportId string, at time.Time) (bool, error)
+		UpdateSLA(ctx context.Context, tx *gorm.DB, reportId string, policyId *uuid.UUID, dueAt *time.Time) error
+		GetSLABreaches(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.Report, error)
+		MarkSLAEscalated(ctx context.Context, tx *gorm.DB, reportId string, at time.Time) (bool, error)
+		GetUnratedCompleted(ctx context.Context, tx *gorm.DB, completedBefore time.Time, limit int) ([]entity.Report, error)
+		MarkRatingReminded(ctx context.Context, tx *gorm.DB, reportId string, at time.Time) (bool, error)
 		UpdateReportStatus(ctx context.Context, tx *gorm.DB, reportId string, status entity.ReportStatus) (dto.UpdateStatusReportResponse, error)
-		CountReportStatus(ctx context.Context, tx *gorm.DB) (dto.CountReportResponse, error)
-		GetReportsByStatus(ctx context.Context, tx *gorm.DB, status entity.ReportStatus, req dto.PaginationRequest) (dto.GetAllReportResponse, error)
+		CountReportStatus(ctx context.Context, tx *gorm.DB, agencyIds []string) (dto.CountReportResponse, error)
 		UpdateReportInference(ctx context.Context, tx *gorm.DB, report entity.Report, class string, location string) ([]entity.Tag, error)
 	}
 
@@ -86,10 +100,10 @@ func (r *reportRepository) CreateReport(ctx context.Context, tx *gorm.DB, report
 	return createdReport, nil
 }
 
-func (r *reportRepository) GetAllReportsWithPagination(
+func (r *reportRepository) GetReports(
 	ctx context.Context,
 	tx *gorm.DB,
-	req dto.PaginationRequest,
+	req dto.ReportFilterRequest,
 ) (dto.GetAllReportResponse, error) {
 	if tx == nil {
 		tx = r.db
@@ -101,29 +115,88 @@ func (r *reportRepository) GetAllReportsWithPagination(
 
 	req.Default()
 
-	query := tx.WithContext(ctx).Model(&entity.Report{})
-	if req.Search != "" {
-		query = query.Where("text LIKE ?", "%"+req.Search+"%")
+	query := tx.WithContext(ctx).Model(&entity.Report{}).Scopes(FilterReports(req))
+
+	if req.WantsCount() {
+		if err := query.Count(&count).Error; err != nil {
+			return dto.GetAllReportResponse{}, err
+		}
+	}
+
+	response := dto.GetAllReportResponse{
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: TotalPage(count, int64(req.PerPage)),
+		},
 	}
 
-	if err := query.Count(&count).Error; err != nil {
+	query = query.Preload("Tag").Preload("User").Scopes(SearchReports(req))
+
+	if !req.IsCursor() {
+		if err := query.Scopes(SortReports(req), Paginate(req.PaginationRequest)).Find(&reports).Error; err != nil {
+			return dto.GetAllReportResponse{}, err
+		}
+
+		response.Reports = reports
+		return response, err
+	}
+
+	cursor, err := dto.DecodeCursor(req.Cursor)
+	if err != nil {
 		return dto.GetAllReportResponse{}, err
 	}
 
-	if err := query.Preload("Tag").Preload("User").Scopes(Paginate(req)).Find(&reports).Error; err != nil {
+	desc := !strings.EqualFold(req.SortDir, dto.REPORT_SORT_ASC)
+	if err := query.Scopes(KeysetPaginate("reports", cursor, req.PerPage, desc)).Find(&reports).Error; err != nil {
 		return dto.GetAllReportResponse{}, err
 	}
 
-	totalPage := TotalPage(count, int64(req.PerPage))
-	return dto.GetAllReportResponse{
-		Reports: reports,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    req.Page,
-			PerPage: req.PerPage,
-			Count:   count,
-			MaxPage: totalPage,
+	reports, response.NextCursor, response.PrevCursor = KeysetPage(reports, cursor, req.PerPage,
+		func(report entity.Report) dto.Cursor {
+			return dto.Cursor{CreatedAt: report.CreatedAt, ID: report.ID.String()}
 		},
-	}, err
+	)
+	response.Reports = reports
+
+	return response, nil
+}
+
+func (r *reportRepository) CountReports(ctx context.Context, tx *gorm.DB, req dto.ReportFilterRequest) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Report{}).Scopes(FilterReports(req)).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// StreamReports walks every report matching the filter in primary key
+// order, handing fn one batch at a time so exports never hold the whole
+// result set. Pagination and sorting fields of req are ignored.
+func (r *reportRepository) StreamReports(
+	ctx context.Context,
+	tx *gorm.DB,
+	req dto.ReportFilterRequest,
+	batchSize int,
+	fn func([]entity.Report) error,
+) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var batch []entity.Report
+	return tx.WithContext(ctx).Model(&entity.Report{}).
+		Scopes(FilterReports(req)).
+		Preload("Tag").Preload("User").
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
 }
 
 func (r *reportRepository) GetReportById(ctx context.Context, tx *gorm.DB, reportId string) (entity.Report, error) {
@@ -139,41 +212,243 @@ func (r *reportRepository) GetReportById(ctx context.Context, tx *gorm.DB, repor
 	return report, nil
 }
 
-func (r *reportRepository) GetReportsByUserId(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllReportResponse, error) {
+func (r *reportRepository) GetTagById(ctx context.Context, tx *gorm.DB, tagId string) (entity.Tag, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var tag entity.Tag
+	if err := tx.WithContext(ctx).First(&tag, "id = ?", tagId).Error; err != nil {
+		return entity.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// UpdateAssignment sets or, with nil values, clears the report's officer.
+func (r *reportRepository) UpdateAssignment(
+	ctx context.Context,
+	tx *gorm.DB,
+	reportId string,
+	assigneeId *uuid.UUID,
+	dueAt *time.Time,
+) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Report{}).Where("id = ?", reportId).Updates(map[string]any{
+		"assignee_id": assigneeId,
+		"due_at":      dueAt,
+	}).Error
+}
+
+func (r *reportRepository) UpdateAgency(ctx context.Context, tx *gorm.DB, reportId string, agencyId *uuid.UUID) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Report{}).Where("id = ?", reportId).Update("agency_id", agencyId).Error
+}
+
+// GetReportForUpdate locks the report row until tx ends, so concurrent
+// decisions on the same report run one after another.
+func (r *reportRepository) GetReportForUpdate(ctx context.Context, tx *gorm.DB, reportId string) (entity.Report, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var report entity.Report
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Tag").Preload("User").First(&report, "id = ?", reportId).Error; err != nil {
+		return entity.Report{}, err
+	}
+
+	return report, nil
+}
+
+func (r *reportRepository) UpdateRejectionCode(ctx context.Context, tx *gorm.DB, reportId string, code string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Report{}).Where("id = ?", reportId).Update("rejection_code", code).Error
+}
+
+// AddUpvotes shifts the upvote counter and returns the new count.
+func (r *reportRepository) AddUpvotes(ctx context.Context, tx *gorm.DB, reportId string, delta int) (int, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var upvotes int
+	if err := tx.WithContext(ctx).Model(&entity.Report{}).
+		Where("id = ?", reportId).
+		Update("upvotes", gorm.Expr("GREATEST(upvotes + ?, 0)", delta)).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.WithContext(ctx).Model(&entity.Report{}).Select("upvotes").Where("id = ?", reportId).Scan(&upvotes).Error; err != nil {
+		return 0, err
+	}
+
+	return upvotes, nil
+}
+
+// LockUserReports takes the user's report advisory lock for the rest of
+// tx, waiting for it if need be, so reports by one user are filed one
+// after another.
+func (r *reportRepository) LockUserReports(ctx context.Context, tx *gorm.DB, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).
+		Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", "user_reports:"+userId).Error
+}
+
+// GetReportActivity counts the reports a user filed since the given time
+// and finds their latest one.
+func (r *reportRepository) GetReportActivity(ctx context.Context, tx *gorm.DB, userId string, since time.Time) (dto.ReportActivity, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var activity dto.ReportActivity
+	if err := tx.WithContext(ctx).Model(&entity.Report{}).
+		Select("COUNT(*) FILTER (WHERE created_at >= ?) AS count, MAX(created_at) AS last_at", since).
+		Where("user_id = ?", userId).
+		Scan(&activity).Error; err != nil {
+		return dto.ReportActivity{}, err
+	}
+
+	return activity, nil
+}
+
+// GetVoterRecord counts the reports of a user that were accepted, meaning
+// verified or further along, and those that were rejected, and sums their
+// upvotes.
+func (r *reportRepository) GetVoterRecord(ctx context.Context, tx *gorm.DB, userId string) (dto.VoterRecord, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var record dto.VoterRecord
+	if err := tx.WithContext(ctx).Model(&entity.Report{}).
+		Select(
+			"COUNT(*) FILTER (WHERE status IN ?) AS accepted, COUNT(*) FILTER (WHERE status = ?) AS rejected, COALESCE(SUM(upvotes), 0) AS upvotes",
+			[]entity.ReportStatus{entity.StatusVerified, entity.StatusHandled, entity.StatusCompleted},
+			entity.StatusRejected,
+		).
+		Where("user_id = ?", userId).
+		Scan(&record).Error; err != nil {
+		return dto.VoterRecord{}, err
+	}
+
+	return record, nil
+}
+
+// MarkVoteFlagged reports whether this call flagged the report.
+func (r *reportRepository) MarkVoteFlagged(ctx context.Context, tx *gorm.DB, reportId string, at time.Time) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.Report{}).
+		Where("id = ? AND vote_flagged_at IS NULL", reportId).
+		Update("vote_flagged_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UpdateSLA sets the deadline for the current status and clears any
+// escalation of the previous one.
+func (r *reportRepository) UpdateSLA(
+	ctx context.Context,
+	tx *gorm.DB,
+	reportId string,
+	policyId *uuid.UUID,
+	dueAt *time.Time,
+) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Report{}).Where("id = ?", reportId).Updates(map[string]any{
+		"sla_policy_id":    policyId,
+		"sla_due_at":       dueAt,
+		"sla_escalated_at": nil,
+	}).Error
+}
+
+// GetSLABreaches returns reports past their SLA deadline that have not been
+// escalated yet, the oldest deadline first.
+func (r *reportRepository) GetSLABreaches(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.Report, error) {
 	if tx == nil {
 		tx = r.db
 	}
 
 	var reports []entity.Report
-	var err error
-	var count int64
+	if err := tx.WithContext(ctx).Preload("Tag").
+		Where("sla_due_at < ? AND sla_escalated_at IS NULL", now).
+		Order("sla_due_at").
+		Limit(limit).
+		Find(&reports).Error; err != nil {
+		return nil, err
+	}
 
-	req.Default()
+	return reports, nil
+}
 
-	query := tx.WithContext(ctx).Model(&entity.Report{})
-	query = query.Where("user_id = ?", userId)
-	if req.Search != "" {
-		query = query.Where("text LIKE ?", "%"+req.Search+"%")
+// MarkSLAEscalated returns false when another run escalated the report
+// first.
+func (r *reportRepository) MarkSLAEscalated(ctx context.Context, tx *gorm.DB, reportId string, at time.Time) (bool, error) {
+	if tx == nil {
+		tx = r.db
 	}
 
-	if err := query.Count(&count).Error; err != nil {
-		return dto.GetAllReportResponse{}, err
+	result := tx.WithContext(ctx).Model(&entity.Report{}).
+		Where("id = ? AND sla_escalated_at IS NULL", reportId).
+		Update("sla_escalated_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetUnratedCompleted returns completed reports without a rating or a
+// reminder whose last completion happened before completedBefore.
+func (r *reportRepository) GetUnratedCompleted(ctx context.Context, tx *gorm.DB, completedBefore time.Time, limit int) ([]entity.Report, error) {
+	if tx == nil {
+		tx = r.db
 	}
 
-	if err := query.Preload("Tag").Preload("User").Scopes(Paginate(req)).Find(&reports).Error; err != nil {
-		return dto.GetAllReportResponse{}, err
+	completedAt := tx.Session(&gorm.Session{NewDB: true}).Model(&entity.ReportHistory{}).
+		Select("MAX(created_at)").
+		Where("report_id = reports.id AND event = ? AND to_status = ?", entity.HistoryStatusChanged, entity.StatusCompleted)
+	rated := tx.Session(&gorm.Session{NewDB: true}).Model(&entity.ReportRating{}).
+		Select("1").
+		Where("report_id = reports.id")
+
+	var reports []entity.Report
+	if err := tx.WithContext(ctx).
+		Where("status = ? AND rating_reminded_at IS NULL", entity.StatusCompleted).
+		Where("NOT EXISTS (?)", rated).
+		Where("(?) < ?", completedAt, completedBefore).
+		Order("reports.updated_at").
+		Limit(limit).
+		Find(&reports).Error; err != nil {
+		return nil, err
 	}
 
-	totalPage := TotalPage(count, int64(req.PerPage))
-	return dto.GetAllReportResponse{
-		Reports: reports,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    req.Page,
-			PerPage: req.PerPage,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+	return reports, nil
+}
+
+// MarkRatingReminded returns false when the reporter was already reminded.
+func (r *reportRepository) MarkRatingReminded(ctx context.Context, tx *gorm.DB, reportId string, at time.Time) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.Report{}).
+		Where("id = ? AND rating_reminded_at IS NULL", reportId).
+		Update("rating_reminded_at", at)
+	return result.RowsAffected > 0, result.Error
 }
 
 func (r *reportRepository) UpdateReportStatus(ctx context.Context, tx *gorm.DB, reportId string, status entity.ReportStatus) (dto.UpdateStatusReportResponse, error) {
@@ -197,17 +472,25 @@ func (r *reportRepository) UpdateReportStatus(ctx context.Context, tx *gorm.DB,
 	}, nil
 }
 
-func (r *reportRepository) CountReportStatus(ctx context.Context, tx *gorm.DB) (dto.CountReportResponse, error) {
+// CountReportStatus counts reports by status, only those of agencyIds when
+// it is not empty.
+func (r *reportRepository) CountReportStatus(ctx context.Context, tx *gorm.DB, agencyIds []string) (dto.CountReportResponse, error) {
 	if tx == nil {
 		tx = r.db
 	}
+	query := tx.WithContext(ctx).Model(&entity.Report{})
+	if len(agencyIds) > 0 {
+		query = query.Where("agency_id IN ?", agencyIds)
+	}
 	var counts []dto.StatusCount
-	if err := tx.WithContext(ctx).Model(&entity.Report{}).Select("status, COUNT(*) as count").Group("status").Scan(&counts).Error; err != nil {
+	if err := query.Select("status, COUNT(*) as count").Group("status").Scan(&counts).Error; err != nil {
 		return dto.CountReportResponse{}, err
 	}
 	var amount dto.CountReportResponse
 	for _, count := range counts {
 		switch count.Status {
+		case "pending_review":
+			amount.PendingReview = count.Count
 		case "unverified":
 			amount.Unverified = count.Count
 		case "verified":
@@ -224,43 +507,6 @@ func (r *reportRepository) CountReportStatus(ctx context.Context, tx *gorm.DB) (
 	return amount, nil
 }
 
-func (r *reportRepository) GetReportsByStatus(ctx context.Context, tx *gorm.DB, status entity.ReportStatus, req dto.PaginationRequest) (dto.GetAllReportResponse, error) {
-	if tx == nil {
-		tx = r.db
-	}
-
-	var reports []entity.Report
-	var err error
-	var count int64
-
-	req.Default()
-
-	query := tx.WithContext(ctx).Model(&entity.Report{})
-	query = query.Where("status = ?", status)
-	if req.Search != "" {
-		query = query.Where("text LIKE ?", "%"+req.Search+"%")
-	}
-
-	if err := query.Count(&count).Error; err != nil {
-		return dto.GetAllReportResponse{}, err
-	}
-
-	if err := query.Preload("Tag").Preload("User").Scopes(Paginate(req)).Find(&reports).Error; err != nil {
-		return dto.GetAllReportResponse{}, err
-	}
-
-	totalPage := TotalPage(count, int64(req.PerPage))
-	return dto.GetAllReportResponse{
-		Reports: reports,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    req.Page,
-			PerPage: req.PerPage,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
-}
-
 func (r *reportRepository) UpdateReportInference(ctx context.Context, tx *gorm.DB, report entity.Report, class string, location string) ([]entity.Tag, error) {
 	if tx == nil {
 		tx = r.db
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ReportUpvoteRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, upvote entity.ReportUpvote) (bool, error)
+		Delete(ctx context.Context, tx *gorm.DB, reportId string, userId string) (bool, error)
+		Exists(ctx context.Context, tx *gorm.DB, reportId string, userId string) (bool, error)
+	}
+
+	reportUpvoteRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReportUpvoteRepository(db *gorm.DB) ReportUpvoteRepository {
+	return &reportUpvoteRepository{
+		db: db,
+	}
+}
+
+// Create reports false when the user already upvoted the report.
+func (r *reportUpvoteRepository) Create(ctx context.Context, tx *gorm.DB, upvote entity.ReportUpvote) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "report_id"}, {Name: "user_id"}},
+		DoNothing: true,
+	}).Create(&upvote)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *reportUpvoteRepository) Delete(ctx context.Context, tx *gorm.DB, reportId string, userId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Delete(&entity.ReportUpvote{}, "report_id = ? AND user_id = ?", reportId, userId)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *reportUpvoteRepository) Exists(ctx context.Context, tx *gorm.DB, reportId string, userId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.ReportUpvote{}).
+		Where("report_id = ? AND user_id = ?", reportId, userId).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
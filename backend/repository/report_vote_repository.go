@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ReportVoteRepository interface {
+		Save(ctx context.Context, tx *gorm.DB, vote entity.ReportVote) error
+		GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) ([]entity.ReportVote, error)
+	}
+
+	reportVoteRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReportVoteRepository(db *gorm.DB) ReportVoteRepository {
+	return &reportVoteRepository{
+		db: db,
+	}
+}
+
+// Save replaces an earlier vote of the same user on the report.
+func (r *reportVoteRepository) Save(ctx context.Context, tx *gorm.DB, vote entity.ReportVote) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "report_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind", "weight", "updated_at"}),
+	}).Create(&vote).Error
+}
+
+func (r *reportVoteRepository) GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) ([]entity.ReportVote, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var votes []entity.ReportVote
+	if err := tx.WithContext(ctx).Where("report_id = ?", reportId).Order("created_at").Find(&votes).Error; err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
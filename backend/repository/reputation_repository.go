@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ReputationRepository interface {
+		Award(ctx context.Context, tx *gorm.DB, entry entity.ReputationEntry) (bool, error)
+		HasKey(ctx context.Context, tx *gorm.DB, key string) (bool, error)
+		GetReasonCounts(ctx context.Context, tx *gorm.DB, userId string) ([]dto.ReputationReasonCount, error)
+		GetEntries(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllReputationEntryRepositoryResponse, error)
+		GetLeaderboard(ctx context.Context, tx *gorm.DB, since *time.Time, region string, limit int) ([]dto.LeaderboardRow, error)
+	}
+
+	reputationRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReputationRepository(db *gorm.DB) ReputationRepository {
+	return &reputationRepository{
+		db: db,
+	}
+}
+
+// Award appends the entry unless its key was already paid, and reports
+// whether it did.
+func (r *reputationRepository) Award(ctx context.Context, tx *gorm.DB, entry entity.ReputationEntry) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoNothing: true,
+	}).Create(&entry)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *reputationRepository) HasKey(ctx context.Context, tx *gorm.DB, key string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.ReputationEntry{}).Where("key = ?", key).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *reputationRepository) GetReasonCounts(ctx context.Context, tx *gorm.DB, userId string) ([]dto.ReputationReasonCount, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var counts []dto.ReputationReasonCount
+	if err := tx.WithContext(ctx).Model(&entity.ReputationEntry{}).
+		Select("reason, COUNT(*) AS count, SUM(points) AS points").
+		Where("user_id = ?", userId).
+		Group("reason").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *reputationRepository) GetEntries(
+	ctx context.Context,
+	tx *gorm.DB,
+	userId string,
+	req dto.PaginationRequest,
+) (dto.GetAllReputationEntryRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var entries []entity.ReputationEntry
+	var count int64
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Model(&entity.ReputationEntry{}).Where("user_id = ?", userId)
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllReputationEntryRepositoryResponse{}, err
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Scopes(Paginate(req)).Find(&entries).Error; err != nil {
+		return dto.GetAllReputationEntryRepositoryResponse{}, err
+	}
+
+	return dto.GetAllReputationEntryRepositoryResponse{
+		Entries: entries,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: TotalPage(count, int64(req.PerPage)),
+		},
+	}, nil
+}
+
+// GetLeaderboard ranks users by the points they earned since the given
+// time, or ever when since is nil, optionally within one region. Users who
+// did not come out ahead are left off.
+func (r *reputationRepository) GetLeaderboard(
+	ctx context.Context,
+	tx *gorm.DB,
+	since *time.Time,
+	region string,
+	limit int,
+) ([]dto.LeaderboardRow, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.ReputationEntry{}).
+		Select("users.name AS name, SUM(reputation_entries.points) AS score").
+		Joins("JOIN users ON users.id = reputation_entries.user_id")
+	if since != nil {
+		query = query.Where("reputation_entries.created_at >= ?", *since)
+	}
+	if region != "" {
+		query = query.Where("LOWER(reputation_entries.region) = LOWER(?)", region)
+	}
+
+	var rows []dto.LeaderboardRow
+	if err := query.
+		Group("users.id, users.name").
+		Having("SUM(reputation_entries.points) > 0").
+		Order("score DESC, users.name").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	ScheduledTaskRepository interface {
+		TryLock(ctx context.Context, tx *gorm.DB, name string) (bool, error)
+		GetOrCreate(ctx context.Context, tx *gorm.DB, name string) (entity.ScheduledTask, error)
+		Save(ctx context.Context, tx *gorm.DB, task entity.ScheduledTask) error
+		GetAll(ctx context.Context, tx *gorm.DB) ([]entity.ScheduledTask, error)
+	}
+
+	scheduledTaskRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewScheduledTaskRepository(db *gorm.DB) ScheduledTaskRepository {
+	return &scheduledTaskRepository{
+		db: db,
+	}
+}
+
+// TryLock takes the task's advisory lock for the rest of tx without
+// waiting. Only one replica can hold it, so only one runs the task.
+func (r *scheduledTaskRepository) TryLock(ctx context.Context, tx *gorm.DB, name string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var locked bool
+	if err := tx.WithContext(ctx).
+		Raw("SELECT pg_try_advisory_xact_lock(hashtextextended(?, 0))", "scheduled_task:"+name).
+		Scan(&locked).Error; err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+func (r *scheduledTaskRepository) GetOrCreate(ctx context.Context, tx *gorm.DB, name string) (entity.ScheduledTask, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	task := entity.ScheduledTask{Name: name}
+	if err := tx.WithContext(ctx).Where("name = ?", name).FirstOrCreate(&task).Error; err != nil {
+		return entity.ScheduledTask{}, err
+	}
+
+	return task, nil
+}
+
+func (r *scheduledTaskRepository) Save(ctx context.Context, tx *gorm.DB, task entity.ScheduledTask) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Save(&task).Error
+}
+
+func (r *scheduledTaskRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]entity.ScheduledTask, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var tasks []entity.ScheduledTask
+	if err := tx.WithContext(ctx).Order("name ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	SLAPolicyRepository interface {
+		GetAll(ctx context.Context, tx *gorm.DB) ([]entity.SLAPolicy, error)
+		GetById(ctx context.Context, tx *gorm.DB, policyId string) (entity.SLAPolicy, error)
+		GetByStatus(ctx context.Context, tx *gorm.DB, status entity.ReportStatus) ([]entity.SLAPolicy, error)
+		Save(ctx context.Context, tx *gorm.DB, policy entity.SLAPolicy) (entity.SLAPolicy, error)
+		Delete(ctx context.Context, tx *gorm.DB, policyId string) (int64, error)
+	}
+
+	slaPolicyRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSLAPolicyRepository(db *gorm.DB) SLAPolicyRepository {
+	return &slaPolicyRepository{
+		db: db,
+	}
+}
+
+func (r *slaPolicyRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]entity.SLAPolicy, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var policies []entity.SLAPolicy
+	if err := tx.WithContext(ctx).Order("status").Order("class").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (r *slaPolicyRepository) GetById(ctx context.Context, tx *gorm.DB, policyId string) (entity.SLAPolicy, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var policy entity.SLAPolicy
+	if err := tx.WithContext(ctx).First(&policy, "id = ?", policyId).Error; err != nil {
+		return entity.SLAPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+func (r *slaPolicyRepository) GetByStatus(ctx context.Context, tx *gorm.DB, status entity.ReportStatus) ([]entity.SLAPolicy, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var policies []entity.SLAPolicy
+	if err := tx.WithContext(ctx).Where("status = ?", status).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// Save replaces the policy of an existing class and status pair.
+func (r *slaPolicyRepository) Save(ctx context.Context, tx *gorm.DB, policy entity.SLAPolicy) (entity.SLAPolicy, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "class"}, {Name: "status"}},
+		DoUpdates: clause.AssignmentColumns([]string{"duration_minutes", "supervisor_emails", "updated_at"}),
+	}).Create(&policy).Error; err != nil {
+		return entity.SLAPolicy{}, err
+	}
+
+	var saved entity.SLAPolicy
+	if err := tx.WithContext(ctx).Where("class = ? AND status = ?", policy.Class, policy.Status).Take(&saved).Error; err != nil {
+		return entity.SLAPolicy{}, err
+	}
+
+	return saved, nil
+}
+
+func (r *slaPolicyRepository) Delete(ctx context.Context, tx *gorm.DB, policyId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Delete(&entity.SLAPolicy{}, "id = ?", policyId)
+	return result.RowsAffected, result.Error
+}
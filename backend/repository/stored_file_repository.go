@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	StoredFileRepository interface {
+		// Save creates the file or replaces the one under the same key.
+		Save(ctx context.Context, tx *gorm.DB, file entity.StoredFile) error
+		// SaveAll saves the files in a single statement, so readers see
+		// either all of them or none.
+		SaveAll(ctx context.Context, tx *gorm.DB, files []entity.StoredFile) error
+		Get(ctx context.Context, tx *gorm.DB, key string) (entity.StoredFile, error)
+		// Find returns the files whose key matches the LIKE pattern.
+		Find(ctx context.Context, tx *gorm.DB, pattern string) ([]entity.StoredFile, error)
+		// DeleteBefore deletes the files under prefix written before the
+		// given time.
+		DeleteBefore(ctx context.Context, tx *gorm.DB, prefix string, before time.Time) (int64, error)
+	}
+
+	storedFileRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewStoredFileRepository(db *gorm.DB) StoredFileRepository {
+	return &storedFileRepository{
+		db: db,
+	}
+}
+
+func (r *storedFileRepository) Save(ctx context.Context, tx *gorm.DB, file entity.StoredFile) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	file.Size = int64(len(file.Content))
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"content", "size", "updated_at"}),
+	}).Create(&file).Error
+}
+
+func (r *storedFileRepository) SaveAll(ctx context.Context, tx *gorm.DB, files []entity.StoredFile) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	for i := range files {
+		files[i].Size = int64(len(files[i].Content))
+	}
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"content", "size", "updated_at"}),
+	}).Create(&files).Error
+}
+
+func (r *storedFileRepository) Get(ctx context.Context, tx *gorm.DB, key string) (entity.StoredFile, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var file entity.StoredFile
+	if err := tx.WithContext(ctx).Where("key = ?", key).Take(&file).Error; err != nil {
+		return entity.StoredFile{}, err
+	}
+
+	return file, nil
+}
+
+func (r *storedFileRepository) Find(ctx context.Context, tx *gorm.DB, pattern string) ([]entity.StoredFile, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var files []entity.StoredFile
+	if err := tx.WithContext(ctx).Where("key LIKE ?", pattern).Order("key").Find(&files).Error; err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (r *storedFileRepository) DeleteBefore(ctx context.Context, tx *gorm.DB, prefix string, before time.Time) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("key LIKE ? AND updated_at < ?", prefix+"%", before).Delete(&entity.StoredFile{})
+	return result.RowsAffected, result.Error
+}
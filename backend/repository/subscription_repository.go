@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// metersPerDegreeLatitude is close enough everywhere to prefilter area
+// subscriptions in SQL; the exact distance is checked afterwards.
+const metersPerDegreeLatitude = 111320.0
+
+type (
+	SubscriptionRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, subscription entity.Subscription) (entity.Subscription, error)
+		GetByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.Subscription, error)
+		CountByUserId(ctx context.Context, tx *gorm.DB, userId string) (int64, error)
+		Delete(ctx context.Context, tx *gorm.DB, userId string, subscriptionId string) (int64, error)
+		GetCandidates(ctx context.Context, tx *gorm.DB, tagId uuid.UUID, lat, lng *float64) ([]entity.Subscription, error)
+	}
+
+	subscriptionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSubscriptionRepository(db *gorm.DB) SubscriptionRepository {
+	return &subscriptionRepository{
+		db: db,
+	}
+}
+
+func (r *subscriptionRepository) Create(ctx context.Context, tx *gorm.DB, subscription entity.Subscription) (entity.Subscription, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&subscription).Error; err != nil {
+		return entity.Subscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (r *subscriptionRepository) GetByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.Subscription, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var subscriptions []entity.Subscription
+	if err := tx.WithContext(ctx).Preload("Tag").
+		Where("user_id = ?", userId).
+		Order("created_at DESC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *subscriptionRepository) CountByUserId(ctx context.Context, tx *gorm.DB, userId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Subscription{}).Where("user_id = ?", userId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *subscriptionRepository) Delete(ctx context.Context, tx *gorm.DB, userId string, subscriptionId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Where("id = ? AND user_id = ?", subscriptionId, userId).Delete(&entity.Subscription{})
+	return result.RowsAffected, result.Error
+}
+
+// GetCandidates returns the subscriptions on tagId plus the area
+// subscriptions whose latitude band contains the point. Callers still run
+// Subscription.Matches on each one.
+func (r *subscriptionRepository) GetCandidates(
+	ctx context.Context,
+	tx *gorm.DB,
+	tagId uuid.UUID,
+	lat, lng *float64,
+) ([]entity.Subscription, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.Subscription{})
+	conditions := tx.Session(&gorm.Session{NewDB: true})
+	matched := false
+
+	if tagId != uuid.Nil {
+		conditions = conditions.Or("kind = ? AND tag_id = ?", entity.SubscriptionTag, tagId)
+		matched = true
+	}
+	if lat != nil && lng != nil {
+		conditions = conditions.Or(
+			"kind = ? AND abs(latitude - ?) * ? <= radius_meters",
+			entity.SubscriptionArea, *lat, metersPerDegreeLatitude,
+		)
+		matched = true
+	}
+	if !matched {
+		return nil, nil
+	}
+
+	var subscriptions []entity.Subscription
+	if err := query.Where(conditions).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
@@ -21,6 +21,14 @@ type (
 		CheckEmail(ctx context.Context, tx *gorm.DB, email string) (entity.User, bool, error)
 		Update(ctx context.Context, tx *gorm.DB, user entity.User) (entity.User, error)
 		Delete(ctx context.Context, tx *gorm.DB, userId string) error
+		UpdateRole(ctx context.Context, tx *gorm.DB, userId string, role string) error
+		GetUsersByRole(ctx context.Context, tx *gorm.DB, role string) ([]entity.User, error)
+		UpdateNotificationPreferences(
+			ctx context.Context,
+			tx *gorm.DB,
+			userId string,
+			prefs entity.NotificationPreferences,
+		) error
 	}
 
 	userRepository struct {
@@ -136,6 +144,27 @@ func (r *userRepository) Update(ctx context.Context, tx *gorm.DB, user entity.Us
 	return user, nil
 }
 
+func (r *userRepository) UpdateRole(ctx context.Context, tx *gorm.DB, userId string, role string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Update("role", role).Error
+}
+
+func (r *userRepository) GetUsersByRole(ctx context.Context, tx *gorm.DB, role string) ([]entity.User, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var users []entity.User
+	if err := tx.WithContext(ctx).Where("role = ?", role).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (r *userRepository) Delete(ctx context.Context, tx *gorm.DB, userId string) error {
 	if tx == nil {
 		tx = r.db
@@ -147,3 +176,22 @@ func (r *userRepository) Delete(ctx context.Context, tx *gorm.DB, userId string)
 
 	return nil
 }
+
+// UpdateNotificationPreferences writes every preference column, including the
+// ones switched off, which Updates with a struct would skip.
+func (r *userRepository) UpdateNotificationPreferences(
+	ctx context.Context,
+	tx *gorm.DB,
+	userId string,
+	prefs entity.NotificationPreferences,
+) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"notify_in_app": prefs.InApp,
+		"notify_email":  prefs.Email,
+		"notify_push":   prefs.Push,
+	}).Error
+}
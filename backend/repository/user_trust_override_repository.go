@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	UserTrustOverrideRepository interface {
+		GetByUserId(ctx context.Context, tx *gorm.DB, userId string) (entity.UserTrustOverride, error)
+		Save(ctx context.Context, tx *gorm.DB, override entity.UserTrustOverride) error
+		Delete(ctx context.Context, tx *gorm.DB, userId string) error
+	}
+
+	userTrustOverrideRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewUserTrustOverrideRepository(db *gorm.DB) UserTrustOverrideRepository {
+	return &userTrustOverrideRepository{
+		db: db,
+	}
+}
+
+func (r *userTrustOverrideRepository) GetByUserId(ctx context.Context, tx *gorm.DB, userId string) (entity.UserTrustOverride, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var override entity.UserTrustOverride
+	if err := tx.WithContext(ctx).First(&override, "user_id = ?", userId).Error; err != nil {
+		return entity.UserTrustOverride{}, err
+	}
+
+	return override, nil
+}
+
+// Save replaces the user's previous override.
+func (r *userTrustOverrideRepository) Save(ctx context.Context, tx *gorm.DB, override entity.UserTrustOverride) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"level", "note", "set_by_id", "updated_at"}),
+	}).Create(&override).Error
+}
+
+func (r *userTrustOverrideRepository) Delete(ctx context.Context, tx *gorm.DB, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Delete(&entity.UserTrustOverride{}, "user_id = ?", userId).Error
+}
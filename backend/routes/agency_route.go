@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Agencies(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvoke[service.UserService](injector)
+	agencyController := do.MustInvoke[controller.AgencyController](injector)
+
+	routes := route.Group("/api/admin/agencies", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN))
+	{
+		routes.GET("", agencyController.GetAgencies)
+		routes.POST("", agencyController.CreateAgency)
+		routes.DELETE("/:id", agencyController.DeleteAgency)
+		routes.PUT("/:id/members/:user_id", agencyController.AddMember)
+		routes.DELETE("/:id/members/:user_id", agencyController.RemoveMember)
+
+		// Class routing
+		routes.GET("/routes", agencyController.GetRoutes)
+		routes.PUT("/routes", agencyController.SaveRoute)
+		routes.DELETE("/routes/:id", agencyController.DeleteRoute)
+	}
+}
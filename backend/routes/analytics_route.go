@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Analytics(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvoke[service.UserService](injector)
+	analyticsController := do.MustInvoke[controller.AnalyticsController](injector)
+
+	routes := route.Group("/api/analytics")
+	{
+		// Analytics
+		routes.GET("/reports", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN), analyticsController.GetReportAnalytics)
+		routes.GET("/satisfaction", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN), analyticsController.GetSatisfaction)
+	}
+}
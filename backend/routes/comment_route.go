@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Comments(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvoke[service.UserService](injector)
+	agencyService := do.MustInvoke[service.AgencyService](injector)
+	commentController := do.MustInvoke[controller.CommentController](injector)
+
+	scope := middleware.AgencyScope(agencyService)
+
+	route.GET("/api/reports/:id/comments", middleware.Authenticate(jwtService), scope, commentController.GetComments)
+	route.POST("/api/reports/:id/comments", middleware.Authenticate(jwtService), scope, commentController.CreateComment)
+
+	routes := route.Group("/api/comments", middleware.Authenticate(jwtService))
+	{
+		routes.PATCH("/:id", commentController.UpdateComment)
+		routes.DELETE("/:id", commentController.DeleteComment)
+	}
+
+	route.PUT("/api/admin/comments/:id/moderation", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN), commentController.ModerateComment)
+}
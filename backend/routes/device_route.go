@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Devices(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	deviceController := do.MustInvoke[controller.DeviceController](injector)
+
+	routes := route.Group("/api/user/me/devices", middleware.Authenticate(jwtService))
+	{
+		routes.GET("", deviceController.GetDevices)
+		routes.POST("", deviceController.RegisterDevice)
+		routes.DELETE("", deviceController.UnregisterDevice)
+	}
+}
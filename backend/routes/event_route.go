@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Events(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	eventController := do.MustInvoke[controller.EventController](injector)
+
+	routes := route.Group("/api/events")
+	{
+		// Server-Sent Events
+		routes.GET("", middleware.TokenFromQuery(), middleware.Authenticate(jwtService), eventController.Stream)
+	}
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Export(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvoke[service.UserService](injector)
+	agencyService := do.MustInvoke[service.AgencyService](injector)
+	exportController := do.MustInvoke[controller.ExportController](injector)
+	scope := middleware.AgencyScope(agencyService)
+
+	routes := route.Group("/api/reports/export")
+	{
+		// Export
+		routes.GET("/geojson", middleware.Authenticate(jwtService), scope, exportController.ExportGeoJSON)
+		routes.GET("/kml", middleware.Authenticate(jwtService), scope, exportController.ExportKML)
+	}
+
+	admin := route.Group("/api/admin/reports", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN))
+	{
+		// Admin spreadsheet export
+		admin.GET("/export", exportController.ExportSpreadsheet)
+		admin.GET("/exports/:id", exportController.GetReportExport)
+		admin.GET("/exports/:id/download", exportController.DownloadReportExport)
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Jobs(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvoke[service.UserService](injector)
+	jobController := do.MustInvoke[controller.JobController](injector)
+
+	routes := route.Group("/api/admin/jobs", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN))
+	{
+		routes.GET("", jobController.GetJobs)
+		routes.GET("/:id", jobController.GetJob)
+		routes.POST("/:id/retry", jobController.RetryJob)
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Notifications(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	notificationController := do.MustInvoke[controller.NotificationController](injector)
+
+	routes := route.Group("/api/notifications", middleware.Authenticate(jwtService))
+	{
+		routes.GET("", notificationController.GetNotifications)
+		routes.GET("/unread_count", notificationController.CountUnread)
+		routes.POST("/read_all", notificationController.MarkAllRead)
+		routes.POST("/:id/read", notificationController.MarkRead)
+	}
+
+	preferences := route.Group("/api/user/me/notification_preferences", middleware.Authenticate(jwtService))
+	{
+		preferences.GET("", notificationController.GetPreferences)
+		preferences.PATCH("", notificationController.UpdatePreferences)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func OpenData(route *gin.Engine, injector *do.Injector) {
+	openDataController := do.MustInvoke[controller.OpenDataController](injector)
+
+	// Public, no authentication
+	routes := route.Group("/api/open-data")
+	{
+		routes.GET("/snapshots", openDataController.ListSnapshots)
+		routes.GET("/snapshots/:version/:file", openDataController.GetSnapshotFile)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Ratings(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	ratingController := do.MustInvoke[controller.RatingController](injector)
+
+	routes := route.Group("/api/reports/:id/rating", middleware.Authenticate(jwtService))
+	{
+		routes.GET("", ratingController.GetRating)
+		routes.POST("", ratingController.RateReport)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func RejectionReasons(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvoke[service.UserService](injector)
+	reasonController := do.MustInvoke[controller.RejectionReasonController](injector)
+
+	route.GET("/api/rejection_reasons", middleware.Authenticate(jwtService), reasonController.GetReasons)
+
+	routes := route.Group("/api/admin/rejection_reasons", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN))
+	{
+		routes.GET("", reasonController.GetAllReasons)
+		routes.PUT("", reasonController.SaveReason)
+		routes.DELETE("/:code", reasonController.DeactivateReason)
+	}
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
 	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/controller"
 	"github.com/Caknoooo/go-gin-clean-starter/middleware"
@@ -12,17 +13,57 @@ import (
 func Reports(route *gin.Engine, injector *do.Injector) {
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
 	reportController := do.MustInvoke[controller.ReportController](injector)
+	assignmentController := do.MustInvoke[controller.AssignmentController](injector)
+	appealController := do.MustInvoke[controller.AppealController](injector)
+	voteController := do.MustInvoke[controller.VoteController](injector)
+	userService := do.MustInvoke[service.UserService](injector)
+	agencyService := do.MustInvoke[service.AgencyService](injector)
+	rateLimiter := do.MustInvoke[service.RateLimiter](injector)
+	scope := middleware.AgencyScope(agencyService)
 
 	routes := route.Group("/api/reports")
 	{
 		// Reports
-		routes.POST("", middleware.Authenticate(jwtService), reportController.CreateReport)
-		routes.GET("", middleware.Authenticate(jwtService), reportController.GetAllReports)
-		routes.GET("/:id", middleware.Authenticate(jwtService), reportController.GetReportById)
-		routes.GET("/user/:id", middleware.Authenticate(jwtService), reportController.GetReportsByUserId)
+		routes.POST("", middleware.Authenticate(jwtService), middleware.RateLimit(rateLimiter, config.RATE_LIMIT_CREATE_REPORT), reportController.CreateReport)
+		routes.GET("", middleware.Authenticate(jwtService), scope, reportController.GetAllReports)
+		routes.GET("/:id", middleware.Authenticate(jwtService), scope, reportController.GetReportById)
+		routes.GET("/user/:id", middleware.Authenticate(jwtService), scope, reportController.GetReportsByUserId)
 		routes.POST("/:id/status", middleware.Authenticate(jwtService), reportController.UpdateReportStatus)
-		routes.GET("/count", middleware.Authenticate(jwtService), reportController.CountReportStatus)
-		routes.GET("/status/:status", middleware.Authenticate(jwtService), reportController.GetReportsByStatus)
+		routes.GET("/:id/history", middleware.Authenticate(jwtService), scope, reportController.GetReportHistory)
+		routes.GET("/:id/completion", middleware.Authenticate(jwtService), scope, reportController.GetCompletion)
+		routes.POST("/:id/completion/confirm", middleware.Authenticate(jwtService), reportController.ConfirmCompletion)
+		routes.POST("/:id/completion/dispute", middleware.Authenticate(jwtService), reportController.DisputeCompletion)
+		routes.GET("/:id/appeal", middleware.Authenticate(jwtService), appealController.GetAppeal)
+		routes.POST("/:id/appeal", middleware.Authenticate(jwtService), appealController.FileAppeal)
+		routes.GET("/:id/votes", middleware.Authenticate(jwtService), voteController.GetVotes)
+		routes.POST("/:id/votes", middleware.Authenticate(jwtService), voteController.VoteReport)
+		routes.POST("/:id/upvote", middleware.Authenticate(jwtService), voteController.UpvoteReport)
+		routes.DELETE("/:id/upvote", middleware.Authenticate(jwtService), voteController.RemoveUpvote)
+		routes.GET("/count", middleware.Authenticate(jwtService), scope, reportController.CountReportStatus)
+		routes.GET("/status/:status", middleware.Authenticate(jwtService), scope, reportController.GetReportsByStatus)
 		routes.POST("/inference_status", reportController.InferenceStatus)
+
+		// Assignment
+		routes.PUT("/:id/assignment", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN), assignmentController.AssignReport)
+		routes.DELETE("/:id/assignment", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN), assignmentController.UnassignReport)
+		routes.PUT("/:id/agency", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN), assignmentController.RouteReport)
+	}
+
+	// Appeal queue
+	appeals := route.Group("/api/admin/appeals", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN))
+	{
+		appeals.GET("", appealController.GetAppeals)
+		appeals.POST("/:id/accept", appealController.AcceptAppeal)
+		appeals.POST("/:id/uphold", appealController.UpholdAppeal)
 	}
+
+	// Reports held for review
+	heldReportController := do.MustInvoke[controller.HeldReportController](injector)
+	held := route.Group("/api/admin/held_reports", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN))
+	{
+		held.GET("", heldReportController.GetHeldReports)
+		held.POST("/:id/release", heldReportController.ReleaseReport)
+	}
+
+	route.GET("/api/user/me/assignments", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_OFFICER), assignmentController.GetMyAssignments)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Reputation(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	userService := do.MustInvoke[service.UserService](injector)
+	reputationController := do.MustInvoke[controller.ReputationController](injector)
+
+	route.GET("/api/leaderboard", reputationController.GetLeaderboard)
+	route.GET("/api/user/me/reputation", middleware.Authenticate(jwtService), reputationController.GetMyLedger)
+	route.GET("/api/admin/users/:id/reputation", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN), reputationController.GetUserLedger)
+}
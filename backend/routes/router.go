@@ -8,4 +8,20 @@ import (
 func RegisterRoutes(server *gin.Engine, injector *do.Injector) {
 	User(server, injector)
 	Reports(server, injector)
+	Analytics(server, injector)
+	Export(server, injector)
+	OpenData(server, injector)
+	Events(server, injector)
+	Notifications(server, injector)
+	Devices(server, injector)
+	Subscriptions(server, injector)
+	Agencies(server, injector)
+	SLAPolicies(server, injector)
+	Ratings(server, injector)
+	RejectionReasons(server, injector)
+	Comments(server, injector)
+	Reputation(server, injector)
+	Trust(server, injector)
+	Jobs(server, injector)
+	Scheduler(server, injector)
 }
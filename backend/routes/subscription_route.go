@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Subscriptions(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	subscriptionController := do.MustInvoke[controller.SubscriptionController](injector)
+
+	routes := route.Group("/api/user/me/subscriptions", middleware.Authenticate(jwtService))
+	{
+		routes.GET("", subscriptionController.GetSubscriptions)
+		routes.POST("", subscriptionController.CreateSubscription)
+		routes.DELETE("/:id", subscriptionController.DeleteSubscription)
+	}
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/Caknoooo/go-gin-clean-starter/config"
 	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/controller"
 	"github.com/Caknoooo/go-gin-clean-starter/middleware"
@@ -12,18 +13,25 @@ import (
 func User(route *gin.Engine, injector *do.Injector) {
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
 	userController := do.MustInvoke[controller.UserController](injector)
+	userService := do.MustInvoke[service.UserService](injector)
+	rateLimiter := do.MustInvoke[service.RateLimiter](injector)
 
 	routes := route.Group("/api/user")
 	{
 		// User
-		routes.POST("", userController.Register)
+		routes.POST("", middleware.RateLimit(rateLimiter, config.RATE_LIMIT_REGISTER), userController.Register)
 		routes.GET("", userController.GetAllUser)
-		routes.POST("/login", userController.Login)
+		routes.POST("/login", middleware.RateLimit(rateLimiter, config.RATE_LIMIT_LOGIN), userController.Login)
 		routes.POST("/refresh", userController.Refresh)
 		routes.DELETE("", middleware.Authenticate(jwtService), userController.Delete)
 		routes.PATCH("", middleware.Authenticate(jwtService), userController.Update)
 		routes.GET("/me", middleware.Authenticate(jwtService), userController.Me)
 		routes.POST("/verify_email", userController.VerifyEmail)
-		routes.POST("/send_verification_email", userController.SendVerificationEmail)
+		routes.POST("/send_verification_email", middleware.RateLimit(rateLimiter, config.RATE_LIMIT_SEND_VERIFICATION_EMAIL), userController.SendVerificationEmail)
+	}
+
+	admin := route.Group("/api/admin/users", middleware.Authenticate(jwtService), middleware.RequireRole(userService, constants.ENUM_ROLE_ADMIN))
+	{
+		admin.PATCH("/:id/role", userController.UpdateRole)
 	}
 }
@@ -0,0 +1,40 @@
+package script
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"gorm.io/gorm"
+)
+
+type (
+	OpenDataScript struct {
+		db *gorm.DB
+	}
+)
+
+func NewOpenDataScript(db *gorm.DB) *OpenDataScript {
+	return &OpenDataScript{
+		db: db,
+	}
+}
+
+// Run publishes today's open data snapshot immediately.
+func (s *OpenDataScript) Run() error {
+	openDataService := service.NewOpenDataService(
+		repository.NewReportRepository(s.db),
+		repository.NewStoredFileRepository(s.db),
+		config.NewOpenDataConfig(),
+	)
+
+	snapshot, err := openDataService.GenerateSnapshot(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("open data snapshot %s: %d records, %d suppressed\n", snapshot.Version, snapshot.RecordCount, snapshot.SuppressedCount)
+	return nil
+}
@@ -0,0 +1,28 @@
+package script
+
+import (
+	"fmt"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/migrations"
+	"gorm.io/gorm"
+)
+
+type (
+	ReindexScript struct {
+		db *gorm.DB
+	}
+)
+
+func NewReindexScript(db *gorm.DB) *ReindexScript {
+	return &ReindexScript{
+		db: db,
+	}
+}
+
+// Run regenerates the report search column with the current
+// REPORT_SEARCH_CONFIG and rebuilds its indexes.
+func (s *ReindexScript) Run() error {
+	fmt.Printf("reindexing reports with %q text search config\n", config.ReportSearchConfig())
+	return migrations.ReportSearch(s.db, true)
+}
@@ -11,6 +11,12 @@ func Script(scriptName string, db *gorm.DB) error {
 	case "example_script":
 		exampleScript := NewExampleScript(db)
 		return exampleScript.Run()
+	case "reindex":
+		reindexScript := NewReindexScript(db)
+		return reindexScript.Run()
+	case "open_data":
+		openDataScript := NewOpenDataScript(db)
+		return openDataScript.Run()
 	default:
 		return errors.New("script not found")
 	}
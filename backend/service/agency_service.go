@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	AgencyService interface {
+		CreateAgency(ctx context.Context, req dto.CreateAgencyRequest) (dto.AgencyResponse, error)
+		GetAgencies(ctx context.Context) ([]dto.AgencyResponse, error)
+		DeleteAgency(ctx context.Context, agencyId string) error
+		AddMember(ctx context.Context, agencyId string, userId string, req dto.AgencyMemberRequest) (dto.AgencyResponse, error)
+		RemoveMember(ctx context.Context, agencyId string, userId string) error
+		GetRoutes(ctx context.Context) ([]dto.AgencyRouteResponse, error)
+		SaveRoute(ctx context.Context, req dto.SaveAgencyRouteRequest) (dto.AgencyRouteResponse, error)
+		DeleteRoute(ctx context.Context, routeId string) error
+		// Scope returns the agencies a user is limited to, or nil when the
+		// user sees every report.
+		Scope(ctx context.Context, userId string) ([]string, error)
+	}
+
+	agencyService struct {
+		agencyRepo repository.AgencyRepository
+		userRepo   repository.UserRepository
+	}
+)
+
+func NewAgencyService(agencyRepo repository.AgencyRepository, userRepo repository.UserRepository) AgencyService {
+	return &agencyService{
+		agencyRepo: agencyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (s *agencyService) CreateAgency(ctx context.Context, req dto.CreateAgencyRequest) (dto.AgencyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if _, err := s.agencyRepo.GetByName(ctx, nil, name); err == nil {
+		return dto.AgencyResponse{}, dto.ErrAgencyNameExists
+	}
+
+	agency, err := s.agencyRepo.Create(ctx, nil, entity.Agency{
+		Name:        name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return dto.AgencyResponse{}, dto.ErrCreateAgency
+	}
+
+	return toAgencyResponse(agency), nil
+}
+
+func (s *agencyService) GetAgencies(ctx context.Context) ([]dto.AgencyResponse, error) {
+	agencies, err := s.agencyRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAgencies
+	}
+
+	datas := make([]dto.AgencyResponse, 0, len(agencies))
+	for _, agency := range agencies {
+		datas = append(datas, toAgencyResponse(agency))
+	}
+
+	return datas, nil
+}
+
+func (s *agencyService) DeleteAgency(ctx context.Context, agencyId string) error {
+	if _, err := uuid.Parse(agencyId); err != nil {
+		return dto.ErrAgencyNotFound
+	}
+
+	deleted, err := s.agencyRepo.Delete(ctx, nil, agencyId)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return dto.ErrAgencyNotFound
+	}
+
+	return nil
+}
+
+// AddMember adds an officer to the agency, or changes their member role
+// when they already belong to it.
+func (s *agencyService) AddMember(
+	ctx context.Context,
+	agencyId string,
+	userId string,
+	req dto.AgencyMemberRequest,
+) (dto.AgencyResponse, error) {
+	agency, err := s.agencyRepo.GetById(ctx, nil, agencyId)
+	if err != nil {
+		return dto.AgencyResponse{}, dto.ErrAgencyNotFound
+	}
+
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.AgencyResponse{}, dto.ErrUserNotFound
+	}
+	if user.Role != constants.ENUM_ROLE_OFFICER {
+		return dto.AgencyResponse{}, dto.ErrAssigneeNotOfficer
+	}
+
+	role := entity.AgencyMemberRole(req.Role)
+	if role == "" {
+		role = entity.AgencyStaff
+	}
+
+	if err := s.agencyRepo.SaveMember(ctx, nil, entity.AgencyMember{
+		AgencyID: agency.ID,
+		UserID:   user.ID,
+		Role:     role,
+	}); err != nil {
+		return dto.AgencyResponse{}, dto.ErrSaveAgencyMember
+	}
+
+	agency, err = s.agencyRepo.GetById(ctx, nil, agencyId)
+	if err != nil {
+		return dto.AgencyResponse{}, dto.ErrAgencyNotFound
+	}
+
+	return toAgencyResponse(agency), nil
+}
+
+func (s *agencyService) RemoveMember(ctx context.Context, agencyId string, userId string) error {
+	deleted, err := s.agencyRepo.DeleteMember(ctx, nil, agencyId, userId)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return dto.ErrAgencyMemberNotFound
+	}
+
+	return nil
+}
+
+func (s *agencyService) GetRoutes(ctx context.Context) ([]dto.AgencyRouteResponse, error) {
+	routes, err := s.agencyRepo.GetRoutes(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAgencies
+	}
+
+	datas := make([]dto.AgencyRouteResponse, 0, len(routes))
+	for _, route := range routes {
+		datas = append(datas, toAgencyRouteResponse(route))
+	}
+
+	return datas, nil
+}
+
+func (s *agencyService) SaveRoute(ctx context.Context, req dto.SaveAgencyRouteRequest) (dto.AgencyRouteResponse, error) {
+	agency, err := s.agencyRepo.GetById(ctx, nil, req.AgencyID)
+	if err != nil {
+		return dto.AgencyRouteResponse{}, dto.ErrAgencyNotFound
+	}
+
+	route, err := s.agencyRepo.SaveRoute(ctx, nil, entity.AgencyRoute{
+		Class:    strings.TrimSpace(req.Class),
+		Region:   strings.TrimSpace(req.Region),
+		AgencyID: agency.ID,
+	})
+	if err != nil {
+		return dto.AgencyRouteResponse{}, dto.ErrSaveAgencyRoute
+	}
+
+	return toAgencyRouteResponse(route), nil
+}
+
+func (s *agencyService) DeleteRoute(ctx context.Context, routeId string) error {
+	if _, err := uuid.Parse(routeId); err != nil {
+		return dto.ErrAgencyRouteNotFound
+	}
+
+	deleted, err := s.agencyRepo.DeleteRoute(ctx, nil, routeId)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return dto.ErrAgencyRouteNotFound
+	}
+
+	return nil
+}
+
+func (s *agencyService) Scope(ctx context.Context, userId string) ([]string, error) {
+	memberships, err := s.agencyRepo.GetMemberships(ctx, nil, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return nil, nil
+	}
+
+	// admins keep the full view even when they also sit in an agency
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == constants.ENUM_ROLE_ADMIN {
+		return nil, nil
+	}
+
+	agencyIds := make([]string, 0, len(memberships))
+	for _, membership := range memberships {
+		agencyIds = append(agencyIds, membership.AgencyID.String())
+	}
+
+	return agencyIds, nil
+}
+
+// ResolveAgencyRoute picks the route for the first class that has one. A
+// route whose region appears in the location wins over the class fallback,
+// and the longest matching region wins among several.
+func ResolveAgencyRoute(routes []entity.AgencyRoute, classes []string, location string) *entity.AgencyRoute {
+	location = strings.ToLower(location)
+
+	for _, class := range classes {
+		var best *entity.AgencyRoute
+		for i := range routes {
+			route := &routes[i]
+			if route.Class != class {
+				continue
+			}
+
+			if route.Region == "" {
+				if best == nil {
+					best = route
+				}
+				continue
+			}
+
+			if !strings.Contains(location, strings.ToLower(route.Region)) {
+				continue
+			}
+			if best == nil || best.Region == "" || len(route.Region) > len(best.Region) {
+				best = route
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+
+	return nil
+}
+
+func toAgencyResponse(agency entity.Agency) dto.AgencyResponse {
+	members := make([]dto.AgencyMemberResponse, 0, len(agency.Members))
+	for _, member := range agency.Members {
+		members = append(members, dto.AgencyMemberResponse{
+			UserID: member.UserID.String(),
+			Name:   member.User.Name,
+			Email:  member.User.Email,
+			Role:   string(member.Role),
+		})
+	}
+
+	return dto.AgencyResponse{
+		ID:          agency.ID.String(),
+		Name:        agency.Name,
+		Description: agency.Description,
+		Members:     members,
+		CreatedAt:   agency.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toAgencyRouteResponse(route entity.AgencyRoute) dto.AgencyRouteResponse {
+	return dto.AgencyRouteResponse{
+		ID:         route.ID.String(),
+		Class:      route.Class,
+		Region:     route.Region,
+		AgencyID:   route.AgencyID.String(),
+		AgencyName: route.Agency.Name,
+	}
+}
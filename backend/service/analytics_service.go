@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+)
+
+type (
+	AnalyticsService interface {
+		GetReportAnalytics(ctx context.Context, req dto.ReportAnalyticsRequest) (dto.ReportAnalyticsResponse, error)
+		GetSatisfaction(ctx context.Context, req dto.SatisfactionRequest) (dto.SatisfactionResponse, error)
+	}
+
+	analyticsService struct {
+		analyticsRepo repository.AnalyticsRepository
+	}
+)
+
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository) AnalyticsService {
+	return &analyticsService{
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+const analyticsDateLayout = "2006-01-02"
+
+func (s *analyticsService) GetReportAnalytics(ctx context.Context, req dto.ReportAnalyticsRequest) (dto.ReportAnalyticsResponse, error) {
+	loc := utils.JakartaLocation()
+
+	if req.Interval == "" {
+		req.Interval = dto.ANALYTICS_INTERVAL_DAY
+	}
+	if req.Interval != dto.ANALYTICS_INTERVAL_DAY && req.Interval != dto.ANALYTICS_INTERVAL_WEEK && req.Interval != dto.ANALYTICS_INTERVAL_MONTH {
+		return dto.ReportAnalyticsResponse{}, dto.ErrInvalidAnalyticsInterval
+	}
+	if req.GroupBy != "" && req.GroupBy != dto.ANALYTICS_GROUP_CLASS && req.GroupBy != dto.ANALYTICS_GROUP_STATUS && req.GroupBy != dto.ANALYTICS_GROUP_LOCATION {
+		return dto.ReportAnalyticsResponse{}, dto.ErrInvalidAnalyticsGroup
+	}
+
+	// dates are calendar days in Jakarta; default to the last 30 days
+	to := startOfDay(time.Now().In(loc))
+	if !req.To.IsZero() {
+		to = time.Date(req.To.Year(), req.To.Month(), req.To.Day(), 0, 0, 0, 0, loc)
+	}
+	from := to.AddDate(0, 0, -29)
+	if !req.From.IsZero() {
+		from = time.Date(req.From.Year(), req.From.Month(), req.From.Day(), 0, 0, 0, 0, loc)
+	}
+	if to.Before(from) {
+		return dto.ReportAnalyticsResponse{}, dto.ErrInvalidDateRange
+	}
+	end := to.AddDate(0, 0, 1)
+
+	buckets := analyticsBuckets(req.Interval, from, end)
+	if len(buckets) > dto.ANALYTICS_MAX_BUCKETS {
+		return dto.ReportAnalyticsResponse{}, dto.ErrAnalyticsRangeTooLarge
+	}
+
+	counts, err := s.analyticsRepo.CountReportsByBucket(ctx, nil, req.Interval, req.GroupBy, from, end)
+	if err != nil {
+		if errors.Is(err, dto.ErrInvalidAnalyticsGroup) {
+			return dto.ReportAnalyticsResponse{}, err
+		}
+		return dto.ReportAnalyticsResponse{}, dto.ErrGetAnalytics
+	}
+
+	bucketIndex := make(map[string]int, len(buckets))
+	for i, bucket := range buckets {
+		bucketIndex[bucket] = i
+	}
+
+	totals := make([]int64, len(buckets))
+	seriesByKey := map[string]*dto.ReportAnalyticsSeries{}
+	for _, count := range counts {
+		i, ok := bucketIndex[count.Bucket]
+		if !ok {
+			continue
+		}
+
+		series, ok := seriesByKey[count.Key]
+		if !ok {
+			series = &dto.ReportAnalyticsSeries{Key: count.Key, Counts: make([]int64, len(buckets))}
+			seriesByKey[count.Key] = series
+		}
+		series.Counts[i] += count.Count
+		series.Total += count.Count
+		totals[i] += count.Count
+	}
+
+	series := make([]dto.ReportAnalyticsSeries, 0, len(seriesByKey))
+	for _, item := range seriesByKey {
+		series = append(series, *item)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Total != series[j].Total {
+			return series[i].Total > series[j].Total
+		}
+		return series[i].Key < series[j].Key
+	})
+
+	verify, err := s.analyticsRepo.MedianTimeToStatus(ctx, nil, entity.StatusVerified, from, end)
+	if err != nil {
+		return dto.ReportAnalyticsResponse{}, dto.ErrGetAnalytics
+	}
+	complete, err := s.analyticsRepo.MedianTimeToStatus(ctx, nil, entity.StatusCompleted, from, end)
+	if err != nil {
+		return dto.ReportAnalyticsResponse{}, dto.ErrGetAnalytics
+	}
+
+	compliance, err := s.analyticsRepo.SLACompliance(ctx, nil, from, end)
+	if err != nil {
+		return dto.ReportAnalyticsResponse{}, dto.ErrGetAnalytics
+	}
+
+	slaMetrics := make([]dto.SLAComplianceMetric, 0, len(compliance))
+	var slaMet, slaTotal int64
+	for _, count := range compliance {
+		slaMetrics = append(slaMetrics, dto.SLAComplianceMetric{
+			Status:  count.Status,
+			Met:     count.Met,
+			Total:   count.Total,
+			Percent: percentOf(count.Met, count.Total),
+		})
+		slaMet += count.Met
+		slaTotal += count.Total
+	}
+
+	return dto.ReportAnalyticsResponse{
+		Interval: req.Interval,
+		GroupBy:  req.GroupBy,
+		From:     from.Format(analyticsDateLayout),
+		To:       to.Format(analyticsDateLayout),
+		Buckets:  buckets,
+		Totals:   totals,
+		Series:   series,
+		Metrics: dto.ReportAnalyticsMetrics{
+			MedianTimeToVerifyHours:   verify.MedianHours,
+			MedianTimeToCompleteHours: complete.MedianHours,
+			VerifiedCount:             verify.Count,
+			CompletedCount:            complete.Count,
+			SLACompliance:             slaMetrics,
+			SLACompliancePercent:      percentOf(slaMet, slaTotal),
+		},
+	}, nil
+}
+
+func (s *analyticsService) GetSatisfaction(ctx context.Context, req dto.SatisfactionRequest) (dto.SatisfactionResponse, error) {
+	loc := utils.JakartaLocation()
+
+	to := startOfDay(time.Now().In(loc))
+	if !req.To.IsZero() {
+		to = time.Date(req.To.Year(), req.To.Month(), req.To.Day(), 0, 0, 0, 0, loc)
+	}
+	from := to.AddDate(0, 0, -89)
+	if !req.From.IsZero() {
+		from = time.Date(req.From.Year(), req.From.Month(), req.From.Day(), 0, 0, 0, 0, loc)
+	}
+	if to.Before(from) {
+		return dto.SatisfactionResponse{}, dto.ErrInvalidDateRange
+	}
+	end := to.AddDate(0, 0, 1)
+
+	overall, err := s.analyticsRepo.CountRatings(ctx, nil, "", from, end)
+	if err != nil {
+		return dto.SatisfactionResponse{}, dto.ErrGetSatisfaction
+	}
+	byClass, err := s.analyticsRepo.CountRatings(ctx, nil, dto.ANALYTICS_GROUP_CLASS, from, end)
+	if err != nil {
+		return dto.SatisfactionResponse{}, dto.ErrGetSatisfaction
+	}
+	byLocation, err := s.analyticsRepo.CountRatings(ctx, nil, dto.ANALYTICS_GROUP_LOCATION, from, end)
+	if err != nil {
+		return dto.SatisfactionResponse{}, dto.ErrGetSatisfaction
+	}
+
+	total := dto.SatisfactionScore{Key: "total", Distribution: make([]int64, dto.RATING_MAX_SCORE)}
+	if scores := SatisfactionScores(overall); len(scores) > 0 {
+		total = scores[0]
+	}
+
+	return dto.SatisfactionResponse{
+		From:       from.Format(analyticsDateLayout),
+		To:         to.Format(analyticsDateLayout),
+		Overall:    total,
+		ByClass:    SatisfactionScores(byClass),
+		ByLocation: SatisfactionScores(byLocation),
+	}, nil
+}
+
+// SatisfactionScores folds per-score rating counts into one score per key,
+// the most rated key first.
+func SatisfactionScores(counts []dto.SatisfactionCount) []dto.SatisfactionScore {
+	byKey := map[string]*dto.SatisfactionScore{}
+	sums := map[string]int64{}
+	for _, count := range counts {
+		if count.Score < dto.RATING_MIN_SCORE || count.Score > dto.RATING_MAX_SCORE {
+			continue
+		}
+
+		score, ok := byKey[count.Key]
+		if !ok {
+			score = &dto.SatisfactionScore{Key: count.Key, Distribution: make([]int64, dto.RATING_MAX_SCORE)}
+			byKey[count.Key] = score
+		}
+		score.Distribution[count.Score-1] += count.Count
+		score.Count += count.Count
+		sums[count.Key] += int64(count.Score) * count.Count
+	}
+
+	scores := make([]dto.SatisfactionScore, 0, len(byKey))
+	for key, score := range byKey {
+		average := float64(sums[key]) / float64(score.Count)
+		score.Average = &average
+		scores = append(scores, *score)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Count != scores[j].Count {
+			return scores[i].Count > scores[j].Count
+		}
+		return scores[i].Key < scores[j].Key
+	})
+
+	return scores
+}
+
+// percentOf is nil when there is nothing to measure.
+func percentOf(part, total int64) *float64 {
+	if total == 0 {
+		return nil
+	}
+	percent := float64(part) * 100 / float64(total)
+	return &percent
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// analyticsBuckets lists the bucket labels covering [from, end), truncated
+// the same way Postgres date_trunc does (weeks start on Monday).
+func analyticsBuckets(interval string, from, end time.Time) []string {
+	start := startOfDay(from)
+	step := func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+
+	switch interval {
+	case dto.ANALYTICS_INTERVAL_WEEK:
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case dto.ANALYTICS_INTERVAL_MONTH:
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+		step = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	}
+
+	var buckets []string
+	for t := start; t.Before(end); t = step(t) {
+		buckets = append(buckets, t.Format(analyticsDateLayout))
+		if len(buckets) > dto.ANALYTICS_MAX_BUCKETS {
+			break
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+)
+
+type (
+	AppealService interface {
+		FileAppeal(ctx context.Context, reportId string, req dto.FileAppealRequest, userId string) (dto.AppealResponse, error)
+		GetAppeal(ctx context.Context, reportId string, userId string) (dto.AppealResponse, error)
+		GetAppeals(ctx context.Context, req dto.AppealListRequest) (dto.AppealPaginationResponse, error)
+		ReviewAppeal(ctx context.Context, appealId string, accept bool, req dto.ReviewAppealRequest, reviewerId string) (dto.AppealResponse, error)
+	}
+
+	appealService struct {
+		*ReportWorkflow
+		appealRepo repository.ReportAppealRepository
+	}
+)
+
+func NewAppealService(workflow *ReportWorkflow, appealRepo repository.ReportAppealRepository) AppealService {
+	return &appealService{
+		ReportWorkflow: workflow,
+		appealRepo:     appealRepo,
+	}
+}
+
+// FileAppeal lets the reporter contest a rejection once, with more text,
+// images or both.
+func (s *appealService) FileAppeal(ctx context.Context, reportId string, req dto.FileAppealRequest, userId string) (dto.AppealResponse, error) {
+	text := strings.TrimSpace(req.Text)
+	if text == "" && len(req.Images) == 0 {
+		return dto.AppealResponse{}, dto.ErrAppealEmpty
+	}
+	if len(req.Images) > dto.APPEAL_MAX_IMAGES {
+		return dto.AppealResponse{}, dto.ErrTooManyAppealImages
+	}
+	if err := validateImages(req.Images); err != nil {
+		return dto.AppealResponse{}, err
+	}
+
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return dto.AppealResponse{}, dto.ErrUserNotFound
+	}
+
+	report, err := s.reportRepo.GetReportById(ctx, nil, reportId)
+	if err != nil {
+		return dto.AppealResponse{}, dto.ErrGetReportById
+	}
+	if report.UserID != userId {
+		return dto.AppealResponse{}, dto.ErrNotReportReporter
+	}
+	if report.Status != entity.StatusRejected {
+		return dto.AppealResponse{}, dto.ErrReportNotRejected
+	}
+	if _, err := s.appealRepo.GetByReportId(ctx, nil, reportId); err == nil {
+		return dto.AppealResponse{}, dto.ErrAppealExists
+	}
+
+	appeal := entity.ReportAppeal{
+		ID:            uuid.New(),
+		ReportID:      report.ID,
+		UserID:        userUUID,
+		Text:          text,
+		RejectionCode: report.RejectionCode,
+		Status:        entity.AppealPending,
+	}
+
+	images, err := uploadImages("appeals", appeal.ID.String(), req.Images)
+	if err != nil {
+		return dto.AppealResponse{}, dto.ErrFileAppeal
+	}
+	appeal.Images = strings.Join(images, ",")
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	// the unique index on report_id settles a race between two requests
+	created, err := s.appealRepo.Create(ctx, tx, appeal)
+	if err != nil {
+		tx.Rollback()
+		removeUploads(images)
+		return dto.AppealResponse{}, dto.ErrFileAppeal
+	}
+
+	// the appeal text is only for the reporter and admins, while history
+	// is public, so history points at the appeal instead
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryAppealFiled,
+		ActorID:  &userUUID,
+		Note:     fmt.Sprintf("Banding %s", appeal.ID),
+	}); err != nil {
+		tx.Rollback()
+		removeUploads(images)
+		return dto.AppealResponse{}, dto.ErrFileAppeal
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		removeUploads(images)
+		return dto.AppealResponse{}, dto.ErrFileAppeal
+	}
+
+	return toAppealResponse(created), nil
+}
+
+// GetAppeal is visible to the reporter and to admins.
+func (s *appealService) GetAppeal(ctx context.Context, reportId string, userId string) (dto.AppealResponse, error) {
+	appeal, err := s.appealRepo.GetByReportId(ctx, nil, reportId)
+	if err != nil {
+		return dto.AppealResponse{}, dto.ErrAppealNotFound
+	}
+
+	if appeal.UserID.String() != userId {
+		user, err := s.userRepo.GetUserById(ctx, nil, userId)
+		if err != nil || user.Role != constants.ENUM_ROLE_ADMIN {
+			return dto.AppealResponse{}, dto.ErrAppealNotFound
+		}
+	}
+
+	return toAppealResponse(appeal), nil
+}
+
+func (s *appealService) GetAppeals(ctx context.Context, req dto.AppealListRequest) (dto.AppealPaginationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.AppealPaginationResponse{}, err
+	}
+
+	appeals, err := s.appealRepo.GetAppeals(ctx, nil, req)
+	if err != nil {
+		return dto.AppealPaginationResponse{}, dto.ErrGetAppeals
+	}
+
+	datas := make([]dto.AppealResponse, 0, len(appeals.Appeals))
+	for _, appeal := range appeals.Appeals {
+		datas = append(datas, toAppealResponse(appeal))
+	}
+
+	return dto.AppealPaginationResponse{
+		Data:               datas,
+		PaginationResponse: appeals.PaginationResponse,
+	}, nil
+}
+
+// ReviewAppeal settles a pending appeal. Accepting reverts the report to
+// unverified so it is reviewed again; upholding keeps the rejection.
+func (s *appealService) ReviewAppeal(
+	ctx context.Context,
+	appealId string,
+	accept bool,
+	req dto.ReviewAppealRequest,
+	reviewerId string,
+) (dto.AppealResponse, error) {
+	if _, err := uuid.Parse(appealId); err != nil {
+		return dto.AppealResponse{}, dto.ErrAppealNotFound
+	}
+
+	status, event := entity.AppealUpheld, entity.HistoryAppealUpheld
+	if accept {
+		status, event = entity.AppealAccepted, entity.HistoryAppealAccepted
+	}
+	note := strings.TrimSpace(req.Note)
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	appeal, err := s.appealRepo.GetById(ctx, tx, appealId)
+	if err != nil {
+		tx.Rollback()
+		return dto.AppealResponse{}, dto.ErrAppealNotFound
+	}
+
+	// lock the report so the status checked below is still current when
+	// the accepted appeal changes it
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, appeal.ReportID.String())
+	if err != nil {
+		tx.Rollback()
+		return dto.AppealResponse{}, dto.ErrGetReportById
+	}
+
+	now := time.Now()
+	reviewer := parseActorId(reviewerId)
+	reviewed, err := s.appealRepo.Review(ctx, tx, appealId, status, reviewer, note, now)
+	if err != nil {
+		tx.Rollback()
+		return dto.AppealResponse{}, dto.ErrReviewAppeal
+	}
+	if !reviewed {
+		tx.Rollback()
+		return dto.AppealResponse{}, dto.ErrAppealNotPending
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    event,
+		ActorID:  reviewer,
+		Note:     note,
+	}); err != nil {
+		tx.Rollback()
+		return dto.AppealResponse{}, dto.ErrReviewAppeal
+	}
+
+	if accept && report.Status == entity.StatusRejected {
+		if _, err := s.changeStatus(ctx, tx, report, entity.StatusUnverified, reviewerId, "Banding diterima"); err != nil {
+			tx.Rollback()
+			return dto.AppealResponse{}, dto.ErrReviewAppeal
+		}
+		if err := s.reverseSpamPenalty(ctx, tx, report); err != nil {
+			tx.Rollback()
+			return dto.AppealResponse{}, dto.ErrReviewAppeal
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.AppealResponse{}, dto.ErrReviewAppeal
+	}
+
+	msg := dto.NotificationMessage{
+		Type:  entity.NotificationAppeal,
+		Title: "Banding Anda ditolak",
+		Body:  "Laporan Anda tetap berstatus ditolak.",
+	}
+	if accept {
+		msg.Title = "Banding Anda diterima"
+		msg.Body = "Laporan Anda akan diverifikasi ulang."
+	}
+	if note != "" {
+		msg.Body += " Catatan: " + note
+	}
+	s.notifyOwner(ctx, report, msg)
+
+	appeal.Status = status
+	appeal.ReviewerID = reviewer
+	appeal.ReviewNote = note
+	appeal.ReviewedAt = &now
+	return toAppealResponse(appeal), nil
+}
+
+func toAppealResponse(appeal entity.ReportAppeal) dto.AppealResponse {
+	images := appeal.ImageList()
+	if images == nil {
+		images = []string{}
+	}
+
+	response := dto.AppealResponse{
+		ID:            appeal.ID.String(),
+		ReportID:      appeal.ReportID.String(),
+		UserID:        appeal.UserID.String(),
+		Text:          appeal.Text,
+		Images:        images,
+		RejectionCode: appeal.RejectionCode,
+		Status:        string(appeal.Status),
+		ReviewNote:    appeal.ReviewNote,
+		CreatedAt:     appeal.CreatedAt.Format(time.RFC3339),
+	}
+	if appeal.ReviewerID != nil {
+		response.ReviewerID = appeal.ReviewerID.String()
+	}
+	if appeal.ReviewedAt != nil {
+		response.ReviewedAt = appeal.ReviewedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+)
+
+type (
+	AssignmentService interface {
+		AssignReport(ctx context.Context, reportId string, req dto.AssignReportRequest, actorId string) (dto.AssignmentResponse, error)
+		UnassignReport(ctx context.Context, reportId string, actorId string) (dto.AssignmentResponse, error)
+		GetMyAssignments(ctx context.Context, officerId string, req dto.ReportFilterRequest) (dto.ReportPaginationResponse, error)
+		RouteReport(ctx context.Context, reportId string, req dto.RouteReportRequest, actorId string) (dto.RouteReportResponse, error)
+	}
+
+	assignmentService struct {
+		*ReportWorkflow
+		agencyRepo repository.AgencyRepository
+	}
+)
+
+func NewAssignmentService(workflow *ReportWorkflow, agencyRepo repository.AgencyRepository) AssignmentService {
+	return &assignmentService{
+		ReportWorkflow: workflow,
+		agencyRepo:     agencyRepo,
+	}
+}
+
+// AssignReport hands a report to an officer. A verified report moves to
+// handled at the same time, so the officer can complete it.
+func (s *assignmentService) AssignReport(
+	ctx context.Context,
+	reportId string,
+	req dto.AssignReportRequest,
+	actorId string,
+) (dto.AssignmentResponse, error) {
+	if !req.DueAt.After(time.Now()) {
+		return dto.AssignmentResponse{}, dto.ErrInvalidDueDate
+	}
+
+	officer, err := s.userRepo.GetUserById(ctx, nil, req.OfficerID)
+	if err != nil {
+		return dto.AssignmentResponse{}, dto.ErrUserNotFound
+	}
+	if officer.Role != constants.ENUM_ROLE_OFFICER {
+		return dto.AssignmentResponse{}, dto.ErrAssigneeNotOfficer
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrGetReportById
+	}
+	if report.Status != entity.StatusVerified && report.Status != entity.StatusHandled {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrReportNotAssignable
+	}
+
+	if report.AgencyID != nil {
+		member, err := s.agencyRepo.IsMember(ctx, tx, report.AgencyID.String(), officer.ID.String())
+		if err != nil {
+			tx.Rollback()
+			return dto.AssignmentResponse{}, dto.ErrAssignReport
+		}
+		if !member {
+			tx.Rollback()
+			return dto.AssignmentResponse{}, dto.ErrAssigneeNotInAgency
+		}
+	}
+
+	if err := s.reportRepo.UpdateAssignment(ctx, tx, reportId, &officer.ID, &req.DueAt); err != nil {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrAssignReport
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryAssigned,
+		ActorID:  parseActorId(actorId),
+		Note: fmt.Sprintf("Ditugaskan ke %s (%s), tenggat %s",
+			officer.Name, officer.ID, req.DueAt.In(utils.JakartaLocation()).Format("2006-01-02 15:04")),
+	}); err != nil {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrAssignReport
+	}
+
+	status := report.Status
+	if report.Status == entity.StatusVerified {
+		if _, err := s.changeStatus(ctx, tx, report, entity.StatusHandled, actorId, "ditugaskan ke petugas"); err != nil {
+			tx.Rollback()
+			return dto.AssignmentResponse{}, dto.ErrAssignReport
+		}
+		status = entity.StatusHandled
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.AssignmentResponse{}, dto.ErrAssignReport
+	}
+
+	if err := s.notificationService.Notify(ctx, officer.ID.String(), dto.NotificationMessage{
+		Type:     entity.NotificationAssigned,
+		Title:    "Tugas baru",
+		Body:     fmt.Sprintf("Anda ditugaskan menangani laporan di %s.", report.Location),
+		ReportID: report.ID.String(),
+	}); err != nil {
+		log.Printf("notify assignee of report %s: %v", report.ID, err)
+	}
+
+	return dto.AssignmentResponse{
+		ReportID:   report.ID.String(),
+		AssigneeID: officer.ID.String(),
+		DueAt:      req.DueAt.Format(time.RFC3339),
+		Status:     status,
+	}, nil
+}
+
+func (s *assignmentService) UnassignReport(ctx context.Context, reportId string, actorId string) (dto.AssignmentResponse, error) {
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrGetReportById
+	}
+	if report.AssigneeID == nil {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrReportNotAssigned
+	}
+
+	if err := s.reportRepo.UpdateAssignment(ctx, tx, reportId, nil, nil); err != nil {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrAssignReport
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryUnassigned,
+		ActorID:  parseActorId(actorId),
+		Note:     fmt.Sprintf("Penugasan %s dicabut", report.AssigneeID),
+	}); err != nil {
+		tx.Rollback()
+		return dto.AssignmentResponse{}, dto.ErrAssignReport
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.AssignmentResponse{}, dto.ErrAssignReport
+	}
+
+	return dto.AssignmentResponse{
+		ReportID: report.ID.String(),
+		Status:   report.Status,
+	}, nil
+}
+
+// GetMyAssignments lists the officer's open assignments, the closest
+// deadline first, unless the request asks for something else.
+func (s *assignmentService) GetMyAssignments(
+	ctx context.Context,
+	officerId string,
+	req dto.ReportFilterRequest,
+) (dto.ReportPaginationResponse, error) {
+	req.AssigneeID = officerId
+	if len(req.Status) == 0 {
+		req.Status = []string{string(entity.StatusHandled)}
+	}
+	if req.SortBy == "" && !req.IsCursor() {
+		req.SortBy = dto.REPORT_SORT_DUE_AT
+		if req.SortDir == "" {
+			req.SortDir = dto.REPORT_SORT_ASC
+		}
+	}
+
+	return s.listReports(ctx, req)
+}
+
+// RouteReport hands a report to another agency. An officer of the previous
+// agency loses the assignment in the same step.
+func (s *assignmentService) RouteReport(
+	ctx context.Context,
+	reportId string,
+	req dto.RouteReportRequest,
+	actorId string,
+) (dto.RouteReportResponse, error) {
+	agency, err := s.agencyRepo.GetById(ctx, nil, req.AgencyID)
+	if err != nil {
+		return dto.RouteReportResponse{}, dto.ErrAgencyNotFound
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.RouteReportResponse{}, dto.ErrGetReportById
+	}
+
+	if err := s.reportRepo.UpdateAgency(ctx, tx, reportId, &agency.ID); err != nil {
+		tx.Rollback()
+		return dto.RouteReportResponse{}, dto.ErrRouteReport
+	}
+
+	note := fmt.Sprintf("Diteruskan ke %s", agency.Name)
+	if req.Note != "" {
+		note += ": " + req.Note
+	}
+
+	if report.AssigneeID != nil {
+		member, err := s.agencyRepo.IsMember(ctx, tx, agency.ID.String(), report.AssigneeID.String())
+		if err != nil {
+			tx.Rollback()
+			return dto.RouteReportResponse{}, dto.ErrRouteReport
+		}
+		if !member {
+			if err := s.reportRepo.UpdateAssignment(ctx, tx, reportId, nil, nil); err != nil {
+				tx.Rollback()
+				return dto.RouteReportResponse{}, dto.ErrRouteReport
+			}
+			note += fmt.Sprintf(" (penugasan %s dicabut)", report.AssigneeID)
+		}
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryRouted,
+		ActorID:  parseActorId(actorId),
+		Note:     note,
+	}); err != nil {
+		tx.Rollback()
+		return dto.RouteReportResponse{}, dto.ErrRouteReport
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.RouteReportResponse{}, dto.ErrRouteReport
+	}
+
+	return dto.RouteReportResponse{
+		ReportID:   report.ID.String(),
+		AgencyID:   agency.ID.String(),
+		AgencyName: agency.Name,
+	}, nil
+}
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	CommentService interface {
+		CreateComment(ctx context.Context, reportId string, req dto.CreateCommentRequest, userId string, agencyIds []string) (dto.CommentResponse, error)
+		GetComments(ctx context.Context, reportId string, req dto.PaginationRequest, userId string, agencyIds []string) (dto.CommentPaginationResponse, error)
+		UpdateComment(ctx context.Context, commentId string, req dto.UpdateCommentRequest, userId string) (dto.CommentResponse, error)
+		DeleteComment(ctx context.Context, commentId string, userId string) error
+		ModerateComment(ctx context.Context, commentId string, req dto.ModerateCommentRequest, adminId string) (dto.CommentResponse, error)
+	}
+
+	commentService struct {
+		commentRepo         repository.CommentRepository
+		reportRepo          repository.ReportRepository
+		userRepo            repository.UserRepository
+		notificationService NotificationService
+	}
+)
+
+func NewCommentService(
+	commentRepo repository.CommentRepository,
+	reportRepo repository.ReportRepository,
+	userRepo repository.UserRepository,
+	notificationService NotificationService,
+) CommentService {
+	return &commentService{
+		commentRepo:         commentRepo,
+		reportRepo:          reportRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+func (s *commentService) CreateComment(
+	ctx context.Context,
+	reportId string,
+	req dto.CreateCommentRequest,
+	userId string,
+	agencyIds []string,
+) (dto.CommentResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return dto.CommentResponse{}, dto.ErrCommentEmpty
+	}
+
+	visibility := entity.CommentVisibility(req.Visibility)
+	if visibility == "" {
+		visibility = entity.CommentPublic
+	}
+	if !visibility.IsValid() {
+		return dto.CommentResponse{}, dto.ErrInvalidVisibility
+	}
+
+	author, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.CommentResponse{}, dto.ErrUserNotFound
+	}
+	if visibility == entity.CommentInternal && author.Role != constants.ENUM_ROLE_ADMIN {
+		return dto.CommentResponse{}, dto.ErrInternalCommentDenied
+	}
+
+	report, err := s.reportRepo.GetReportById(ctx, nil, reportId)
+	if err != nil || !reportVisibleTo(report, author) || !inAgencies(report, agencyIds) {
+		return dto.CommentResponse{}, dto.ErrGetReportById
+	}
+
+	comment := entity.Comment{
+		ReportID:   report.ID,
+		UserID:     author.ID,
+		Visibility: visibility,
+		Body:       body,
+	}
+
+	if req.ParentID != "" {
+		if _, err := uuid.Parse(req.ParentID); err != nil {
+			return dto.CommentResponse{}, dto.ErrCommentNotFound
+		}
+		parent, err := s.commentRepo.GetById(ctx, nil, req.ParentID)
+		if err != nil {
+			return dto.CommentResponse{}, dto.ErrCommentNotFound
+		}
+		if parent.ReportID != report.ID {
+			return dto.CommentResponse{}, dto.ErrCommentParentMismatch
+		}
+		if parent.Visibility == entity.CommentInternal && visibility != entity.CommentInternal {
+			return dto.CommentResponse{}, dto.ErrCommentVisibilityReply
+		}
+
+		// replies to a reply join the thread of its top-level comment
+		rootId := parent.ID
+		if parent.ParentID != nil {
+			rootId = *parent.ParentID
+		}
+		comment.ParentID = &rootId
+	}
+
+	created, err := s.commentRepo.Create(ctx, nil, comment)
+	if err != nil {
+		return dto.CommentResponse{}, dto.ErrCreateComment
+	}
+	created.User = author
+
+	s.notifyComment(ctx, report, created)
+
+	return toCommentResponse(created, author.Role == constants.ENUM_ROLE_ADMIN), nil
+}
+
+// notifyComment tells mentioned staff they were mentioned and, for public
+// comments, the reporter, the assigned officer and earlier commenters that
+// the conversation moved on. The author never hears about their own comment.
+func (s *commentService) notifyComment(ctx context.Context, report entity.Report, comment entity.Comment) {
+	notified := map[string]bool{comment.UserID.String(): true}
+	send := func(userId string, title string) {
+		if notified[userId] {
+			return
+		}
+		notified[userId] = true
+
+		if err := s.notificationService.Notify(ctx, userId, dto.NotificationMessage{
+			Type:     entity.NotificationComment,
+			Title:    title,
+			Body:     fmt.Sprintf("%s: %s", comment.User.Name, commentSnippet(comment.Body)),
+			ReportID: report.ID.String(),
+		}); err != nil {
+			log.Printf("notify comment %s to %s: %v", comment.ID, userId, err)
+		}
+	}
+
+	for _, email := range ParseMentions(comment.Body) {
+		user, err := s.userRepo.GetUserByEmail(ctx, nil, email)
+		if err != nil || !canBeMentioned(user, comment.Visibility) {
+			continue
+		}
+		send(user.ID.String(), "Anda disebut dalam komentar laporan")
+	}
+
+	if comment.Visibility != entity.CommentPublic {
+		return
+	}
+
+	send(report.UserID, "Komentar baru pada laporan Anda")
+	if report.AssigneeID != nil {
+		send(report.AssigneeID.String(), "Komentar baru pada laporan")
+	}
+
+	participants, err := s.commentRepo.GetParticipants(ctx, nil, report.ID.String())
+	if err != nil {
+		log.Printf("comment participants of report %s: %v", report.ID, err)
+	}
+	for _, participant := range participants {
+		send(participant.String(), "Komentar baru pada laporan")
+	}
+}
+
+// canBeMentioned limits mentions to staff who can read the comment.
+func canBeMentioned(user entity.User, visibility entity.CommentVisibility) bool {
+	switch user.Role {
+	case constants.ENUM_ROLE_ADMIN:
+		return true
+	case constants.ENUM_ROLE_OFFICER:
+		return visibility == entity.CommentPublic
+	}
+	return false
+}
+
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+// ParseMentions returns the addresses mentioned as @user@example.com, in
+// order, lowercased and without duplicates.
+func ParseMentions(body string) []string {
+	var emails []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(strings.TrimRight(match[1], "."))
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		emails = append(emails, email)
+		if len(emails) == dto.COMMENT_MAX_MENTIONS {
+			break
+		}
+	}
+	return emails
+}
+
+func commentSnippet(body string) string {
+	runes := []rune(body)
+	if len(runes) <= 100 {
+		return body
+	}
+	return string(runes[:100]) + "…"
+}
+
+// GetComments lists the threads of a report. Agency staff, whose agencies
+// are passed in agencyIds, only see comments on reports of those agencies.
+// inAgencies tells whether agency staff scoped to agencyIds may see
+// report; an empty scope sees everything.
+func inAgencies(report entity.Report, agencyIds []string) bool {
+	return len(agencyIds) == 0 || (report.AgencyID != nil && slices.Contains(agencyIds, report.AgencyID.String()))
+}
+
+func (s *commentService) GetComments(ctx context.Context, reportId string, req dto.PaginationRequest, userId string, agencyIds []string) (dto.CommentPaginationResponse, error) {
+	viewer, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.CommentPaginationResponse{}, dto.ErrUserNotFound
+	}
+	isAdmin := viewer.Role == constants.ENUM_ROLE_ADMIN
+
+	report, err := s.reportRepo.GetReportById(ctx, nil, reportId)
+	if err != nil || !reportVisibleTo(report, viewer) || !inAgencies(report, agencyIds) {
+		return dto.CommentPaginationResponse{}, dto.ErrGetReportById
+	}
+
+	threads, err := s.commentRepo.GetThreads(ctx, nil, reportId, isAdmin, req)
+	if err != nil {
+		return dto.CommentPaginationResponse{}, dto.ErrGetComments
+	}
+
+	rootIds := make([]uuid.UUID, 0, len(threads.Comments))
+	for _, comment := range threads.Comments {
+		rootIds = append(rootIds, comment.ID)
+	}
+	replies, err := s.commentRepo.GetReplies(ctx, nil, rootIds, isAdmin)
+	if err != nil {
+		return dto.CommentPaginationResponse{}, dto.ErrGetComments
+	}
+
+	repliesByRoot := map[uuid.UUID][]dto.CommentResponse{}
+	for _, reply := range replies {
+		root := *reply.ParentID
+		if len(repliesByRoot[root]) < dto.COMMENT_MAX_REPLIES {
+			repliesByRoot[root] = append(repliesByRoot[root], toCommentResponse(reply, isAdmin))
+		}
+	}
+
+	datas := make([]dto.CommentResponse, 0, len(threads.Comments))
+	for _, comment := range threads.Comments {
+		data := toCommentResponse(comment, isAdmin)
+		data.Replies = repliesByRoot[comment.ID]
+		datas = append(datas, data)
+	}
+
+	return dto.CommentPaginationResponse{
+		Data:               datas,
+		PaginationResponse: threads.PaginationResponse,
+	}, nil
+}
+
+// UpdateComment is for the author only, and not once a comment is deleted
+// or hidden by a moderator.
+func (s *commentService) UpdateComment(ctx context.Context, commentId string, req dto.UpdateCommentRequest, userId string) (dto.CommentResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return dto.CommentResponse{}, dto.ErrCommentEmpty
+	}
+
+	comment, err := s.getComment(ctx, commentId)
+	if err != nil {
+		return dto.CommentResponse{}, err
+	}
+	if comment.UserID.String() != userId || comment.Hidden {
+		return dto.CommentResponse{}, dto.ErrCommentForbidden
+	}
+	if comment.DeletedAt != nil {
+		return dto.CommentResponse{}, dto.ErrCommentDeleted
+	}
+
+	now := time.Now()
+	if err := s.commentRepo.UpdateBody(ctx, nil, commentId, body, now); err != nil {
+		return dto.CommentResponse{}, dto.ErrUpdateComment
+	}
+
+	comment.Body = body
+	comment.EditedAt = &now
+	return toCommentResponse(comment, false), nil
+}
+
+// DeleteComment is allowed to the author and to admins.
+func (s *commentService) DeleteComment(ctx context.Context, commentId string, userId string) error {
+	comment, err := s.getComment(ctx, commentId)
+	if err != nil {
+		return err
+	}
+	if comment.DeletedAt != nil {
+		return dto.ErrCommentDeleted
+	}
+
+	if comment.UserID.String() != userId {
+		user, err := s.userRepo.GetUserById(ctx, nil, userId)
+		if err != nil || user.Role != constants.ENUM_ROLE_ADMIN {
+			return dto.ErrCommentForbidden
+		}
+	}
+
+	if err := s.commentRepo.MarkDeleted(ctx, nil, commentId, time.Now()); err != nil {
+		return dto.ErrUpdateComment
+	}
+
+	return nil
+}
+
+func (s *commentService) ModerateComment(ctx context.Context, commentId string, req dto.ModerateCommentRequest, adminId string) (dto.CommentResponse, error) {
+	moderatorId, err := uuid.Parse(adminId)
+	if err != nil {
+		return dto.CommentResponse{}, dto.ErrUserNotFound
+	}
+
+	comment, err := s.getComment(ctx, commentId)
+	if err != nil {
+		return dto.CommentResponse{}, err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if !req.Hidden {
+		reason = ""
+	}
+	if err := s.commentRepo.Moderate(ctx, nil, commentId, req.Hidden, reason, moderatorId); err != nil {
+		return dto.CommentResponse{}, dto.ErrUpdateComment
+	}
+
+	comment.Hidden = req.Hidden
+	comment.ModerationReason = reason
+	comment.ModeratedBy = &moderatorId
+	return toCommentResponse(comment, true), nil
+}
+
+func (s *commentService) getComment(ctx context.Context, commentId string) (entity.Comment, error) {
+	if _, err := uuid.Parse(commentId); err != nil {
+		return entity.Comment{}, dto.ErrCommentNotFound
+	}
+
+	comment, err := s.commentRepo.GetById(ctx, nil, commentId)
+	if err != nil {
+		return entity.Comment{}, dto.ErrCommentNotFound
+	}
+
+	return comment, nil
+}
+
+func toCommentResponse(comment entity.Comment, isAdmin bool) dto.CommentResponse {
+	response := dto.CommentResponse{
+		ID:         comment.ID.String(),
+		ReportID:   comment.ReportID.String(),
+		UserID:     comment.UserID.String(),
+		Username:   comment.User.Name,
+		Visibility: string(comment.Visibility),
+		Body:       comment.Body,
+		Edited:     comment.EditedAt != nil,
+		Deleted:    comment.DeletedAt != nil,
+		Hidden:     comment.Hidden,
+		CreatedAt:  comment.CreatedAt.Format(time.RFC3339),
+	}
+	if comment.ParentID != nil {
+		response.ParentID = comment.ParentID.String()
+	}
+	if comment.Hidden {
+		response.ModerationReason = comment.ModerationReason
+		if !isAdmin {
+			response.Body = ""
+		}
+	}
+	if comment.DeletedAt != nil {
+		response.Body = ""
+	}
+
+	return response
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	DeviceService interface {
+		RegisterDevice(ctx context.Context, userId string, req dto.RegisterDeviceRequest) (dto.DeviceResponse, error)
+		UnregisterDevice(ctx context.Context, userId string, req dto.UnregisterDeviceRequest) error
+		GetDevices(ctx context.Context, userId string) ([]dto.DeviceResponse, error)
+		// SendPush is the JOB_SEND_PUSH handler.
+		SendPush(ctx context.Context, job dto.SendPushJob) error
+	}
+
+	deviceService struct {
+		deviceRepo repository.DeviceTokenRepository
+		pushSender utils.PushSender
+		db         *gorm.DB
+	}
+)
+
+func NewDeviceService(deviceRepo repository.DeviceTokenRepository, pushSender utils.PushSender, db *gorm.DB) DeviceService {
+	return &deviceService{
+		deviceRepo: deviceRepo,
+		pushSender: pushSender,
+		db:         db,
+	}
+}
+
+func (s *deviceService) RegisterDevice(ctx context.Context, userId string, req dto.RegisterDeviceRequest) (dto.DeviceResponse, error) {
+	ownerId, err := uuid.Parse(userId)
+	if err != nil {
+		return dto.DeviceResponse{}, dto.ErrUserNotFound
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	if req.PreviousToken != "" && req.PreviousToken != req.Token {
+		if _, err := s.deviceRepo.DeleteByUserAndToken(ctx, tx, userId, req.PreviousToken); err != nil {
+			tx.Rollback()
+			return dto.DeviceResponse{}, dto.ErrRegisterDevice
+		}
+	}
+
+	device, err := s.deviceRepo.Upsert(ctx, tx, entity.DeviceToken{
+		UserID:     ownerId,
+		Token:      req.Token,
+		Platform:   req.Platform,
+		LastSeenAt: time.Now(),
+	})
+	if err != nil {
+		tx.Rollback()
+		return dto.DeviceResponse{}, dto.ErrRegisterDevice
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.DeviceResponse{}, dto.ErrRegisterDevice
+	}
+
+	return toDeviceResponse(device), nil
+}
+
+func (s *deviceService) UnregisterDevice(ctx context.Context, userId string, req dto.UnregisterDeviceRequest) error {
+	deleted, err := s.deviceRepo.DeleteByUserAndToken(ctx, nil, userId, req.Token)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return dto.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (s *deviceService) GetDevices(ctx context.Context, userId string) ([]dto.DeviceResponse, error) {
+	devices, err := s.deviceRepo.GetByUserId(ctx, nil, userId)
+	if err != nil {
+		return nil, dto.ErrGetDevices
+	}
+
+	datas := make([]dto.DeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		datas = append(datas, toDeviceResponse(device))
+	}
+
+	return datas, nil
+}
+
+// SendPush delivers to one device. A token the provider rejects is deleted
+// and the job succeeds; any other error is retried by the job queue.
+func (s *deviceService) SendPush(ctx context.Context, job dto.SendPushJob) error {
+	err := s.pushSender.Send(ctx, job.Token, utils.PushMessage{
+		Title: job.Title,
+		Body:  job.Body,
+		Data:  job.Data,
+	})
+	if errors.Is(err, utils.ErrInvalidPushToken) {
+		log.Printf("push: pruning invalid device token")
+		return s.deviceRepo.DeleteByToken(ctx, nil, job.Token)
+	}
+
+	return err
+}
+
+func toDeviceResponse(device entity.DeviceToken) dto.DeviceResponse {
+	return dto.DeviceResponse{
+		ID:         device.ID.String(),
+		Platform:   device.Platform,
+		LastSeenAt: device.LastSeenAt.Format(time.RFC3339),
+		CreatedAt:  device.CreatedAt.Format(time.RFC3339),
+	}
+}
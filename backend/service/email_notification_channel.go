@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+)
+
+// emailTemplates lists the notification types worth an email. Anything not
+// listed stays in-app only.
+var emailTemplates = map[entity.NotificationType]utils.EmailTemplate{
+	entity.NotificationStatusChanged: utils.EmailReportStatus,
+	entity.NotificationSubscription:  utils.EmailSubscriptionAlert,
+	entity.NotificationCompletion:    utils.EmailReportStatus,
+	entity.NotificationDisputed:      utils.EmailReportStatus,
+	entity.NotificationAppeal:        utils.EmailReportStatus,
+}
+
+// emailNotificationChannel renders the mail right away but leaves sending
+// to a JOB_SEND_EMAIL job, so a slow or failing SMTP server neither blocks
+// the request nor loses the mail.
+type emailNotificationChannel struct {
+	jobService JobService
+}
+
+func NewEmailNotificationChannel(jobService JobService) NotificationChannel {
+	return &emailNotificationChannel{
+		jobService: jobService,
+	}
+}
+
+func (c *emailNotificationChannel) Enabled(prefs entity.NotificationPreferences) bool {
+	return prefs.Email
+}
+
+func (c *emailNotificationChannel) Send(ctx context.Context, user entity.User, notification entity.Notification) error {
+	name, ok := emailTemplates[notification.Type]
+	if !ok {
+		return nil
+	}
+
+	mail, err := utils.RenderEmail(name, struct {
+		Name  string
+		Title string
+		Body  string
+	}{
+		Name:  user.Name,
+		Title: notification.Title,
+		Body:  notification.Body,
+	})
+	if err != nil {
+		return err
+	}
+	mail.To = user.Email
+
+	_, err = c.jobService.Enqueue(ctx, nil, dto.JOB_SEND_EMAIL, mail)
+	return err
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+)
+
+type (
+	// EventBroker fans report events out to SSE clients on every API
+	// instance. Publishing goes through Postgres NOTIFY, and each instance
+	// LISTENs and delivers to its own subscribers, so no other broker is
+	// needed.
+	EventBroker interface {
+		// Publish sends the event inside tx when given, so it is only
+		// delivered if the transaction commits.
+		Publish(ctx context.Context, tx *gorm.DB, event dto.ReportEvent) error
+		// Subscribe returns the live channel plus any buffered events that came
+		// after lastEventId, for clients reconnecting with Last-Event-ID.
+		Subscribe(lastEventId int64) (<-chan dto.ReportEvent, []dto.ReportEvent, func())
+		// Run listens for notifications until ctx ends, reconnecting on errors.
+		Run(ctx context.Context)
+	}
+
+	eventBroker struct {
+		db  *gorm.DB
+		dsn string
+
+		mu          sync.Mutex
+		buffer      []dto.ReportEvent
+		subscribers map[int]chan dto.ReportEvent
+		nextId      int
+	}
+)
+
+const (
+	eventReplayBufferSize   = 500
+	eventSubscriberCapacity = 64
+)
+
+func NewEventBroker(db *gorm.DB, dsn string) EventBroker {
+	return &eventBroker{
+		db:          db,
+		dsn:         dsn,
+		subscribers: map[int]chan dto.ReportEvent{},
+	}
+}
+
+func (b *eventBroker) Publish(ctx context.Context, tx *gorm.DB, event dto.ReportEvent) error {
+	if tx == nil {
+		tx = b.db
+	}
+
+	if err := tx.WithContext(ctx).Raw(`SELECT nextval('report_event_seq')`).Scan(&event.ID).Error; err != nil {
+		return err
+	}
+	if event.CreatedAt == "" {
+		event.CreatedAt = time.Now().Format(time.RFC3339)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return tx.WithContext(ctx).Exec(`SELECT pg_notify(?, ?)`, dto.EVENT_CHANNEL, string(payload)).Error
+}
+
+func (b *eventBroker) Subscribe(lastEventId int64) (<-chan dto.ReportEvent, []dto.ReportEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// replay and registration happen under one lock so nothing is missed
+	// or delivered twice in between
+	replay := EventsAfter(b.buffer, lastEventId)
+
+	id := b.nextId
+	b.nextId++
+	ch := make(chan dto.ReportEvent, eventSubscriberCapacity)
+	b.subscribers[id] = ch
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if ch, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			close(ch)
+		}
+	}
+
+	return ch, replay, unsubscribe
+}
+
+// EventsAfter returns the buffered events a client that last saw
+// lastEventId has missed. Ids are taken when an event is published but
+// events arrive in commit order, so the missed events are those after
+// lastEventId in the buffer rather than those with a larger id. When
+// lastEventId has left the buffer the client gets all of it. A client
+// without a Last-Event-ID starts live.
+func EventsAfter(buffer []dto.ReportEvent, lastEventId int64) []dto.ReportEvent {
+	if lastEventId <= 0 {
+		return nil
+	}
+
+	for i := len(buffer) - 1; i >= 0; i-- {
+		if buffer[i].ID == lastEventId {
+			return slices.Clone(buffer[i+1:])
+		}
+	}
+	return slices.Clone(buffer)
+}
+
+func (b *eventBroker) deliver(event dto.ReportEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buffer = append(b.buffer, event)
+	if len(b.buffer) > eventReplayBufferSize {
+		b.buffer = b.buffer[len(b.buffer)-eventReplayBufferSize:]
+	}
+
+	for id, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// a client this far behind reconnects and replays instead
+			delete(b.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+func (b *eventBroker) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("event broker: %v, reconnecting in %s", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (b *eventBroker) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{dto.EVENT_CHANNEL}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event dto.ReportEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("event broker: bad payload: %v", err)
+			continue
+		}
+		b.deliver(event)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+)
+
+type (
+	ExportService interface {
+		ExportGeoJSON(ctx context.Context, req dto.ReportFilterRequest, w io.Writer) error
+		ExportKML(ctx context.Context, req dto.ReportFilterRequest, w io.Writer) error
+		ValidateSpreadsheetExport(req dto.SpreadsheetExportRequest) error
+		ShouldExportInBackground(ctx context.Context, req dto.SpreadsheetExportRequest) (bool, error)
+		ExportSpreadsheet(ctx context.Context, req dto.SpreadsheetExportRequest, w io.Writer) error
+		StartSpreadsheetExport(ctx context.Context, req dto.SpreadsheetExportRequest, userId string) (dto.ReportExportResponse, error)
+		GetReportExport(ctx context.Context, exportId string) (dto.ReportExportResponse, error)
+		// GetReportExportFile returns the finished spreadsheet and the name
+		// to download it as.
+		GetReportExportFile(ctx context.Context, exportId string) ([]byte, string, error)
+		RunSpreadsheetExport(ctx context.Context, job dto.ReportExportJob) error
+		// PurgeExports deletes background exports, and their files, older
+		// than EXPORT_RETENTION.
+		PurgeExports(ctx context.Context) error
+	}
+
+	exportService struct {
+		reportRepo  repository.ReportRepository
+		historyRepo repository.ReportHistoryRepository
+		exportRepo  repository.ReportExportRepository
+		fileRepo    repository.StoredFileRepository
+		jobService  JobService
+	}
+)
+
+func NewExportService(
+	reportRepo repository.ReportRepository,
+	historyRepo repository.ReportHistoryRepository,
+	exportRepo repository.ReportExportRepository,
+	fileRepo repository.StoredFileRepository,
+	jobService JobService,
+) ExportService {
+	return &exportService{
+		reportRepo:  reportRepo,
+		historyRepo: historyRepo,
+		exportRepo:  exportRepo,
+		fileRepo:    fileRepo,
+		jobService:  jobService,
+	}
+}
+
+func reportClass(report entity.Report) string {
+	if report.Tag.Class == "" {
+		return "unclassified"
+	}
+	return report.Tag.Class
+}
+
+// flush pushes what has been written so far to the client, so a large
+// export starts arriving before the last batch is read.
+func flush(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *exportService) ExportGeoJSON(ctx context.Context, req dto.ReportFilterRequest, w io.Writer) error {
+	if _, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`); err != nil {
+		return err
+	}
+
+	first := true
+	err := s.reportRepo.StreamReports(ctx, nil, req, dto.EXPORT_BATCH_SIZE, func(reports []entity.Report) error {
+		for _, report := range reports {
+			feature := dto.GeoJSONFeature{
+				Type: "Feature",
+				ID:   report.ID.String(),
+				Properties: dto.GeoJSONReportProperties{
+					Status:    string(report.Status),
+					Class:     reportClass(report),
+					Upvotes:   report.Upvotes,
+					Location:  report.Location,
+					CreatedAt: report.CreatedAt.Format(time.RFC3339),
+				},
+			}
+			if lat, lng, ok := report.Coordinates(); ok {
+				// GeoJSON positions are longitude first
+				feature.Geometry = &dto.GeoJSONGeometry{Type: "Point", Coordinates: [2]float64{lng, lat}}
+			}
+
+			raw, err := json.Marshal(feature)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+		}
+		flush(w)
+		return nil
+	})
+	if err != nil {
+		return dto.ErrExportReports
+	}
+
+	_, err = io.WriteString(w, "]}")
+	return err
+}
+
+func (s *exportService) ExportKML(ctx context.Context, req dto.ReportFilterRequest, w io.Writer) error {
+	header := xml.Header + `<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>Laporan RAPID</name>`
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	err := s.reportRepo.StreamReports(ctx, nil, req, dto.EXPORT_BATCH_SIZE, func(reports []entity.Report) error {
+		for _, report := range reports {
+			lat, lng, ok := report.Coordinates()
+			if !ok {
+				// a placemark without a point is useless on a map layer
+				continue
+			}
+
+			placemark := dto.KMLPlacemark{
+				ID:          report.ID.String(),
+				Name:        reportClass(report),
+				Description: report.Location,
+				ExtendedData: []dto.KMLData{
+					{Name: "status", Value: string(report.Status)},
+					{Name: "class", Value: reportClass(report)},
+					{Name: "upvotes", Value: strconv.Itoa(report.Upvotes)},
+					{Name: "created_at", Value: report.CreatedAt.Format(time.RFC3339)},
+				},
+				Coordinates: fmt.Sprintf("%f,%f", lng, lat),
+			}
+			if err := encoder.Encode(placemark); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Flush(); err != nil {
+			return err
+		}
+		flush(w)
+		return nil
+	})
+	if err != nil {
+		return dto.ErrExportReports
+	}
+
+	_, err = io.WriteString(w, "</Document></kml>")
+	return err
+}
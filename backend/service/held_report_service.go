@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+)
+
+type (
+	// HeldReportService is the admin review of reports held back from
+	// low-trust reporters.
+	HeldReportService interface {
+		GetHeldReports(ctx context.Context, req dto.ReportFilterRequest) (dto.ReportPaginationResponse, error)
+		ReleaseReport(ctx context.Context, reportId string, adminId string) (dto.ReportResponse, error)
+	}
+
+	heldReportService struct {
+		*ReportWorkflow
+	}
+)
+
+func NewHeldReportService(workflow *ReportWorkflow) HeldReportService {
+	return &heldReportService{
+		ReportWorkflow: workflow,
+	}
+}
+
+// GetHeldReports is the admin queue of reports pending review, oldest
+// first.
+func (s *heldReportService) GetHeldReports(ctx context.Context, req dto.ReportFilterRequest) (dto.ReportPaginationResponse, error) {
+	req.Status = []string{string(entity.StatusPendingReview)}
+	req.IncludeHeld = true
+	if req.SortBy == "" {
+		req.SortBy = dto.REPORT_SORT_CREATED_AT
+		req.SortDir = dto.REPORT_SORT_ASC
+	}
+
+	return s.listReports(ctx, req)
+}
+
+// ReleaseReport publishes a held report as unverified, the state it would
+// have been created in.
+func (s *heldReportService) ReleaseReport(ctx context.Context, reportId string, adminId string) (dto.ReportResponse, error) {
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.ReportResponse{}, dto.ErrGetReportById
+	}
+	if report.Status != entity.StatusPendingReview {
+		tx.Rollback()
+		return dto.ReportResponse{}, dto.ErrReportNotHeld
+	}
+
+	if _, err := s.changeStatus(ctx, tx, report, entity.StatusUnverified, adminId, "Dirilis dari peninjauan"); err != nil {
+		tx.Rollback()
+		return dto.ReportResponse{}, dto.ErrReleaseReport
+	}
+	if err := s.matchSubscriptions(ctx, tx, report, dto.SUBSCRIPTION_EVENT_CREATED); err != nil {
+		tx.Rollback()
+		return dto.ReportResponse{}, dto.ErrReleaseReport
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.ReportResponse{}, dto.ErrReleaseReport
+	}
+
+	s.notifyOwner(ctx, report, dto.NotificationMessage{
+		Type:  entity.NotificationStatusChanged,
+		Title: "Laporan Anda telah dipublikasikan",
+		Body:  "Laporan Anda lolos peninjauan dan menunggu verifikasi.",
+	})
+
+	report.Status = entity.StatusUnverified
+	return toReportResponse(report), nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"gorm.io/gorm"
+)
+
+type (
+	// JobHandler runs one job type. Handlers are registered in the injector
+	// under JobHandlerName(type) and picked up by the worker.
+	JobHandler interface {
+		Handle(ctx context.Context, job entity.Job) error
+	}
+
+	JobService interface {
+		Enqueue(ctx context.Context, tx *gorm.DB, jobType string, payload any) (entity.Job, error)
+		EnqueueAt(ctx context.Context, tx *gorm.DB, jobType string, payload any, runAt time.Time) (entity.Job, error)
+		GetJobs(ctx context.Context, req dto.JobListRequest) (dto.JobPaginationResponse, error)
+		GetJob(ctx context.Context, jobId string) (dto.JobResponse, error)
+		RetryJob(ctx context.Context, jobId string) (dto.JobResponse, error)
+	}
+
+	jobHandlerFunc[T any] func(ctx context.Context, payload T) error
+
+	// permanentJobError marks a failure that retrying cannot fix.
+	permanentJobError struct {
+		err error
+	}
+
+	jobService struct {
+		jobRepo repository.JobRepository
+	}
+)
+
+func NewJobService(jobRepo repository.JobRepository) JobService {
+	return &jobService{
+		jobRepo: jobRepo,
+	}
+}
+
+func JobHandlerName(jobType string) string {
+	return constants.JobHandler + jobType
+}
+
+// NewJobHandler adapts a function taking the decoded payload. A payload
+// that does not decode into T kills the job instead of retrying it.
+func NewJobHandler[T any](fn func(ctx context.Context, payload T) error) JobHandler {
+	return jobHandlerFunc[T](fn)
+}
+
+func (fn jobHandlerFunc[T]) Handle(ctx context.Context, job entity.Job) error {
+	var payload T
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return PermanentJobError(fmt.Errorf("decode %s payload: %w", job.Type, err))
+	}
+	return fn(ctx, payload)
+}
+
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+func IsPermanentJobError(err error) bool {
+	var permanent *permanentJobError
+	return errors.As(err, &permanent)
+}
+
+func (e *permanentJobError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentJobError) Unwrap() error {
+	return e.err
+}
+
+// JobBackoff is the delay before retrying a job that failed its n-th
+// attempt: 30s doubling per attempt, capped at one hour.
+func JobBackoff(attempt int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, time.Hour)
+}
+
+// Enqueue adds a job that is due now. Passing the caller's tx makes the job
+// appear only if that transaction commits.
+func (s *jobService) Enqueue(ctx context.Context, tx *gorm.DB, jobType string, payload any) (entity.Job, error) {
+	return s.EnqueueAt(ctx, tx, jobType, payload, time.Now())
+}
+
+func (s *jobService) EnqueueAt(ctx context.Context, tx *gorm.DB, jobType string, payload any, runAt time.Time) (entity.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return entity.Job{}, err
+	}
+
+	job, err := s.jobRepo.Create(ctx, tx, entity.Job{
+		Type:    jobType,
+		Payload: string(raw),
+		Status:  entity.JobPending,
+		RunAt:   runAt,
+	})
+	if err != nil {
+		return entity.Job{}, dto.ErrEnqueueJob
+	}
+
+	return job, nil
+}
+
+func (s *jobService) GetJobs(ctx context.Context, req dto.JobListRequest) (dto.JobPaginationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.JobPaginationResponse{}, err
+	}
+
+	result, err := s.jobRepo.GetJobs(ctx, nil, req)
+	if err != nil {
+		return dto.JobPaginationResponse{}, dto.ErrGetJobs
+	}
+
+	datas := make([]dto.JobResponse, 0, len(result.Jobs))
+	for _, job := range result.Jobs {
+		datas = append(datas, toJobResponse(job))
+	}
+
+	return dto.JobPaginationResponse{
+		Data:               datas,
+		PaginationResponse: result.PaginationResponse,
+	}, nil
+}
+
+func (s *jobService) GetJob(ctx context.Context, jobId string) (dto.JobResponse, error) {
+	job, err := s.jobRepo.GetById(ctx, nil, jobId)
+	if err != nil {
+		return dto.JobResponse{}, dto.ErrJobNotFound
+	}
+
+	return toJobResponse(job), nil
+}
+
+func (s *jobService) RetryJob(ctx context.Context, jobId string) (dto.JobResponse, error) {
+	if _, err := s.jobRepo.GetById(ctx, nil, jobId); err != nil {
+		return dto.JobResponse{}, dto.ErrJobNotFound
+	}
+
+	job, err := s.jobRepo.Retry(ctx, nil, jobId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.JobResponse{}, dto.ErrJobNotRetryable
+		}
+		return dto.JobResponse{}, err
+	}
+
+	return toJobResponse(job), nil
+}
+
+func toJobResponse(job entity.Job) dto.JobResponse {
+	response := dto.JobResponse{
+		ID:          job.ID.String(),
+		Type:        job.Type,
+		Payload:     job.Payload,
+		Status:      string(job.Status),
+		RunAt:       job.RunAt.Format(time.RFC3339),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LockedBy:    job.LockedBy,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.CompletedAt != nil {
+		response.CompletedAt = job.CompletedAt.Format(time.RFC3339)
+	}
+	return response
+}
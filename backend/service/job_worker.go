@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+)
+
+type (
+	JobWorker interface {
+		// Run claims and executes jobs until ctx is cancelled, then waits for
+		// the jobs in flight.
+		Run(ctx context.Context)
+	}
+
+	jobWorker struct {
+		id       string
+		jobRepo  repository.JobRepository
+		handlers map[string]JobHandler
+		cfg      config.JobConfig
+	}
+)
+
+func NewJobWorker(jobRepo repository.JobRepository, handlers map[string]JobHandler, cfg config.JobConfig) JobWorker {
+	host, _ := os.Hostname()
+	return &jobWorker{
+		id:       fmt.Sprintf("%s-%d", host, os.Getpid()),
+		jobRepo:  jobRepo,
+		handlers: handlers,
+		cfg:      cfg,
+	}
+}
+
+func (w *jobWorker) Run(ctx context.Context) {
+	types := make([]string, 0, len(w.handlers))
+	for jobType := range w.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	if len(types) == 0 {
+		log.Println("job worker: no handlers registered")
+		return
+	}
+	log.Printf("job worker %s: handling %v", w.id, types)
+
+	slots := make(chan struct{}, w.cfg.Concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var lastRelease time.Time
+	for {
+		if time.Since(lastRelease) > time.Minute {
+			if n, err := w.jobRepo.ReleaseStale(ctx, nil, time.Now().Add(-w.cfg.LockTimeout)); err != nil {
+				log.Printf("job worker: release stale jobs: %v", err)
+			} else if n > 0 {
+				log.Printf("job worker: released %d stale jobs", n)
+			}
+			lastRelease = time.Now()
+		}
+
+		free := cap(slots) - len(slots)
+		claimed := 0
+		if free > 0 {
+			jobs, err := w.jobRepo.Claim(ctx, nil, w.id, types, free)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("job worker: claim: %v", err)
+			}
+			claimed = len(jobs)
+
+			for _, job := range jobs {
+				slots <- struct{}{}
+				wg.Add(1)
+				go func(job entity.Job) {
+					defer func() {
+						<-slots
+						wg.Done()
+					}()
+					w.process(ctx, job)
+				}(job)
+			}
+		}
+
+		// a full batch means more work is probably waiting
+		if claimed > 0 && claimed == free {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *jobWorker) process(ctx context.Context, job entity.Job) {
+	// stay inside the lock so no other worker picks the job up meanwhile
+	runCtx, cancel := context.WithTimeout(ctx, w.cfg.LockTimeout)
+	defer cancel()
+
+	err := w.handle(runCtx, job)
+
+	// record the outcome even when shutting down
+	saveCtx := context.WithoutCancel(ctx)
+	if err == nil {
+		if err := w.jobRepo.Complete(saveCtx, nil, job.ID.String()); err != nil {
+			log.Printf("job %s: complete: %v", job.ID, err)
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	if job.Attempts < job.MaxAttempts && !IsPermanentJobError(err) {
+		at := time.Now().Add(JobBackoff(job.Attempts))
+		retryAt = &at
+	}
+
+	log.Printf("job %s (%s) attempt %d/%d failed: %v", job.ID, job.Type, job.Attempts, job.MaxAttempts, err)
+	if err := w.jobRepo.Fail(saveCtx, nil, job, err.Error(), retryAt); err != nil {
+		log.Printf("job %s: record failure: %v", job.ID, err)
+	}
+}
+
+func (w *jobWorker) handle(ctx context.Context, job entity.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return PermanentJobError(fmt.Errorf("no handler for job type %q", job.Type))
+	}
+
+	return handler.Handle(ctx, job)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	NotificationService interface {
+		Notify(ctx context.Context, userId string, msg dto.NotificationMessage) error
+		GetNotifications(ctx context.Context, userId string, req dto.NotificationListRequest) (dto.NotificationPaginationResponse, error)
+		CountUnread(ctx context.Context, userId string) (dto.UnreadNotificationCountResponse, error)
+		MarkRead(ctx context.Context, userId string, notificationId string) (dto.NotificationResponse, error)
+		MarkAllRead(ctx context.Context, userId string) (dto.ReadAllNotificationResponse, error)
+		GetPreferences(ctx context.Context, userId string) (dto.NotificationPreferencesResponse, error)
+		UpdatePreferences(
+			ctx context.Context,
+			userId string,
+			req dto.NotificationPreferencesRequest,
+		) (dto.NotificationPreferencesResponse, error)
+	}
+
+	// NotificationChannel delivers a notification outside the app, e.g. by
+	// email or push. Enabled lets each channel honour its own preference.
+	NotificationChannel interface {
+		Enabled(prefs entity.NotificationPreferences) bool
+		Send(ctx context.Context, user entity.User, notification entity.Notification) error
+	}
+
+	notificationService struct {
+		userRepo         repository.UserRepository
+		notificationRepo repository.NotificationRepository
+		channels         []NotificationChannel
+	}
+)
+
+func NewNotificationService(
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	channels ...NotificationChannel,
+) NotificationService {
+	return &notificationService{
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		channels:         channels,
+	}
+}
+
+// Notify stores the notification in the user's inbox (unless they opted out
+// of in-app notifications) and hands it to every enabled channel. Channel
+// failures are logged, not returned: the inbox is the source of truth.
+func (s *notificationService) Notify(ctx context.Context, userId string, msg dto.NotificationMessage) error {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	notification := entity.Notification{
+		UserID: user.ID,
+		Type:   msg.Type,
+		Title:  msg.Title,
+		Body:   msg.Body,
+	}
+	if reportId, err := uuid.Parse(msg.ReportID); err == nil {
+		notification.ReportID = &reportId
+	}
+
+	if user.NotificationPreferences.InApp {
+		notification, err = s.notificationRepo.Create(ctx, nil, notification)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, channel := range s.channels {
+		if !channel.Enabled(user.NotificationPreferences) {
+			continue
+		}
+		if err := channel.Send(ctx, user, notification); err != nil {
+			log.Printf("send %s notification to %s: %v", msg.Type, user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *notificationService) GetNotifications(
+	ctx context.Context,
+	userId string,
+	req dto.NotificationListRequest,
+) (dto.NotificationPaginationResponse, error) {
+	result, err := s.notificationRepo.GetByUserId(ctx, nil, userId, req)
+	if err != nil {
+		return dto.NotificationPaginationResponse{}, dto.ErrGetNotifications
+	}
+
+	datas := make([]dto.NotificationResponse, 0, len(result.Notifications))
+	for _, notification := range result.Notifications {
+		datas = append(datas, toNotificationResponse(notification))
+	}
+
+	return dto.NotificationPaginationResponse{
+		Data:               datas,
+		PaginationResponse: result.PaginationResponse,
+	}, nil
+}
+
+func (s *notificationService) CountUnread(ctx context.Context, userId string) (dto.UnreadNotificationCountResponse, error) {
+	count, err := s.notificationRepo.CountUnread(ctx, nil, userId)
+	if err != nil {
+		return dto.UnreadNotificationCountResponse{}, dto.ErrGetNotifications
+	}
+
+	return dto.UnreadNotificationCountResponse{Unread: count}, nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, userId string, notificationId string) (dto.NotificationResponse, error) {
+	notification, err := s.notificationRepo.MarkRead(ctx, nil, userId, notificationId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.NotificationResponse{}, dto.ErrNotificationNotFound
+		}
+		return dto.NotificationResponse{}, dto.ErrReadNotification
+	}
+
+	return toNotificationResponse(notification), nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userId string) (dto.ReadAllNotificationResponse, error) {
+	updated, err := s.notificationRepo.MarkAllRead(ctx, nil, userId)
+	if err != nil {
+		return dto.ReadAllNotificationResponse{}, dto.ErrReadNotification
+	}
+
+	return dto.ReadAllNotificationResponse{Updated: updated}, nil
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, userId string) (dto.NotificationPreferencesResponse, error) {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.NotificationPreferencesResponse{}, dto.ErrUserNotFound
+	}
+
+	return toNotificationPreferencesResponse(user.NotificationPreferences), nil
+}
+
+func (s *notificationService) UpdatePreferences(
+	ctx context.Context,
+	userId string,
+	req dto.NotificationPreferencesRequest,
+) (dto.NotificationPreferencesResponse, error) {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.NotificationPreferencesResponse{}, dto.ErrUserNotFound
+	}
+
+	prefs := user.NotificationPreferences
+	if req.InApp != nil {
+		prefs.InApp = *req.InApp
+	}
+	if req.Email != nil {
+		prefs.Email = *req.Email
+	}
+	if req.Push != nil {
+		prefs.Push = *req.Push
+	}
+
+	if err := s.userRepo.UpdateNotificationPreferences(ctx, nil, userId, prefs); err != nil {
+		return dto.NotificationPreferencesResponse{}, dto.ErrUpdateNotificationPreference
+	}
+
+	return toNotificationPreferencesResponse(prefs), nil
+}
+
+func toNotificationResponse(notification entity.Notification) dto.NotificationResponse {
+	data := dto.NotificationResponse{
+		ID:        notification.ID.String(),
+		Type:      string(notification.Type),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		IsRead:    notification.ReadAt != nil,
+		CreatedAt: notification.CreatedAt.Format(time.RFC3339),
+	}
+	if notification.ReportID != nil {
+		data.ReportID = notification.ReportID.String()
+	}
+	if notification.ReadAt != nil {
+		data.ReadAt = notification.ReadAt.Format(time.RFC3339)
+	}
+	return data
+}
+
+func toNotificationPreferencesResponse(prefs entity.NotificationPreferences) dto.NotificationPreferencesResponse {
+	return dto.NotificationPreferencesResponse{
+		InApp: prefs.InApp,
+		Email: prefs.Email,
+		Push:  prefs.Push,
+	}
+}
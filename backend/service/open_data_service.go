@@ -0,0 +1,311 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+)
+
+type (
+	OpenDataService interface {
+		GenerateSnapshot(ctx context.Context) (dto.OpenDataSnapshotResponse, error)
+		ListSnapshots(ctx context.Context) ([]dto.OpenDataSnapshotResponse, error)
+		GetSnapshotFile(ctx context.Context, version string, file string) ([]byte, error)
+	}
+
+	openDataService struct {
+		reportRepo repository.ReportRepository
+		fileRepo   repository.StoredFileRepository
+		config     config.OpenDataConfig
+	}
+)
+
+func NewOpenDataService(
+	reportRepo repository.ReportRepository,
+	fileRepo repository.StoredFileRepository,
+	cfg config.OpenDataConfig,
+) OpenDataService {
+	return &openDataService{
+		reportRepo: reportRepo,
+		fileRepo:   fileRepo,
+		config:     cfg,
+	}
+}
+
+// Only reports a human has accepted are published.
+var openDataStatuses = []string{
+	string(entity.StatusVerified),
+	string(entity.StatusHandled),
+	string(entity.StatusCompleted),
+}
+
+var openDataFiles = []string{
+	dto.OPEN_DATA_FILE_CSV,
+	dto.OPEN_DATA_FILE_JSON,
+	dto.OPEN_DATA_FILE_SCHEMA,
+	dto.OPEN_DATA_FILE_METADATA,
+}
+
+const (
+	openDataVersionLayout = "2006-01-02"
+	// Snapshot files are stored under open-data/<version>/<file>.
+	openDataFilePrefix = "open-data/"
+)
+
+func openDataFileKey(version string, file string) string {
+	return openDataFilePrefix + version + "/" + file
+}
+
+type openDataCell struct {
+	class string
+	lat   float64
+	lng   float64
+	known bool
+}
+
+func (s *openDataService) cell(report entity.Report) openDataCell {
+	cell := openDataCell{class: reportClass(report)}
+	if lat, lng, ok := report.Coordinates(); ok {
+		g := s.config.GridDegrees
+		// snap to the centre of the grid cell, rounded to hide the original precision
+		cell.lat = math.Round((math.Floor(lat/g)*g+g/2)*1e6) / 1e6
+		cell.lng = math.Round((math.Floor(lng/g)*g+g/2)*1e6) / 1e6
+		cell.known = true
+	}
+	return cell
+}
+
+func (s *openDataService) schema() dto.TableSchema {
+	fields := []dto.TableSchemaField{
+		{Name: "created_date", Type: "date", Format: "%Y-%m-%d", Description: "Tanggal laporan dibuat (Asia/Jakarta)"},
+		{Name: "status", Type: "string", Description: "Status laporan: verified, handled atau completed"},
+		{Name: "class", Type: "string", Description: "Kelas masalah hasil inferensi"},
+		{Name: "grid_lat", Type: "number", Description: fmt.Sprintf("Lintang titik tengah sel grid %.4f derajat", s.config.GridDegrees)},
+		{Name: "grid_lng", Type: "number", Description: fmt.Sprintf("Bujur titik tengah sel grid %.4f derajat", s.config.GridDegrees)},
+		{Name: "upvotes", Type: "integer", Description: "Jumlah dukungan warga"},
+	}
+	if s.config.IncludeText {
+		fields = append(fields, dto.TableSchemaField{Name: "text", Type: "string", Description: "Isi laporan"})
+	}
+	return dto.TableSchema{Fields: fields, MissingValue: []string{""}}
+}
+
+// GenerateSnapshot writes today's snapshot. Reports are streamed twice:
+// first to count reports per (class, grid cell), then to write every
+// report whose group reaches the k threshold.
+func (s *openDataService) GenerateSnapshot(ctx context.Context) (dto.OpenDataSnapshotResponse, error) {
+	filter := dto.ReportFilterRequest{Status: openDataStatuses}
+
+	groups := map[openDataCell]int{}
+	err := s.reportRepo.StreamReports(ctx, nil, filter, dto.EXPORT_BATCH_SIZE, func(reports []entity.Report) error {
+		for _, report := range reports {
+			groups[s.cell(report)]++
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.OpenDataSnapshotResponse{}, dto.ErrGenerateOpenData
+	}
+
+	var csvBuf, jsonBuf bytes.Buffer
+	snapshot, err := s.writeSnapshot(ctx, &csvBuf, &jsonBuf, filter, groups)
+	if err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+
+	now := time.Now().In(utils.JakartaLocation())
+	version := now.Format(openDataVersionLayout)
+	snapshot.Version = version
+	snapshot.GeneratedAt = now.Format(time.RFC3339)
+	snapshot.Files = s.snapshotFiles(version)
+
+	schema, err := json.MarshalIndent(s.schema(), "", "  ")
+	if err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+	metadata, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+
+	// all files go in one statement so every replica sees either the old
+	// snapshot or the new one, never a mix
+	err = s.fileRepo.SaveAll(ctx, nil, []entity.StoredFile{
+		{Key: openDataFileKey(version, dto.OPEN_DATA_FILE_CSV), Content: csvBuf.Bytes()},
+		{Key: openDataFileKey(version, dto.OPEN_DATA_FILE_JSON), Content: jsonBuf.Bytes()},
+		{Key: openDataFileKey(version, dto.OPEN_DATA_FILE_SCHEMA), Content: schema},
+		{Key: openDataFileKey(version, dto.OPEN_DATA_FILE_METADATA), Content: metadata},
+	})
+	if err != nil {
+		return dto.OpenDataSnapshotResponse{}, dto.ErrGenerateOpenData
+	}
+
+	return snapshot, nil
+}
+
+func (s *openDataService) writeSnapshot(
+	ctx context.Context,
+	csvFile *bytes.Buffer,
+	jsonFile *bytes.Buffer,
+	filter dto.ReportFilterRequest,
+	groups map[openDataCell]int,
+) (dto.OpenDataSnapshotResponse, error) {
+	schema := s.schema()
+	csvWriter, err := utils.NewRowWriter(utils.SPREADSHEET_CSV, csvFile)
+	if err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+	header := make([]any, len(schema.Fields))
+	for i, field := range schema.Fields {
+		header[i] = field.Name
+	}
+	if err := csvWriter.Write(header); err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+
+	if _, err := jsonFile.WriteString("["); err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+
+	snapshot := dto.OpenDataSnapshotResponse{
+		GridDegrees:  s.config.GridDegrees,
+		K:            s.config.K,
+		IncludesText: s.config.IncludeText,
+	}
+	loc := utils.JakartaLocation()
+
+	err = s.reportRepo.StreamReports(ctx, nil, filter, dto.EXPORT_BATCH_SIZE, func(reports []entity.Report) error {
+		for _, report := range reports {
+			cell := s.cell(report)
+			if groups[cell] < s.config.K {
+				snapshot.SuppressedCount++
+				continue
+			}
+
+			record := dto.OpenDataRecord{
+				CreatedDate: report.CreatedAt.In(loc).Format(openDataVersionLayout),
+				Status:      string(report.Status),
+				Class:       cell.class,
+				Upvotes:     report.Upvotes,
+			}
+			row := []any{record.CreatedDate, record.Status, record.Class, "", "", record.Upvotes}
+			if cell.known {
+				record.GridLat, record.GridLng = &cell.lat, &cell.lng
+				row[3], row[4] = cell.lat, cell.lng
+			}
+			if s.config.IncludeText {
+				text := report.Text
+				record.Text = &text
+				row = append(row, text)
+			}
+
+			if err := csvWriter.Write(row); err != nil {
+				return err
+			}
+
+			raw, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if snapshot.RecordCount > 0 {
+				if _, err := jsonFile.WriteString(","); err != nil {
+					return err
+				}
+			}
+			if _, err := jsonFile.Write(raw); err != nil {
+				return err
+			}
+			snapshot.RecordCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.OpenDataSnapshotResponse{}, dto.ErrGenerateOpenData
+	}
+
+	if _, err := jsonFile.WriteString("]"); err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+	if err := csvWriter.Close(); err != nil {
+		return dto.OpenDataSnapshotResponse{}, err
+	}
+
+	return snapshot, nil
+}
+
+func (s *openDataService) snapshotFiles(version string) map[string]string {
+	files := make(map[string]string, len(openDataFiles))
+	for _, file := range openDataFiles {
+		files[file] = fmt.Sprintf("/api/open-data/snapshots/%s/%s", version, file)
+	}
+	return files
+}
+
+func (s *openDataService) ListSnapshots(ctx context.Context) ([]dto.OpenDataSnapshotResponse, error) {
+	files, err := s.fileRepo.Find(ctx, nil, openDataFileKey("%", dto.OPEN_DATA_FILE_METADATA))
+	if err != nil {
+		return nil, dto.ErrGetOpenDataSnapshots
+	}
+
+	snapshots := []dto.OpenDataSnapshotResponse{}
+	for _, file := range files {
+		version, _, _ := strings.Cut(strings.TrimPrefix(file.Key, openDataFilePrefix), "/")
+		if _, err := time.Parse(openDataVersionLayout, version); err != nil {
+			continue
+		}
+
+		var snapshot dto.OpenDataSnapshotResponse
+		if err := json.Unmarshal(file.Content, &snapshot); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Version > snapshots[j].Version
+	})
+
+	return snapshots, nil
+}
+
+func (s *openDataService) GetSnapshotFile(ctx context.Context, version string, file string) ([]byte, error) {
+	if !slices.Contains(openDataFiles, file) {
+		return nil, dto.ErrInvalidOpenDataFile
+	}
+
+	if version == dto.OPEN_DATA_LATEST {
+		snapshots, err := s.ListSnapshots(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(snapshots) == 0 {
+			return nil, dto.ErrOpenDataNotFound
+		}
+		version = snapshots[0].Version
+	}
+
+	// the version must be a plain date, which also keeps other keys out of
+	// reach
+	if _, err := time.Parse(openDataVersionLayout, version); err != nil {
+		return nil, dto.ErrOpenDataNotFound
+	}
+
+	stored, err := s.fileRepo.Get(ctx, nil, openDataFileKey(version, file))
+	if err != nil {
+		return nil, dto.ErrOpenDataNotFound
+	}
+
+	return stored.Content, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+)
+
+// pushNotificationChannel fans a notification out to every device of the
+// user, one JOB_SEND_PUSH job per device so a failing device is retried on
+// its own.
+type pushNotificationChannel struct {
+	deviceRepo repository.DeviceTokenRepository
+	jobService JobService
+}
+
+func NewPushNotificationChannel(deviceRepo repository.DeviceTokenRepository, jobService JobService) NotificationChannel {
+	return &pushNotificationChannel{
+		deviceRepo: deviceRepo,
+		jobService: jobService,
+	}
+}
+
+func (c *pushNotificationChannel) Enabled(prefs entity.NotificationPreferences) bool {
+	return prefs.Push
+}
+
+func (c *pushNotificationChannel) Send(ctx context.Context, user entity.User, notification entity.Notification) error {
+	devices, err := c.deviceRepo.GetByUserId(ctx, nil, user.ID.String())
+	if err != nil {
+		return err
+	}
+
+	data := map[string]string{"type": string(notification.Type)}
+	if notification.ID != uuid.Nil {
+		data["notification_id"] = notification.ID.String()
+	}
+	if notification.ReportID != nil {
+		data["report_id"] = notification.ReportID.String()
+	}
+
+	for _, device := range devices {
+		if _, err := c.jobService.Enqueue(ctx, nil, dto.JOB_SEND_PUSH, dto.SendPushJob{
+			Token: device.Token,
+			Title: notification.Title,
+			Body:  notification.Body,
+			Data:  data,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
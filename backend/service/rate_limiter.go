@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"gorm.io/gorm"
+)
+
+type (
+	RateLimiter interface {
+		// Allow takes a token from each bucket that policy keeps for keys,
+		// or from none of them when any one is empty, and reports the
+		// tightest bucket. Unknown policies, and all of them while rate
+		// limiting is disabled, let everything through with a zero Limit.
+		Allow(ctx context.Context, policy string, keys ...string) (dto.RateLimitResult, error)
+		// PurgeIdleBuckets forgets buckets that have refilled completely.
+		PurgeIdleBuckets(ctx context.Context) error
+	}
+
+	memoryRateLimiter struct {
+		cfg       config.RateLimitConfig
+		mu        sync.Mutex
+		buckets   map[string]memoryBucket
+		lastPurge time.Time
+	}
+
+	memoryBucket struct {
+		dto.TokenBucket
+		period time.Duration
+	}
+
+	postgresRateLimiter struct {
+		cfg  config.RateLimitConfig
+		repo repository.RateLimitRepository
+		db   *gorm.DB
+	}
+)
+
+// memoryPurgeInterval is how often the in-memory limiter sweeps out idle
+// buckets while serving requests.
+const memoryPurgeInterval = time.Minute
+
+// NewRateLimiter builds the limiter selected by cfg.Backend.
+func NewRateLimiter(cfg config.RateLimitConfig, repo repository.RateLimitRepository, db *gorm.DB) RateLimiter {
+	if cfg.Backend == config.RATE_LIMIT_BACKEND_POSTGRES {
+		return &postgresRateLimiter{
+			cfg:  cfg,
+			repo: repo,
+			db:   db,
+		}
+	}
+
+	return &memoryRateLimiter{
+		cfg:     cfg,
+		buckets: make(map[string]memoryBucket),
+	}
+}
+
+// TakeToken refills bucket for the time since it was last touched and
+// takes one token from it if it has one. A zero bucket is a new, full one.
+func TakeToken(bucket dto.TokenBucket, policy config.RateLimitPolicy, now time.Time) (dto.TokenBucket, dto.RateLimitResult) {
+	limit := float64(policy.Limit)
+	perSecond := limit / policy.Period.Seconds()
+
+	tokens := limit
+	if !bucket.UpdatedAt.IsZero() {
+		elapsed := math.Max(now.Sub(bucket.UpdatedAt).Seconds(), 0)
+		tokens = math.Min(limit, bucket.Tokens+elapsed*perSecond)
+	}
+
+	result := dto.RateLimitResult{Limit: policy.Limit}
+	// tolerate float error so a bucket that has just refilled one token
+	// is not refused
+	if tokens >= 1-1e-9 {
+		tokens = math.Max(tokens-1, 0)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / perSecond)
+	}
+	result.Remaining = int(math.Floor(tokens + 1e-9))
+	result.Reset = seconds((limit - tokens) / perSecond)
+
+	return dto.TokenBucket{Tokens: tokens, UpdatedAt: now}, result
+}
+
+// tightest picks the result to report for a request that needed a token
+// from several buckets: the first refusal, else the one with fewest left.
+func tightest(results []dto.RateLimitResult) dto.RateLimitResult {
+	var tightest dto.RateLimitResult
+	for i, result := range results {
+		if !result.Allowed {
+			return result
+		}
+		if i == 0 || result.Remaining < tightest.Remaining {
+			tightest = result
+		}
+	}
+	return tightest
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func (l *memoryRateLimiter) Allow(ctx context.Context, policy string, keys ...string) (dto.RateLimitResult, error) {
+	p, ok := l.cfg.Policies[policy]
+	if !l.cfg.Enabled || !ok {
+		return dto.RateLimitResult{Allowed: true}, nil
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPurge) >= memoryPurgeInterval {
+		l.purge(now)
+	}
+
+	buckets := make([]dto.TokenBucket, len(keys))
+	results := make([]dto.RateLimitResult, len(keys))
+	for i, key := range keys {
+		buckets[i], results[i] = TakeToken(l.buckets[policy+":"+key].TokenBucket, p, now)
+	}
+
+	result := tightest(results)
+	if result.Allowed {
+		for i, key := range keys {
+			l.buckets[policy+":"+key] = memoryBucket{TokenBucket: buckets[i], period: p.Period}
+		}
+	}
+
+	return result, nil
+}
+
+func (l *memoryRateLimiter) PurgeIdleBuckets(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.purge(time.Now())
+	return nil
+}
+
+// purge must be called with l.mu held.
+func (l *memoryRateLimiter) purge(now time.Time) {
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.UpdatedAt) >= bucket.period {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPurge = now
+}
+
+func (l *postgresRateLimiter) Allow(ctx context.Context, policy string, keys ...string) (dto.RateLimitResult, error) {
+	p, ok := l.cfg.Policies[policy]
+	if !l.cfg.Enabled || !ok {
+		return dto.RateLimitResult{Allowed: true}, nil
+	}
+
+	// lock the rows in a fixed order so two requests sharing buckets
+	// cannot deadlock
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+
+	now := time.Now()
+	tx := l.db.Begin()
+	defer SafeRollback(tx)
+
+	stored := make([]entity.RateLimitBucket, len(keys))
+	results := make([]dto.RateLimitResult, len(keys))
+	for i, key := range keys {
+		bucket, err := l.repo.GetBucketForUpdate(ctx, tx, entity.RateLimitBucket{
+			Key:       policy + ":" + key,
+			Tokens:    float64(p.Limit),
+			UpdatedAt: now,
+		})
+		if err != nil {
+			tx.Rollback()
+			return dto.RateLimitResult{}, err
+		}
+
+		var taken dto.TokenBucket
+		taken, results[i] = TakeToken(dto.TokenBucket{Tokens: bucket.Tokens, UpdatedAt: bucket.UpdatedAt}, p, now)
+		bucket.Tokens = taken.Tokens
+		bucket.UpdatedAt = taken.UpdatedAt
+		stored[i] = bucket
+	}
+
+	result := tightest(results)
+	if !result.Allowed {
+		tx.Rollback()
+		return result, nil
+	}
+
+	for _, bucket := range stored {
+		if err := l.repo.SaveBucket(ctx, tx, bucket); err != nil {
+			tx.Rollback()
+			return dto.RateLimitResult{}, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.RateLimitResult{}, err
+	}
+
+	return result, nil
+}
+
+// PurgeIdleBuckets only knows a bucket's age, not its policy, so it waits
+// for the longest period before deleting any.
+func (l *postgresRateLimiter) PurgeIdleBuckets(ctx context.Context) error {
+	var longest time.Duration
+	for _, p := range l.cfg.Policies {
+		longest = max(longest, p.Period)
+	}
+
+	return l.repo.DeleteIdleBuckets(ctx, nil, time.Now().Add(-longest))
+}
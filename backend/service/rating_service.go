@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	RatingService interface {
+		RateReport(ctx context.Context, reportId string, req dto.RateReportRequest, userId string) (dto.RatingResponse, error)
+		GetRating(ctx context.Context, reportId string, userId string) (dto.RatingResponse, error)
+		// SendReminders is the TASK_RATING_REMINDERS scheduled task.
+		SendReminders(ctx context.Context) error
+	}
+
+	ratingService struct {
+		ratingRepo          repository.ReportRatingRepository
+		reportRepo          repository.ReportRepository
+		historyRepo         repository.ReportHistoryRepository
+		userRepo            repository.UserRepository
+		notificationService NotificationService
+		db                  *gorm.DB
+	}
+)
+
+func NewRatingService(
+	ratingRepo repository.ReportRatingRepository,
+	reportRepo repository.ReportRepository,
+	historyRepo repository.ReportHistoryRepository,
+	userRepo repository.UserRepository,
+	notificationService NotificationService,
+	db *gorm.DB,
+) RatingService {
+	return &ratingService{
+		ratingRepo:          ratingRepo,
+		reportRepo:          reportRepo,
+		historyRepo:         historyRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		db:                  db,
+	}
+}
+
+func (s *ratingService) RateReport(ctx context.Context, reportId string, req dto.RateReportRequest, userId string) (dto.RatingResponse, error) {
+	if req.Score < dto.RATING_MIN_SCORE || req.Score > dto.RATING_MAX_SCORE {
+		return dto.RatingResponse{}, dto.ErrRateReport
+	}
+
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return dto.RatingResponse{}, dto.ErrUserNotFound
+	}
+
+	report, err := s.reportRepo.GetReportById(ctx, nil, reportId)
+	if err != nil {
+		return dto.RatingResponse{}, dto.ErrGetReportById
+	}
+	if report.UserID != userId {
+		return dto.RatingResponse{}, dto.ErrNotReportReporter
+	}
+	if report.Status != entity.StatusCompleted {
+		return dto.RatingResponse{}, dto.ErrReportNotCompleted
+	}
+	if _, err := s.ratingRepo.GetByReportId(ctx, nil, reportId); err == nil {
+		return dto.RatingResponse{}, dto.ErrReportAlreadyRated
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	// the unique index on report_id settles a race between two requests
+	rating, err := s.ratingRepo.Create(ctx, tx, entity.ReportRating{
+		ReportID: report.ID,
+		UserID:   userUUID,
+		Score:    req.Score,
+		Comment:  strings.TrimSpace(req.Comment),
+	})
+	if err != nil {
+		tx.Rollback()
+		return dto.RatingResponse{}, dto.ErrRateReport
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryRated,
+		ActorID:  &userUUID,
+		Note:     fmt.Sprintf("%d/%d", rating.Score, dto.RATING_MAX_SCORE),
+	}); err != nil {
+		tx.Rollback()
+		return dto.RatingResponse{}, dto.ErrRateReport
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.RatingResponse{}, dto.ErrRateReport
+	}
+
+	return toRatingResponse(rating), nil
+}
+
+// GetRating is visible to the reporter and to admins.
+func (s *ratingService) GetRating(ctx context.Context, reportId string, userId string) (dto.RatingResponse, error) {
+	rating, err := s.ratingRepo.GetByReportId(ctx, nil, reportId)
+	if err != nil {
+		return dto.RatingResponse{}, dto.ErrRatingNotFound
+	}
+
+	if rating.UserID.String() != userId {
+		user, err := s.userRepo.GetUserById(ctx, nil, userId)
+		if err != nil || user.Role != constants.ENUM_ROLE_ADMIN {
+			return dto.RatingResponse{}, dto.ErrRatingNotFound
+		}
+	}
+
+	return toRatingResponse(rating), nil
+}
+
+// SendReminders nudges every reporter who has not rated a report completed
+// more than RATING_REMINDER_DELAY ago. Each report is reminded once.
+func (s *ratingService) SendReminders(ctx context.Context) error {
+	now := time.Now()
+	before := now.Add(-dto.RATING_REMINDER_DELAY)
+
+	for {
+		reports, err := s.reportRepo.GetUnratedCompleted(ctx, nil, before, dto.RATING_REMINDER_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, report := range reports {
+			marked, err := s.reportRepo.MarkRatingReminded(ctx, nil, report.ID.String(), now)
+			if err != nil {
+				return err
+			}
+			if !marked {
+				continue
+			}
+
+			if err := s.notificationService.Notify(ctx, report.UserID, dto.NotificationMessage{
+				Type:     entity.NotificationRatingReminder,
+				Title:    "Bagaimana penanganan laporan Anda?",
+				Body:     "Beri penilaian 1 sampai 5 untuk penyelesaian laporan Anda.",
+				ReportID: report.ID.String(),
+			}); err != nil {
+				log.Printf("rating reminder for report %s: %v", report.ID, err)
+			}
+		}
+
+		if len(reports) < dto.RATING_REMINDER_BATCH_SIZE {
+			return nil
+		}
+	}
+}
+
+func toRatingResponse(rating entity.ReportRating) dto.RatingResponse {
+	return dto.RatingResponse{
+		ID:        rating.ID.String(),
+		ReportID:  rating.ReportID.String(),
+		Score:     rating.Score,
+		Comment:   rating.Comment,
+		CreatedAt: rating.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+)
+
+type (
+	RejectionReasonService interface {
+		// GetReasons lists the active codes, or every code for admins.
+		GetReasons(ctx context.Context, lang string, includeInactive bool) ([]dto.RejectionReasonResponse, error)
+		SaveReason(ctx context.Context, req dto.SaveRejectionReasonRequest, lang string) (dto.RejectionReasonResponse, error)
+		DeactivateReason(ctx context.Context, code string) error
+	}
+
+	rejectionReasonService struct {
+		reasonRepo repository.RejectionReasonRepository
+	}
+)
+
+func NewRejectionReasonService(reasonRepo repository.RejectionReasonRepository) RejectionReasonService {
+	return &rejectionReasonService{
+		reasonRepo: reasonRepo,
+	}
+}
+
+var rejectionCodePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+func (s *rejectionReasonService) GetReasons(ctx context.Context, lang string, includeInactive bool) ([]dto.RejectionReasonResponse, error) {
+	reasons, err := s.reasonRepo.GetAll(ctx, nil, !includeInactive)
+	if err != nil {
+		return nil, dto.ErrGetRejectionReasons
+	}
+
+	datas := make([]dto.RejectionReasonResponse, 0, len(reasons))
+	for _, reason := range reasons {
+		datas = append(datas, toRejectionReasonResponse(reason, lang))
+	}
+
+	return datas, nil
+}
+
+func (s *rejectionReasonService) SaveReason(ctx context.Context, req dto.SaveRejectionReasonRequest, lang string) (dto.RejectionReasonResponse, error) {
+	code := strings.TrimSpace(req.Code)
+	if !rejectionCodePattern.MatchString(code) {
+		return dto.RejectionReasonResponse{}, dto.ErrInvalidRejectionCode
+	}
+
+	reason, err := s.reasonRepo.Save(ctx, nil, entity.RejectionReason{
+		Code:          code,
+		DescriptionID: strings.TrimSpace(req.DescriptionID),
+		DescriptionEN: strings.TrimSpace(req.DescriptionEN),
+		Spam:          req.Spam,
+	})
+	if err != nil {
+		return dto.RejectionReasonResponse{}, dto.ErrSaveRejectionReason
+	}
+
+	return toRejectionReasonResponse(reason, lang), nil
+}
+
+func (s *rejectionReasonService) DeactivateReason(ctx context.Context, code string) error {
+	updated, err := s.reasonRepo.Deactivate(ctx, nil, code)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return dto.ErrRejectionReasonNotFound
+	}
+
+	return nil
+}
+
+func toRejectionReasonResponse(reason entity.RejectionReason, lang string) dto.RejectionReasonResponse {
+	return dto.RejectionReasonResponse{
+		Code:          reason.Code,
+		Description:   reason.Description(lang),
+		DescriptionID: reason.DescriptionID,
+		DescriptionEN: reason.DescriptionEN,
+		Active:        reason.Active,
+		Spam:          reason.Spam,
+	}
+}
@@ -3,12 +3,17 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
+	"mime/multipart"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"gorm.io/gorm"
 
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
 	"github.com/Caknoooo/go-gin-clean-starter/dto"
 	"github.com/Caknoooo/go-gin-clean-starter/entity"
 	"github.com/Caknoooo/go-gin-clean-starter/repository"
@@ -18,31 +23,43 @@ import (
 type (
 	ReportService interface {
 		CreateReport(ctx context.Context, req dto.CreateReportRequest) (dto.CreateReportResponse, error)
-		GetAllReports(ctx context.Context, req dto.PaginationRequest) (dto.ReportPaginationResponse, error)
-		GetReportById(ctx context.Context, reportId string) (dto.ReportResponse, error)
-		GetReportsByUserId(ctx context.Context, userId string, req dto.PaginationRequest) (dto.ReportPaginationResponse, error)
-		UpdateReportStatus(ctx context.Context, reportId string, status entity.ReportStatus) (dto.UpdateStatusReportResponse, error)
-		CountReportStatus(ctx context.Context) (dto.CountReportResponse, error)
-		GetReportsByStatus(ctx context.Context, status string, req dto.PaginationRequest) (dto.ReportPaginationResponse, error)
+		GetReports(ctx context.Context, req dto.ReportFilterRequest) (dto.ReportPaginationResponse, error)
+		// GetReportById hides held reports from everyone but their reporter
+		// and the admins.
+		GetReportById(ctx context.Context, reportId string, viewerId string) (dto.ReportResponse, error)
+		UpdateReportStatus(ctx context.Context, reportId string, req dto.UpdateStatusReportRequest, actorId string) (dto.UpdateStatusReportResponse, error)
+		GetCompletion(ctx context.Context, reportId string) (dto.CompletionEvidenceResponse, error)
+		ConfirmCompletion(ctx context.Context, reportId string, userId string) (dto.CompletionEvidenceResponse, error)
+		DisputeCompletion(ctx context.Context, reportId string, req dto.DisputeCompletionRequest, userId string) (dto.CompletionEvidenceResponse, error)
+		// ExpireCompletions is the TASK_EXPIRE_COMPLETIONS scheduled task.
+		ExpireCompletions(ctx context.Context) error
+		GetReportHistory(ctx context.Context, reportId string, viewerId string) ([]dto.ReportHistoryResponse, error)
+		CountReportStatus(ctx context.Context, agencyIds []string) (dto.CountReportResponse, error)
 		InferenceStatus(ctx context.Context, req dto.InferenceRequest, token string) (dto.InferenceResponse, error)
 	}
 
 	reportService struct {
-		userRepo   repository.UserRepository
-		reportRepo repository.ReportRepository
-		db         *gorm.DB
+		*ReportWorkflow
+		agencyRepo   repository.AgencyRepository
+		evidenceRepo repository.CompletionEvidenceRepository
+		reasonRepo   repository.RejectionReasonRepository
+		trustService TrustService
 	}
 )
 
 func NewReportService(
-	userRepo repository.UserRepository,
-	reportRepo repository.ReportRepository,
-	db *gorm.DB,
+	workflow *ReportWorkflow,
+	agencyRepo repository.AgencyRepository,
+	evidenceRepo repository.CompletionEvidenceRepository,
+	reasonRepo repository.RejectionReasonRepository,
+	trustService TrustService,
 ) ReportService {
 	return &reportService{
-		userRepo:   userRepo,
-		reportRepo: reportRepo,
-		db:         db,
+		ReportWorkflow: workflow,
+		agencyRepo:     agencyRepo,
+		evidenceRepo:   evidenceRepo,
+		reasonRepo:     reasonRepo,
+		trustService:   trustService,
 	}
 }
 
@@ -64,12 +81,32 @@ func (s *reportService) CreateReport(ctx context.Context, req dto.CreateReportRe
 		return dto.CreateReportResponse{}, dto.ErrEmptyContent
 	}
 
+	if (req.Latitude == nil) != (req.Longitude == nil) ||
+		(req.Latitude != nil && !utils.ValidLatLng(*req.Latitude, *req.Longitude)) {
+		return dto.CreateReportResponse{}, dto.ErrInvalidCoordinates
+	}
+
 	// Validate user exists
 	_, err := s.userRepo.GetUserById(ctx, nil, user_id)
 	if err != nil {
 		return dto.CreateReportResponse{}, dto.ErrUserNotFound
 	}
 
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	// Throttle by trust; low-trust reports wait for an admin. The report
+	// is filed in the transaction that checked the quota
+	policy, err := s.trustService.CheckReportQuota(ctx, tx, user_id)
+	if err != nil {
+		tx.Rollback()
+		return dto.CreateReportResponse{}, err
+	}
+	status := entity.StatusUnverified
+	if policy.HoldForReview {
+		status = entity.StatusPendingReview
+	}
+
 	reportID := uuid.New()
 	var imagePath string
 
@@ -78,95 +115,108 @@ func (s *reportService) CreateReport(ctx context.Context, req dto.CreateReportRe
 		ext := utils.GetExtensions(req.Image.Filename)
 		imagePath = fmt.Sprintf("reports/%s.%s", reportID, ext)
 		if err := utils.UploadFile(req.Image, imagePath); err != nil {
+			tx.Rollback()
 			return dto.CreateReportResponse{}, err
 		}
 	}
 
 	// Create report entity (prepared for database insertion)
 	report := entity.Report{
-		ID:       reportID,
-		Text:     req.Text,
-		Image:    imagePath,
-		UserID:   user_id,
-		Status:   entity.StatusUnverified,
-		Location: req.Location,
-		TagID:    uuid.Nil,
+		ID:        reportID,
+		Text:      req.Text,
+		Image:     imagePath,
+		UserID:    user_id,
+		Status:    status,
+		Location:  req.Location,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		TagID:     uuid.Nil,
 	}
 
-	createdReport, err := s.reportRepo.CreateReport(ctx, nil, report)
+	createdReport, err := s.reportRepo.CreateReport(ctx, tx, report)
 	if err != nil {
+		tx.Rollback()
 		if imagePath != "" {
 			os.Remove(imagePath)
 		}
 		return dto.CreateReportResponse{}, dto.ErrCreateReport
 	}
 
-	return dto.CreateReportResponse{
-		ID:       createdReport.ID.String(),
-		Text:     createdReport.Text,
-		Image:    createdReport.Image,
-		Location: createdReport.Location,
-	}, nil
-}
+	if err := tx.Commit().Error; err != nil {
+		if imagePath != "" {
+			os.Remove(imagePath)
+		}
+		return dto.CreateReportResponse{}, dto.ErrCreateReport
+	}
 
-func (s *reportService) GetAllReports(ctx context.Context, req dto.PaginationRequest) (dto.ReportPaginationResponse, error) {
-	reports, err := s.reportRepo.GetAllReportsWithPagination(ctx, nil, req)
-	if err != nil {
-		return dto.ReportPaginationResponse{}, dto.ErrGetReports
-	}
-
-	var datas []dto.ReportResponse
-	for _, report := range reports.Reports {
-		data := dto.ReportResponse{
-			ID:         report.ID.String(),
-			Text:       report.Text,
-			Image:      report.Image,
-			Location:   report.Location,
-			Status:     fmt.Sprintf("%v", report.Status),
-			Upvotes:    report.Upvotes,
-			ShareCount: report.ShareCount,
-			UserID:     report.UserID,
-			Username:   report.User.Name, // Gunakan preloaded User
-			TagID: func() string {
-				if report.TagID == uuid.Nil {
-					return ""
-				}
-				return report.TagID.String()
-			}(),
-			PredConfidence: func() int {
-				if report.PredConfidence != nil {
-					return *report.PredConfidence
-				}
-				return 0
-			}(),
-			User: report.User, // Tambahkan nested object
-			Tag:  report.Tag,  // Tambahkan nested object
+	response := dto.CreateReportResponse{
+		ID:        createdReport.ID.String(),
+		Text:      createdReport.Text,
+		Image:     createdReport.Image,
+		Location:  createdReport.Location,
+		Latitude:  createdReport.Latitude,
+		Longitude: createdReport.Longitude,
+		Status:    string(createdReport.Status),
+	}
+
+	// held reports stay quiet until they are released
+	if createdReport.Status == entity.StatusPendingReview {
+		if _, err := s.historyRepo.Create(ctx, nil, entity.ReportHistory{
+			ReportID: createdReport.ID,
+			Event:    entity.HistoryHeldForReview,
+			Note:     "Pelapor dengan tingkat kepercayaan rendah",
+		}); err != nil {
+			log.Printf("record hold of report %s: %v", createdReport.ID, err)
 		}
-		datas = append(datas, data)
+		return response, nil
 	}
 
-	return dto.ReportPaginationResponse{
-		Data: datas,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    reports.Page,
-			PerPage: reports.PerPage,
-			MaxPage: reports.MaxPage,
-			Count:   reports.Count,
-		},
-	}, nil
+	if err := s.applySLA(ctx, nil, createdReport, createdReport.Status, time.Now()); err != nil {
+		log.Printf("apply sla to report %s: %v", createdReport.ID, err)
+	}
+
+	if err := s.eventBroker.Publish(ctx, nil, dto.ReportEvent{
+		Type:     dto.EVENT_REPORT_CREATED,
+		ReportID: createdReport.ID.String(),
+		UserID:   createdReport.UserID,
+		Status:   string(createdReport.Status),
+	}); err != nil {
+		log.Printf("publish report created: %v", err)
+	}
+
+	if err := s.matchSubscriptions(ctx, nil, createdReport, dto.SUBSCRIPTION_EVENT_CREATED); err != nil {
+		log.Printf("match subscriptions for report %s: %v", createdReport.ID, err)
+	}
+
+	return response, nil
+}
+
+func (s *reportService) GetReports(ctx context.Context, req dto.ReportFilterRequest) (dto.ReportPaginationResponse, error) {
+	return s.listReports(ctx, req)
 }
 
-func (s *reportService) GetReportById(ctx context.Context, reportId string) (dto.ReportResponse, error) {
+func (s *reportService) GetReportById(ctx context.Context, reportId string, viewerId string) (dto.ReportResponse, error) {
 	report, err := s.reportRepo.GetReportById(ctx, nil, reportId)
-	if err != nil {
+	if err != nil || !s.canSeeReport(ctx, report, viewerId) {
 		return dto.ReportResponse{}, dto.ErrGetReportById
 	}
 
+	return toReportResponse(report), nil
+}
+
+func toReportResponse(report entity.Report) dto.ReportResponse {
+	var latitude, longitude *float64
+	if lat, lng, ok := report.Coordinates(); ok {
+		latitude, longitude = &lat, &lng
+	}
+
 	return dto.ReportResponse{
 		ID:         report.ID.String(),
 		Text:       report.Text,
 		Image:      report.Image,
 		Location:   report.Location,
+		Latitude:   latitude,
+		Longitude:  longitude,
 		Status:     fmt.Sprintf("%v", report.Status),
 		Upvotes:    report.Upvotes,
 		ShareCount: report.ShareCount,
@@ -184,133 +234,638 @@ func (s *reportService) GetReportById(ctx context.Context, reportId string) (dto
 			}
 			return 0
 		}(),
-		User: report.User, // Tambahkan nested object
-		Tag:  report.Tag,  // Tambahkan nested object
-	}, nil
+		CreatedAt: report.CreatedAt.Format(time.RFC3339),
+		Highlight: report.Highlight,
+		AgencyID: func() string {
+			if report.AgencyID == nil {
+				return ""
+			}
+			return report.AgencyID.String()
+		}(),
+		AssigneeID: func() string {
+			if report.AssigneeID == nil {
+				return ""
+			}
+			return report.AssigneeID.String()
+		}(),
+		DueAt: func() string {
+			if report.DueAt == nil {
+				return ""
+			}
+			return report.DueAt.Format(time.RFC3339)
+		}(),
+		RejectionCode: report.RejectionCode,
+		SLADueAt: func() string {
+			if report.SLADueAt == nil {
+				return ""
+			}
+			return report.SLADueAt.Format(time.RFC3339)
+		}(),
+		Overdue:     report.SLADueAt != nil && time.Now().After(*report.SLADueAt),
+		VoteFlagged: report.VoteFlaggedAt != nil,
+		User:        report.User, // Tambahkan nested object
+		Tag:         report.Tag,  // Tambahkan nested object
+	}
 }
 
-func (s *reportService) GetReportsByUserId(ctx context.Context, userId string, req dto.PaginationRequest) (dto.ReportPaginationResponse, error) {
-	reports, err := s.reportRepo.GetReportsByUserId(ctx, nil, userId, req)
+func (s *reportService) UpdateReportStatus(
+	ctx context.Context,
+	reportId string,
+	req dto.UpdateStatusReportRequest,
+	actorId string,
+) (dto.UpdateStatusReportResponse, error) {
+	status := req.Status
+	// reports only enter the hold when they are created, and leave it
+	// through ReleaseReport
+	if !status.IsValid() || status == entity.StatusPendingReview {
+		return dto.UpdateStatusReportResponse{}, dto.ErrUpdateReportStatus
+	}
+
+	completing := status == entity.StatusCompleted
+	if completing {
+		if err := validateCompletionEvidence(req); err != nil {
+			return dto.UpdateStatusReportResponse{}, err
+		}
+	}
+
+	var reason entity.RejectionReason
+	if status == entity.StatusRejected {
+		if req.ReasonCode == "" {
+			return dto.UpdateStatusReportResponse{}, dto.ErrRejectionReasonRequired
+		}
+		found, err := s.reasonRepo.GetByCode(ctx, nil, req.ReasonCode)
+		if err != nil || !found.Active {
+			return dto.UpdateStatusReportResponse{}, dto.ErrRejectionReasonNotFound
+		}
+		reason = found
+	}
+
+	actor, err := s.userRepo.GetUserById(ctx, nil, actorId)
 	if err != nil {
-		return dto.ReportPaginationResponse{}, dto.ErrGetReports
-	}
-
-	var datas []dto.ReportResponse
-	for _, report := range reports.Reports {
-		data := dto.ReportResponse{
-			ID:         report.ID.String(),
-			Text:       report.Text,
-			Image:      report.Image,
-			Location:   report.Location,
-			Status:     fmt.Sprintf("%v", report.Status),
-			Upvotes:    report.Upvotes,
-			ShareCount: report.ShareCount,
-			UserID:     report.UserID,
-			Username:   report.User.Name, // Gunakan preloaded User
-			TagID: func() string {
-				if report.TagID == uuid.Nil {
-					return ""
-				}
-				return report.TagID.String()
-			}(),
-			PredConfidence: func() int {
-				if report.PredConfidence != nil {
-					return *report.PredConfidence
-				}
-				return 0
-			}(),
-			User: report.User, // Tambahkan nested object
-			Tag:  report.Tag,  // Tambahkan nested object
+		return dto.UpdateStatusReportResponse{}, dto.ErrUserNotFound
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.UpdateStatusReportResponse{}, dto.ErrGetReportById
+	}
+	if report.Status == entity.StatusPendingReview {
+		tx.Rollback()
+		return dto.UpdateStatusReportResponse{}, dto.ErrReportHeld
+	}
+
+	if !CanChangeReportStatus(actor, report, status) {
+		tx.Rollback()
+		return dto.UpdateStatusReportResponse{}, dto.ErrStatusChangeForbidden
+	}
+
+	var evidence entity.CompletionEvidence
+	note := ""
+	if completing {
+		evidence, err = s.createCompletionEvidence(ctx, tx, report, req, actor)
+		if err != nil {
+			tx.Rollback()
+			return dto.UpdateStatusReportResponse{}, err
 		}
-		datas = append(datas, data)
+		note = evidence.Note
 	}
 
-	return dto.ReportPaginationResponse{
-		Data: datas,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    reports.Page,
-			PerPage: reports.PerPage,
-			MaxPage: reports.MaxPage,
-			Count:   reports.Count,
-		},
-	}, nil
-}
+	if status == entity.StatusRejected {
+		if err := s.reportRepo.UpdateRejectionCode(ctx, tx, report.ID.String(), reason.Code); err != nil {
+			tx.Rollback()
+			return dto.UpdateStatusReportResponse{}, dto.ErrUpdateReportStatus
+		}
+		note = fmt.Sprintf("%s: %s", reason.Code, reason.DescriptionID)
 
-func (s *reportService) UpdateReportStatus(ctx context.Context, reportId string, status entity.ReportStatus) (dto.UpdateStatusReportResponse, error) {
-	if status != entity.StatusUnverified && status != entity.StatusVerified && status != entity.StatusRejected && status != entity.StatusCompleted && status != entity.StatusHandled {
-		return dto.UpdateStatusReportResponse{}, dto.ErrUpdateReportStatus
+		if reason.Spam {
+			if err := s.awardReputation(ctx, tx, report, entity.ReputationReportSpam, dto.REPUTATION_POINTS_SPAM, ""); err != nil {
+				tx.Rollback()
+				return dto.UpdateStatusReportResponse{}, dto.ErrUpdateReportStatus
+			}
+		}
 	}
-	reports, err := s.reportRepo.UpdateReportStatus(ctx, nil, reportId, status)
+
+	result, err := s.changeStatus(ctx, tx, report, status, actorId, note)
 	if err != nil {
+		tx.Rollback()
+		removeUploads(evidence.PhotoList())
+		return dto.UpdateStatusReportResponse{}, dto.ErrUpdateReportStatus
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		removeUploads(evidence.PhotoList())
 		return dto.UpdateStatusReportResponse{}, dto.ErrUpdateReportStatus
 	}
 
-	return reports, nil
+	if completing {
+		s.notifyOwner(ctx, report, dto.NotificationMessage{
+			Type:  entity.NotificationCompletion,
+			Title: "Laporan Anda telah diselesaikan",
+			Body: fmt.Sprintf("Periksa bukti perbaikan lalu konfirmasi atau sanggah sebelum %s.",
+				evidence.ConfirmBy.In(utils.JakartaLocation()).Format("2006-01-02 15:04")),
+		})
+	} else if status == entity.StatusRejected {
+		s.notifyOwner(ctx, report, dto.NotificationMessage{
+			Type:  entity.NotificationStatusChanged,
+			Title: "Laporan Anda ditolak",
+			Body:  fmt.Sprintf("Alasan: %s. Anda dapat mengajukan banding satu kali.", reason.DescriptionID),
+		})
+	} else if actorId != report.UserID {
+		s.notifyOwner(ctx, report, dto.NotificationMessage{
+			Type:  entity.NotificationStatusChanged,
+			Title: "Status laporan diperbarui",
+			Body:  fmt.Sprintf("Laporan Anda kini berstatus %s.", status),
+		})
+	}
+
+	return result, nil
 }
 
-func (s *reportService) CountReportStatus(ctx context.Context) (dto.CountReportResponse, error) {
-	counts, err := s.reportRepo.CountReportStatus(ctx, nil)
+// validateCompletionEvidence checks the parts of the proof that do not
+// need the report.
+func validateCompletionEvidence(req dto.UpdateStatusReportRequest) error {
+	if strings.TrimSpace(req.Note) == "" || len(req.Photos) == 0 {
+		return dto.ErrEvidenceRequired
+	}
+	if len(req.Photos) > dto.COMPLETION_MAX_PHOTOS {
+		return dto.ErrTooManyEvidencePhotos
+	}
+	if err := validateImages(req.Photos); err != nil {
+		return err
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) ||
+		(req.Latitude != nil && !utils.ValidLatLng(*req.Latitude, *req.Longitude)) {
+		return dto.ErrInvalidCoordinates
+	}
+	return nil
+}
+
+// createCompletionEvidence checks the geotag against the report location,
+// stores the photos and records the proof inside tx. The photos are removed
+// again if recording fails.
+func (s *reportService) createCompletionEvidence(
+	ctx context.Context,
+	tx *gorm.DB,
+	report entity.Report,
+	req dto.UpdateStatusReportRequest,
+	actor entity.User,
+) (entity.CompletionEvidence, error) {
+	evidence := entity.CompletionEvidence{
+		ID:        uuid.New(),
+		ReportID:  report.ID,
+		ActorID:   &actor.ID,
+		Note:      strings.TrimSpace(req.Note),
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Outcome:   entity.CompletionPending,
+		ConfirmBy: time.Now().Add(dto.COMPLETION_CONFIRM_WINDOW),
+	}
+
+	if lat, lng, ok := report.Coordinates(); ok && req.Latitude != nil {
+		distance := utils.DistanceMeters(lat, lng, *req.Latitude, *req.Longitude)
+		if distance > dto.COMPLETION_MAX_DISTANCE_METERS {
+			return entity.CompletionEvidence{}, dto.ErrEvidenceTooFar
+		}
+		evidence.DistanceMeters = &distance
+	}
+
+	photos, err := uploadImages("completions", evidence.ID.String(), req.Photos)
 	if err != nil {
-		return dto.CountReportResponse{}, dto.ErrGetReports
+		return entity.CompletionEvidence{}, dto.ErrUpdateReportStatus
 	}
-	return dto.CountReportResponse{
-		Total:      counts.Total,
-		Unverified: counts.Unverified,
-		Verified:   counts.Verified,
-		Rejected:   counts.Rejected,
-		Handled:    counts.Handled,
-		Completed:  counts.Completed,
-	}, nil
+	evidence.Photos = strings.Join(photos, ",")
+
+	created, err := s.evidenceRepo.Create(ctx, tx, evidence)
+	if err != nil {
+		removeUploads(photos)
+		return entity.CompletionEvidence{}, dto.ErrUpdateReportStatus
+	}
+
+	return created, nil
+}
+
+// validateImages accepts non-empty jpg and png files up to MaxImageSize.
+func validateImages(files []*multipart.FileHeader) error {
+	for _, file := range files {
+		switch strings.ToLower(utils.GetExtensions(file.Filename)) {
+		case "jpg", "jpeg", "png":
+		default:
+			return dto.ErrInvalidImageExtension
+		}
+		if file.Size == 0 {
+			return dto.ErrEmptyFileUploaded
+		}
+		if file.Size > MaxImageSize {
+			return dto.ErrImageSizeTooLarge
+		}
+	}
+	return nil
+}
+
+// uploadImages stores files as dir/<id>-<n>.<ext> under the assets
+// directory. Nothing is left behind when one of them fails.
+func uploadImages(dir string, id string, files []*multipart.FileHeader) ([]string, error) {
+	paths := make([]string, 0, len(files))
+	for i, file := range files {
+		path := fmt.Sprintf("%s/%s-%d.%s", dir, id, i+1, strings.ToLower(utils.GetExtensions(file.Filename)))
+		if err := utils.UploadFile(file, path); err != nil {
+			removeUploads(paths)
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func removeUploads(paths []string) {
+	for _, path := range paths {
+		if err := os.Remove(fmt.Sprintf("%s/%s", utils.PATH, path)); err != nil && !os.IsNotExist(err) {
+			log.Printf("remove upload %s: %v", path, err)
+		}
+	}
+}
+
+func (s *reportService) GetCompletion(ctx context.Context, reportId string) (dto.CompletionEvidenceResponse, error) {
+	evidence, err := s.evidenceRepo.GetLatestByReportId(ctx, nil, reportId)
+	if err != nil {
+		return dto.CompletionEvidenceResponse{}, dto.ErrCompletionNotFound
+	}
+
+	return toCompletionEvidenceResponse(evidence), nil
+}
+
+// pendingCompletion locks the report and loads its latest proof for the
+// reporter to respond to. Resolve only settles pending evidence, and the
+// lock keeps the status a dispute changes from going stale.
+func (s *reportService) pendingCompletion(
+	ctx context.Context,
+	tx *gorm.DB,
+	reportId string,
+	userId string,
+) (entity.Report, entity.CompletionEvidence, error) {
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, reportId)
+	if err != nil {
+		return entity.Report{}, entity.CompletionEvidence{}, dto.ErrGetReportById
+	}
+	if report.UserID != userId {
+		return entity.Report{}, entity.CompletionEvidence{}, dto.ErrNotReportOwner
+	}
+
+	evidence, err := s.evidenceRepo.GetLatestByReportId(ctx, tx, reportId)
+	if err != nil {
+		return entity.Report{}, entity.CompletionEvidence{}, dto.ErrCompletionNotFound
+	}
+	if evidence.Outcome != entity.CompletionPending || report.Status != entity.StatusCompleted {
+		return entity.Report{}, entity.CompletionEvidence{}, dto.ErrCompletionNotPending
+	}
+	if time.Now().After(evidence.ConfirmBy) {
+		return entity.Report{}, entity.CompletionEvidence{}, dto.ErrCompletionWindowClosed
+	}
+
+	return report, evidence, nil
+}
+
+func (s *reportService) ConfirmCompletion(ctx context.Context, reportId string, userId string) (dto.CompletionEvidenceResponse, error) {
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, evidence, err := s.pendingCompletion(ctx, tx, reportId, userId)
+	if err != nil {
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, err
+	}
+
+	now := time.Now()
+	resolved, err := s.evidenceRepo.Resolve(ctx, tx, evidence.ID.String(), entity.CompletionConfirmed, "", now)
+	if err != nil {
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, dto.ErrUpdateReportStatus
+	}
+	if !resolved {
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, dto.ErrCompletionNotPending
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryCompletionConfirmed,
+		ActorID:  parseActorId(userId),
+	}); err != nil {
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, dto.ErrUpdateReportStatus
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.CompletionEvidenceResponse{}, dto.ErrUpdateReportStatus
+	}
+
+	if evidence.ActorID != nil {
+		msg := dto.NotificationMessage{
+			Type:     entity.NotificationCompletion,
+			Title:    "Penyelesaian dikonfirmasi",
+			Body:     "Pelapor mengonfirmasi bahwa laporan telah diperbaiki.",
+			ReportID: report.ID.String(),
+		}
+		if err := s.notificationService.Notify(ctx, evidence.ActorID.String(), msg); err != nil {
+			log.Printf("notify completion confirmed for report %s: %v", report.ID, err)
+		}
+	}
+
+	evidence.Outcome = entity.CompletionConfirmed
+	evidence.RespondedAt = &now
+	return toCompletionEvidenceResponse(evidence), nil
 }
 
-func (s *reportService) GetReportsByStatus(ctx context.Context, status string, req dto.PaginationRequest) (dto.ReportPaginationResponse, error) {
-	reportStatus := entity.ReportStatus(status)
-	if reportStatus != entity.StatusUnverified && reportStatus != entity.StatusVerified && reportStatus != entity.StatusRejected && reportStatus != entity.StatusCompleted && reportStatus != entity.StatusHandled {
-		return dto.ReportPaginationResponse{}, dto.ErrGetReports
+// DisputeCompletion reopens the report as handled, which restarts its SLA
+// deadline, and escalates to the officer and the supervisors.
+func (s *reportService) DisputeCompletion(
+	ctx context.Context,
+	reportId string,
+	req dto.DisputeCompletionRequest,
+	userId string,
+) (dto.CompletionEvidenceResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return dto.CompletionEvidenceResponse{}, dto.ErrEmptyContent
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, evidence, err := s.pendingCompletion(ctx, tx, reportId, userId)
+	if err != nil {
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, err
 	}
-	reports, err := s.reportRepo.GetReportsByStatus(ctx, nil, reportStatus, req)
+
+	now := time.Now()
+	resolved, err := s.evidenceRepo.Resolve(ctx, tx, evidence.ID.String(), entity.CompletionDisputed, reason, now)
 	if err != nil {
-		return dto.ReportPaginationResponse{}, dto.ErrGetReports
-	}
-
-	var datas []dto.ReportResponse
-	for _, report := range reports.Reports {
-		data := dto.ReportResponse{
-			ID:         report.ID.String(),
-			Text:       report.Text,
-			Image:      report.Image,
-			Location:   report.Location,
-			Status:     fmt.Sprintf("%v", report.Status),
-			Upvotes:    report.Upvotes,
-			ShareCount: report.ShareCount,
-			UserID:     report.UserID,
-			Username:   report.User.Name, // Gunakan preloaded User
-			TagID: func() string {
-				if report.TagID == uuid.Nil {
-					return ""
-				}
-				return report.TagID.String()
-			}(),
-			PredConfidence: func() int {
-				if report.PredConfidence != nil {
-					return *report.PredConfidence
-				}
-				return 0
-			}(),
-			User: report.User, // Tambahkan nested object
-			Tag:  report.Tag,  // Tambahkan nested object
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, dto.ErrUpdateReportStatus
+	}
+	if !resolved {
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, dto.ErrCompletionNotPending
+	}
+
+	if _, err := s.changeStatus(ctx, tx, report, entity.StatusHandled, userId, "Penyelesaian disanggah pelapor: "+reason); err != nil {
+		tx.Rollback()
+		return dto.CompletionEvidenceResponse{}, dto.ErrUpdateReportStatus
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.CompletionEvidenceResponse{}, dto.ErrUpdateReportStatus
+	}
+
+	msg := dto.NotificationMessage{
+		Type:     entity.NotificationDisputed,
+		Title:    "Penyelesaian laporan disanggah",
+		Body:     fmt.Sprintf("Pelapor menyanggah penyelesaian laporan dan laporan dibuka kembali: %s", reason),
+		ReportID: report.ID.String(),
+	}
+	for _, recipient := range s.disputeRecipients(ctx, report) {
+		if err := s.notificationService.Notify(ctx, recipient, msg); err != nil {
+			log.Printf("notify dispute of report %s to %s: %v", report.ID, recipient, err)
+		}
+	}
+
+	evidence.Outcome = entity.CompletionDisputed
+	evidence.DisputeReason = reason
+	evidence.RespondedAt = &now
+	return toCompletionEvidenceResponse(evidence), nil
+}
+
+// disputeRecipients are the assigned officer and the supervisors of the
+// report's agency, or every admin when the report has no supervisor.
+func (s *reportService) disputeRecipients(ctx context.Context, report entity.Report) []string {
+	var recipients []string
+	seen := map[string]bool{}
+	add := func(userId string) {
+		if !seen[userId] {
+			seen[userId] = true
+			recipients = append(recipients, userId)
+		}
+	}
+
+	if report.AssigneeID != nil {
+		add(report.AssigneeID.String())
+	}
+
+	supervised := false
+	if report.AgencyID != nil {
+		members, err := s.agencyRepo.GetMembers(ctx, nil, report.AgencyID.String())
+		if err != nil {
+			log.Printf("dispute: agency supervisors of report %s: %v", report.ID, err)
+		}
+		for _, member := range members {
+			if member.Role == entity.AgencySupervisor {
+				add(member.UserID.String())
+				supervised = true
+			}
+		}
+	}
+
+	if !supervised {
+		admins, err := s.userRepo.GetUsersByRole(ctx, nil, constants.ENUM_ROLE_ADMIN)
+		if err != nil {
+			log.Printf("dispute: admins for report %s: %v", report.ID, err)
+		}
+		for _, admin := range admins {
+			add(admin.ID.String())
+		}
+	}
+
+	return recipients
+}
+
+// ExpireCompletions accepts every completion the reporter let lapse.
+func (s *reportService) ExpireCompletions(ctx context.Context) error {
+	now := time.Now()
+
+	for {
+		evidences, err := s.evidenceRepo.GetExpired(ctx, nil, now, dto.COMPLETION_EXPIRE_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, evidence := range evidences {
+			if err := s.expireCompletion(ctx, evidence, now); err != nil {
+				return fmt.Errorf("expire completion %s: %w", evidence.ID, err)
+			}
+		}
+
+		if len(evidences) < dto.COMPLETION_EXPIRE_BATCH_SIZE {
+			return nil
+		}
+	}
+}
+
+func (s *reportService) expireCompletion(ctx context.Context, evidence entity.CompletionEvidence, now time.Time) error {
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	resolved, err := s.evidenceRepo.Resolve(ctx, tx, evidence.ID.String(), entity.CompletionExpired, "", now)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !resolved {
+		tx.Rollback()
+		return nil
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: evidence.ReportID,
+		Event:    entity.HistoryCompletionExpired,
+		Note:     "Pelapor tidak menanggapi, penyelesaian dianggap diterima",
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func toCompletionEvidenceResponse(evidence entity.CompletionEvidence) dto.CompletionEvidenceResponse {
+	photos := evidence.PhotoList()
+	if photos == nil {
+		photos = []string{}
+	}
+
+	response := dto.CompletionEvidenceResponse{
+		ID:             evidence.ID.String(),
+		ReportID:       evidence.ReportID.String(),
+		Note:           evidence.Note,
+		Photos:         photos,
+		Latitude:       evidence.Latitude,
+		Longitude:      evidence.Longitude,
+		DistanceMeters: evidence.DistanceMeters,
+		Outcome:        string(evidence.Outcome),
+		ConfirmBy:      evidence.ConfirmBy.Format(time.RFC3339),
+		DisputeReason:  evidence.DisputeReason,
+		CreatedAt:      evidence.CreatedAt.Format(time.RFC3339),
+	}
+	if evidence.ActorID != nil {
+		response.ActorID = evidence.ActorID.String()
+	}
+	if evidence.RespondedAt != nil {
+		response.RespondedAt = evidence.RespondedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
+
+// officerStatuses are the statuses an officer may move their own
+// assignments between.
+var officerStatuses = map[entity.ReportStatus]bool{
+	entity.StatusHandled:   true,
+	entity.StatusCompleted: true,
+}
+
+// CanChangeReportStatus reports whether actor may move report to status.
+// Admins may make any change; officers only toggle their own assignments
+// between handled and completed.
+func CanChangeReportStatus(actor entity.User, report entity.Report, status entity.ReportStatus) bool {
+	switch actor.Role {
+	case constants.ENUM_ROLE_ADMIN:
+		return true
+	case constants.ENUM_ROLE_OFFICER:
+		return report.AssigneeID != nil && *report.AssigneeID == actor.ID &&
+			officerStatuses[report.Status] && officerStatuses[status]
+	}
+	return false
+}
+
+// routeByClass sends a freshly classified report to the agency configured
+// for its class and location. Reports an admin already routed keep their
+// agency.
+func (s *reportService) routeByClass(ctx context.Context, report entity.Report, class string, location string) error {
+	if report.AgencyID != nil {
+		return nil
+	}
+
+	classes := strings.Split(class, ",")
+	routes, err := s.agencyRepo.GetRoutesByClass(ctx, nil, classes)
+	if err != nil {
+		return err
+	}
+
+	if location == "" {
+		location = report.Location
+	}
+	route := ResolveAgencyRoute(routes, classes, location)
+	if route == nil {
+		return nil
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	if err := s.reportRepo.UpdateAgency(ctx, tx, report.ID.String(), &route.AgencyID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryRouted,
+		Note:     fmt.Sprintf("Diteruskan otomatis ke %s (kelas %s)", route.Agency.Name, route.Class),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (s *reportService) GetReportHistory(ctx context.Context, reportId string, viewerId string) ([]dto.ReportHistoryResponse, error) {
+	report, err := s.reportRepo.GetReportById(ctx, nil, reportId)
+	if err != nil || !s.canSeeReport(ctx, report, viewerId) {
+		return nil, dto.ErrGetReportById
+	}
+
+	histories, err := s.historyRepo.GetByReportId(ctx, nil, reportId)
+	if err != nil {
+		return nil, dto.ErrGetReportHistory
+	}
+
+	datas := make([]dto.ReportHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		data := dto.ReportHistoryResponse{
+			ID:         history.ID.String(),
+			Event:      string(history.Event),
+			FromStatus: string(history.FromStatus),
+			ToStatus:   string(history.ToStatus),
+			Note:       history.Note,
+			CreatedAt:  history.CreatedAt.Format(time.RFC3339),
+		}
+		if history.ActorID != nil {
+			data.ActorID = history.ActorID.String()
 		}
 		datas = append(datas, data)
 	}
 
-	return dto.ReportPaginationResponse{
-		Data: datas,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    reports.Page,
-			PerPage: reports.PerPage,
-			MaxPage: reports.MaxPage,
-			Count:   reports.Count,
-		},
+	return datas, nil
+}
+
+func (s *reportService) CountReportStatus(ctx context.Context, agencyIds []string) (dto.CountReportResponse, error) {
+	counts, err := s.reportRepo.CountReportStatus(ctx, nil, agencyIds)
+	if err != nil {
+		return dto.CountReportResponse{}, dto.ErrGetReports
+	}
+	return dto.CountReportResponse{
+		Total:         counts.Total,
+		PendingReview: counts.PendingReview,
+		Unverified:    counts.Unverified,
+		Verified:      counts.Verified,
+		Rejected:      counts.Rejected,
+		Handled:       counts.Handled,
+		Completed:     counts.Completed,
 	}, nil
 }
 
@@ -332,6 +887,34 @@ func (s *reportService) InferenceStatus(ctx context.Context, req dto.InferenceRe
 		return dto.InferenceResponse{}, dto.ErrUpdateReportInference
 	}
 
+	if _, err := s.historyRepo.Create(ctx, nil, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistoryInferenceCompleted,
+		Note:     req.Class,
+	}); err != nil {
+		return dto.InferenceResponse{}, dto.ErrUpdateReportInference
+	}
+
+	if err := s.eventBroker.Publish(ctx, nil, dto.ReportEvent{
+		Type:     dto.EVENT_INFERENCE_COMPLETED,
+		ReportID: report.ID.String(),
+		UserID:   report.UserID,
+		Status:   string(report.Status),
+		Class:    req.Class,
+	}); err != nil {
+		log.Printf("publish inference completed: %v", err)
+	}
+
+	if err := s.routeByClass(ctx, report, req.Class, req.Location); err != nil {
+		log.Printf("route report %s: %v", report.ID, err)
+	}
+
+	s.notifyOwner(ctx, report, dto.NotificationMessage{
+		Type:  entity.NotificationInferenceCompleted,
+		Title: "Laporan selesai dianalisis",
+		Body:  fmt.Sprintf("Laporan Anda dikategorikan sebagai %s.", req.Class),
+	})
+
 	var response dto.InferenceResponse
 
 	for _, result := range res {
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gorm.io/gorm"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+)
+
+// ReportWorkflow holds the steps the report services share: listing
+// reports, moving a report between statuses along with its history, SLA,
+// reputation and events, and telling the reporter. The report, assignment,
+// appeal, vote and held-report services embed it.
+type ReportWorkflow struct {
+	userRepo            repository.UserRepository
+	reportRepo          repository.ReportRepository
+	historyRepo         repository.ReportHistoryRepository
+	slaRepo             repository.SLAPolicyRepository
+	reputationRepo      repository.ReputationRepository
+	eventBroker         EventBroker
+	notificationService NotificationService
+	jobService          JobService
+	db                  *gorm.DB
+}
+
+func NewReportWorkflow(
+	userRepo repository.UserRepository,
+	reportRepo repository.ReportRepository,
+	historyRepo repository.ReportHistoryRepository,
+	slaRepo repository.SLAPolicyRepository,
+	reputationRepo repository.ReputationRepository,
+	eventBroker EventBroker,
+	notificationService NotificationService,
+	jobService JobService,
+	db *gorm.DB,
+) *ReportWorkflow {
+	return &ReportWorkflow{
+		userRepo:            userRepo,
+		reportRepo:          reportRepo,
+		historyRepo:         historyRepo,
+		slaRepo:             slaRepo,
+		reputationRepo:      reputationRepo,
+		eventBroker:         eventBroker,
+		notificationService: notificationService,
+		jobService:          jobService,
+		db:                  db,
+	}
+}
+
+// listReports is the listing behind every report feed; the feeds differ
+// only in the filter they pass.
+func (s *ReportWorkflow) listReports(ctx context.Context, req dto.ReportFilterRequest) (dto.ReportPaginationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.ReportPaginationResponse{}, err
+	}
+
+	reports, err := s.reportRepo.GetReports(ctx, nil, req)
+	if err != nil {
+		return dto.ReportPaginationResponse{}, dto.ErrGetReports
+	}
+
+	var datas []dto.ReportResponse
+	for _, report := range reports.Reports {
+		datas = append(datas, toReportResponse(report))
+	}
+
+	return dto.ReportPaginationResponse{
+		Data:               datas,
+		PaginationResponse: reports.PaginationResponse,
+	}, nil
+}
+
+// canSeeReport tells whether viewer may see report. Held reports are only
+// visible to their reporter and to the admins who review them.
+func (s *ReportWorkflow) canSeeReport(ctx context.Context, report entity.Report, viewerId string) bool {
+	if report.Status != entity.StatusPendingReview || report.UserID == viewerId {
+		return true
+	}
+
+	viewer, err := s.userRepo.GetUserById(ctx, nil, viewerId)
+	return err == nil && reportVisibleTo(report, viewer)
+}
+
+// reportVisibleTo is canSeeReport for a viewer that is already loaded.
+func reportVisibleTo(report entity.Report, viewer entity.User) bool {
+	return report.Status != entity.StatusPendingReview ||
+		report.UserID == viewer.ID.String() ||
+		viewer.Role == constants.ENUM_ROLE_ADMIN
+}
+
+// statusReputation are the status changes the reporter earns points for.
+var statusReputation = map[entity.ReportStatus]entity.ReputationReason{
+	entity.StatusVerified:  entity.ReputationReportVerified,
+	entity.StatusCompleted: entity.ReputationReportCompleted,
+}
+
+var reputationPoints = map[entity.ReputationReason]int{
+	entity.ReputationReportVerified:  dto.REPUTATION_POINTS_VERIFIED,
+	entity.ReputationReportCompleted: dto.REPUTATION_POINTS_COMPLETED,
+}
+
+// awardReputation writes a ledger entry for the reporter of report. Each
+// reason pays once per report, and once per report and source user when
+// sourceId is set, so a report that is verified again after an appeal, or
+// upvoted again by the same user, earns nothing more.
+func (s *ReportWorkflow) awardReputation(
+	ctx context.Context,
+	tx *gorm.DB,
+	report entity.Report,
+	reason entity.ReputationReason,
+	points int,
+	sourceId string,
+) error {
+	userId, err := uuid.Parse(report.UserID)
+	if err != nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s:%s", reason, report.ID)
+	if sourceId != "" {
+		key += ":" + sourceId
+	}
+
+	_, err = s.reputationRepo.Award(ctx, tx, entity.ReputationEntry{
+		UserID:    userId,
+		ReportID:  &report.ID,
+		Reason:    reason,
+		Points:    points,
+		Region:    report.Location,
+		Key:       key,
+		CreatedAt: time.Now(),
+	})
+	return err
+}
+
+// reverseSpamPenalty refunds the spam penalty of a report whose rejection
+// was overturned.
+func (s *ReportWorkflow) reverseSpamPenalty(ctx context.Context, tx *gorm.DB, report entity.Report) error {
+	penalized, err := s.reputationRepo.HasKey(ctx, tx, fmt.Sprintf("%s:%s", entity.ReputationReportSpam, report.ID))
+	if err != nil || !penalized {
+		return err
+	}
+	return s.awardReputation(ctx, tx, report, entity.ReputationSpamReversed, -dto.REPUTATION_POINTS_SPAM, "")
+}
+
+// notifyOwner tells the report's author about something that happened to
+// it. It runs after the change is committed and never fails the caller.
+func (s *ReportWorkflow) notifyOwner(ctx context.Context, report entity.Report, msg dto.NotificationMessage) {
+	msg.ReportID = report.ID.String()
+	if err := s.notificationService.Notify(ctx, report.UserID, msg); err != nil {
+		log.Printf("notify report %s owner: %v", report.ID, err)
+	}
+}
+
+// changeStatus moves a report to a new status inside tx and records the
+// transition in the report history.
+func (s *ReportWorkflow) changeStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	report entity.Report,
+	status entity.ReportStatus,
+	actorId string,
+	note string,
+) (dto.UpdateStatusReportResponse, error) {
+	result, err := s.reportRepo.UpdateReportStatus(ctx, tx, report.ID.String(), status)
+	if err != nil {
+		return dto.UpdateStatusReportResponse{}, err
+	}
+
+	now := time.Now()
+	var slaMet *bool
+	if report.SLADueAt != nil {
+		met := !now.After(*report.SLADueAt)
+		slaMet = &met
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID:   report.ID,
+		Event:      entity.HistoryStatusChanged,
+		FromStatus: report.Status,
+		ToStatus:   status,
+		ActorID:    parseActorId(actorId),
+		Note:       note,
+		SLAMet:     slaMet,
+	}); err != nil {
+		return dto.UpdateStatusReportResponse{}, err
+	}
+
+	if err := s.applySLA(ctx, tx, report, status, now); err != nil {
+		return dto.UpdateStatusReportResponse{}, err
+	}
+
+	if reason, ok := statusReputation[status]; ok {
+		if err := s.awardReputation(ctx, tx, report, reason, reputationPoints[reason], ""); err != nil {
+			return dto.UpdateStatusReportResponse{}, err
+		}
+	}
+
+	// a rejection reason only explains the rejected status
+	if report.RejectionCode != "" && status != entity.StatusRejected {
+		if err := s.reportRepo.UpdateRejectionCode(ctx, tx, report.ID.String(), ""); err != nil {
+			return dto.UpdateStatusReportResponse{}, err
+		}
+	}
+
+	// NOTIFY is transactional, so listeners only see committed changes
+	if err := s.eventBroker.Publish(ctx, tx, dto.ReportEvent{
+		Type:     dto.EVENT_STATUS_CHANGED,
+		ReportID: report.ID.String(),
+		UserID:   report.UserID,
+		Status:   string(status),
+		Class:    report.Tag.Class,
+	}); err != nil {
+		return dto.UpdateStatusReportResponse{}, err
+	}
+
+	if event, ok := subscriptionStatusEvents[status]; ok {
+		if err := s.matchSubscriptions(ctx, tx, report, event); err != nil {
+			return dto.UpdateStatusReportResponse{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// applySLA starts the deadline of the status the report just entered, or
+// clears it when no policy covers the status.
+func (s *ReportWorkflow) applySLA(ctx context.Context, tx *gorm.DB, report entity.Report, status entity.ReportStatus, since time.Time) error {
+	policies, err := s.slaRepo.GetByStatus(ctx, tx, status)
+	if err != nil {
+		return err
+	}
+
+	policy := ResolveSLAPolicy(policies, report.Tag.Class, status)
+	if policy == nil {
+		if report.SLADueAt == nil {
+			return nil
+		}
+		return s.reportRepo.UpdateSLA(ctx, tx, report.ID.String(), nil, nil)
+	}
+
+	dueAt := since.Add(policy.Duration())
+	return s.reportRepo.UpdateSLA(ctx, tx, report.ID.String(), &policy.ID, &dueAt)
+}
+
+// subscriptionStatusEvents are the status changes subscribers hear about.
+var subscriptionStatusEvents = map[entity.ReportStatus]string{
+	entity.StatusVerified:  dto.SUBSCRIPTION_EVENT_VERIFIED,
+	entity.StatusCompleted: dto.SUBSCRIPTION_EVENT_COMPLETED,
+}
+
+// matchSubscriptions queues the subscription matching for a report event,
+// inside tx when given so it only runs once the change is committed.
+func (s *ReportWorkflow) matchSubscriptions(ctx context.Context, tx *gorm.DB, report entity.Report, event string) error {
+	_, err := s.jobService.Enqueue(ctx, tx, dto.JOB_MATCH_SUBSCRIPTIONS, dto.MatchSubscriptionsJob{
+		ReportID: report.ID.String(),
+		Event:    event,
+	})
+	return err
+}
+
+// parseActorId returns nil for system actions or ids that are not UUIDs.
+func parseActorId(actorId string) *uuid.UUID {
+	id, err := uuid.Parse(actorId)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+)
+
+type (
+	ReputationService interface {
+		GetReputation(ctx context.Context, userId string) (dto.ReputationResponse, error)
+		GetLedger(ctx context.Context, userId string, req dto.PaginationRequest) (dto.ReputationLedgerResponse, error)
+		GetLeaderboard(ctx context.Context, req dto.LeaderboardRequest) (dto.LeaderboardResponse, error)
+	}
+
+	reputationService struct {
+		reputationRepo repository.ReputationRepository
+	}
+)
+
+func NewReputationService(reputationRepo repository.ReputationRepository) ReputationService {
+	return &reputationService{
+		reputationRepo: reputationRepo,
+	}
+}
+
+// reputationBadge is earned with MinCount ledger entries of Reason, or with
+// a score of MinScore when Reason is empty.
+type reputationBadge struct {
+	Code     string
+	Name     string
+	Reason   entity.ReputationReason
+	MinCount int64
+	MinScore int64
+}
+
+var reputationBadges = []reputationBadge{
+	{Code: "first_verified", Name: "Laporan Pertama Terverifikasi", Reason: entity.ReputationReportVerified, MinCount: 1},
+	{Code: "trusted_reporter", Name: "Pelapor Tepercaya", Reason: entity.ReputationReportVerified, MinCount: 10},
+	{Code: "problem_solver", Name: "Pendorong Perbaikan", Reason: entity.ReputationReportCompleted, MinCount: 5},
+	{Code: "community_voice", Name: "Suara Warga", Reason: entity.ReputationReportUpvoted, MinCount: 50},
+	{Code: "city_guardian", Name: "Penjaga Kota", MinScore: 500},
+}
+
+func (s *reputationService) GetReputation(ctx context.Context, userId string) (dto.ReputationResponse, error) {
+	counts, err := s.reputationRepo.GetReasonCounts(ctx, nil, userId)
+	if err != nil {
+		return dto.ReputationResponse{}, dto.ErrGetReputation
+	}
+
+	return ReputationSummary(counts), nil
+}
+
+// GetLedger lists the entries behind a user's score, newest first, so the
+// total can be audited.
+func (s *reputationService) GetLedger(ctx context.Context, userId string, req dto.PaginationRequest) (dto.ReputationLedgerResponse, error) {
+	counts, err := s.reputationRepo.GetReasonCounts(ctx, nil, userId)
+	if err != nil {
+		return dto.ReputationLedgerResponse{}, dto.ErrGetReputation
+	}
+
+	entries, err := s.reputationRepo.GetEntries(ctx, nil, userId, req)
+	if err != nil {
+		return dto.ReputationLedgerResponse{}, dto.ErrGetReputation
+	}
+
+	datas := make([]dto.ReputationEntryResponse, 0, len(entries.Entries))
+	for _, entry := range entries.Entries {
+		data := dto.ReputationEntryResponse{
+			ID:        entry.ID.String(),
+			Reason:    string(entry.Reason),
+			Points:    entry.Points,
+			Region:    entry.Region,
+			CreatedAt: entry.CreatedAt.Format(time.RFC3339),
+		}
+		if entry.ReportID != nil {
+			data.ReportID = entry.ReportID.String()
+		}
+		datas = append(datas, data)
+	}
+
+	return dto.ReputationLedgerResponse{
+		Score:              ReputationSummary(counts).Score,
+		Data:               datas,
+		PaginationResponse: entries.PaginationResponse,
+	}, nil
+}
+
+// ReputationSummary totals the ledger counts of one user and lists the
+// badges they earned, in the order they are defined.
+func ReputationSummary(counts []dto.ReputationReasonCount) dto.ReputationResponse {
+	var score int64
+	byReason := map[entity.ReputationReason]int64{}
+	for _, count := range counts {
+		score += count.Points
+		byReason[count.Reason] += count.Count
+	}
+
+	badges := []dto.BadgeResponse{}
+	for _, badge := range reputationBadges {
+		earned := score >= badge.MinScore
+		if badge.Reason != "" {
+			earned = byReason[badge.Reason] >= badge.MinCount
+		}
+		if earned {
+			badges = append(badges, dto.BadgeResponse{Code: badge.Code, Name: badge.Name})
+		}
+	}
+
+	return dto.ReputationResponse{
+		Score:  score,
+		Badges: badges,
+	}
+}
+
+func (s *reputationService) GetLeaderboard(ctx context.Context, req dto.LeaderboardRequest) (dto.LeaderboardResponse, error) {
+	if req.Period == "" {
+		req.Period = dto.LEADERBOARD_ALL_TIME
+	}
+	since, err := LeaderboardSince(req.Period, time.Now())
+	if err != nil {
+		return dto.LeaderboardResponse{}, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = dto.LEADERBOARD_DEFAULT_LIMIT
+	}
+	limit = min(limit, dto.LEADERBOARD_MAX_LIMIT)
+	region := strings.TrimSpace(req.Region)
+
+	rows, err := s.reputationRepo.GetLeaderboard(ctx, nil, since, region, limit)
+	if err != nil {
+		return dto.LeaderboardResponse{}, dto.ErrGetLeaderboard
+	}
+
+	entries := make([]dto.LeaderboardEntry, 0, len(rows))
+	for i, row := range rows {
+		entries = append(entries, dto.LeaderboardEntry{
+			Rank:  i + 1,
+			Name:  row.Name,
+			Score: row.Score,
+		})
+	}
+
+	response := dto.LeaderboardResponse{
+		Period:  req.Period,
+		Region:  region,
+		Entries: entries,
+	}
+	if since != nil {
+		response.Since = since.Format(time.RFC3339)
+	}
+	return response, nil
+}
+
+// LeaderboardSince is the start of the current Jakarta calendar week
+// (from Monday) or month, or nil for the all-time board.
+func LeaderboardSince(period string, now time.Time) (*time.Time, error) {
+	today := startOfDay(now.In(utils.JakartaLocation()))
+
+	var since time.Time
+	switch period {
+	case dto.LEADERBOARD_ALL_TIME:
+		return nil, nil
+	case dto.LEADERBOARD_WEEKLY:
+		since = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	case dto.LEADERBOARD_MONTHLY:
+		since = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	default:
+		return nil, dto.ErrInvalidLeaderboardRange
+	}
+	return &since, nil
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+)
+
+type (
+	// ScheduledTask is a periodic task. Schedule is a standard five field
+	// cron expression evaluated in Asia/Jakarta. Tasks are registered in the
+	// injector under constants.ScheduledTask + Name.
+	ScheduledTask struct {
+		Name        string
+		Description string
+		Schedule    string
+		Run         func(ctx context.Context) error
+	}
+
+	Scheduler interface {
+		// Run fires tasks on their schedule until ctx is cancelled.
+		Run(ctx context.Context)
+		ListTasks(ctx context.Context) ([]dto.ScheduledTaskResponse, error)
+		// RunNow starts a task immediately in the background, unless it is
+		// already running on some replica.
+		RunNow(ctx context.Context, name string) (dto.ScheduledTaskResponse, error)
+	}
+
+	parsedTask struct {
+		ScheduledTask
+		schedule cron.Schedule
+	}
+
+	scheduler struct {
+		db       *gorm.DB
+		taskRepo repository.ScheduledTaskRepository
+		tasks    []parsedTask
+		byName   map[string]parsedTask
+	}
+)
+
+func NewScheduler(db *gorm.DB, taskRepo repository.ScheduledTaskRepository, tasks []ScheduledTask) (Scheduler, error) {
+	s := &scheduler{
+		db:       db,
+		taskRepo: taskRepo,
+		byName:   make(map[string]parsedTask, len(tasks)),
+	}
+
+	for _, task := range tasks {
+		if _, ok := s.byName[task.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", dto.ErrDuplicateScheduledTask, task.Name)
+		}
+
+		schedule, err := cron.ParseStandard(task.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %q: %v", dto.ErrInvalidScheduleSpec, task.Name, task.Schedule, err)
+		}
+
+		parsed := parsedTask{ScheduledTask: task, schedule: schedule}
+		s.tasks = append(s.tasks, parsed)
+		s.byName[task.Name] = parsed
+	}
+
+	sort.Slice(s.tasks, func(i, j int) bool { return s.tasks[i].Name < s.tasks[j].Name })
+
+	return s, nil
+}
+
+func (s *scheduler) Run(ctx context.Context) {
+	if len(s.tasks) == 0 {
+		return
+	}
+
+	next := make(map[string]time.Time, len(s.tasks))
+	now := time.Now().In(utils.JakartaLocation())
+	for _, task := range s.tasks {
+		next[task.Name] = task.schedule.Next(now)
+	}
+
+	for {
+		var earliest time.Time
+		for _, at := range next {
+			if earliest.IsZero() || at.Before(earliest) {
+				earliest = at
+			}
+		}
+
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now().In(utils.JakartaLocation())
+		for _, task := range s.tasks {
+			slot := next[task.Name]
+			if slot.After(now) {
+				continue
+			}
+			next[task.Name] = task.schedule.Next(now)
+			go s.runScheduled(ctx, task, slot)
+		}
+	}
+}
+
+// runScheduled runs one cron slot of a task. Every replica wakes up for the
+// slot; the advisory lock lets one through and LastScheduledAt stops a
+// replica with a slightly late clock from running the slot again.
+func (s *scheduler) runScheduled(ctx context.Context, task parsedTask, slot time.Time) {
+	tx, record, ok, err := s.lock(task.Name)
+	if err != nil {
+		log.Printf("scheduler %s: %v", task.Name, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	if record.LastScheduledAt != nil && !record.LastScheduledAt.Before(slot) {
+		tx.Rollback()
+		return
+	}
+	record.LastScheduledAt = &slot
+
+	s.execute(ctx, tx, task, record)
+}
+
+func (s *scheduler) RunNow(_ context.Context, name string) (dto.ScheduledTaskResponse, error) {
+	task, ok := s.byName[name]
+	if !ok {
+		return dto.ScheduledTaskResponse{}, dto.ErrScheduledTaskNotFound
+	}
+
+	tx, record, ok, err := s.lock(name)
+	if err != nil {
+		return dto.ScheduledTaskResponse{}, err
+	}
+	if !ok {
+		return dto.ScheduledTaskResponse{}, dto.ErrScheduledTaskRunning
+	}
+
+	now := time.Now()
+	record.LastStartedAt = &now
+	record.LastStatus = entity.TaskRunning
+	response := toScheduledTaskResponse(task, record)
+
+	// detached from the request, which ends before the task does
+	go s.execute(context.Background(), tx, task, record)
+
+	return response, nil
+}
+
+// lock opens the transaction that holds the task's advisory lock for the
+// whole run. The transaction is detached from any caller context so that
+// cancelling a task still lets its outcome be recorded.
+func (s *scheduler) lock(name string) (*gorm.DB, entity.ScheduledTask, bool, error) {
+	ctx := context.Background()
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, entity.ScheduledTask{}, false, tx.Error
+	}
+
+	locked, err := s.taskRepo.TryLock(ctx, tx, name)
+	if err != nil || !locked {
+		tx.Rollback()
+		return nil, entity.ScheduledTask{}, false, err
+	}
+
+	record, err := s.taskRepo.GetOrCreate(ctx, tx, name)
+	if err != nil {
+		tx.Rollback()
+		return nil, entity.ScheduledTask{}, false, err
+	}
+
+	return tx, record, true, nil
+}
+
+func (s *scheduler) execute(ctx context.Context, tx *gorm.DB, task parsedTask, record entity.ScheduledTask) {
+	defer SafeRollback(tx)
+
+	started := time.Now()
+	err := runTask(ctx, task.ScheduledTask)
+	finished := time.Now()
+
+	record.LastStartedAt = &started
+	record.LastFinishedAt = &finished
+	record.LastDurationMs = finished.Sub(started).Milliseconds()
+	record.LastStatus = entity.TaskSucceeded
+	record.LastError = ""
+	if err != nil {
+		record.LastStatus = entity.TaskFailed
+		record.LastError = err.Error()
+		log.Printf("scheduler %s failed after %s: %v", task.Name, finished.Sub(started), err)
+	}
+
+	if err := s.taskRepo.Save(context.Background(), tx, record); err != nil {
+		tx.Rollback()
+		log.Printf("scheduler %s: record run: %v", task.Name, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Printf("scheduler %s: record run: %v", task.Name, err)
+	}
+}
+
+func runTask(ctx context.Context, task ScheduledTask) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return task.Run(ctx)
+}
+
+func (s *scheduler) ListTasks(ctx context.Context) ([]dto.ScheduledTaskResponse, error) {
+	records, err := s.taskRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetScheduledTasks
+	}
+
+	byName := make(map[string]entity.ScheduledTask, len(records))
+	for _, record := range records {
+		byName[record.Name] = record
+	}
+
+	datas := make([]dto.ScheduledTaskResponse, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		record, ok := byName[task.Name]
+		if !ok {
+			record = entity.ScheduledTask{Name: task.Name}
+		}
+		datas = append(datas, toScheduledTaskResponse(task, record))
+	}
+
+	return datas, nil
+}
+
+func toScheduledTaskResponse(task parsedTask, record entity.ScheduledTask) dto.ScheduledTaskResponse {
+	response := dto.ScheduledTaskResponse{
+		Name:           task.Name,
+		Description:    task.Description,
+		Schedule:       task.Schedule,
+		NextRunAt:      task.schedule.Next(time.Now().In(utils.JakartaLocation())).Format(time.RFC3339),
+		LastStatus:     string(record.LastStatus),
+		LastError:      record.LastError,
+		LastDurationMs: record.LastDurationMs,
+	}
+
+	if record.LastScheduledAt != nil {
+		response.LastScheduledAt = record.LastScheduledAt.Format(time.RFC3339)
+	}
+	if record.LastStartedAt != nil {
+		response.LastStartedAt = record.LastStartedAt.Format(time.RFC3339)
+	}
+	if record.LastFinishedAt != nil {
+		response.LastFinishedAt = record.LastFinishedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
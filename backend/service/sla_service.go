@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	SLAService interface {
+		GetPolicies(ctx context.Context) ([]dto.SLAPolicyResponse, error)
+		SavePolicy(ctx context.Context, req dto.SaveSLAPolicyRequest) (dto.SLAPolicyResponse, error)
+		DeletePolicy(ctx context.Context, policyId string) error
+		// CheckBreaches is the TASK_SLA_CHECK scheduled task.
+		CheckBreaches(ctx context.Context) error
+	}
+
+	slaService struct {
+		slaRepo     repository.SLAPolicyRepository
+		reportRepo  repository.ReportRepository
+		historyRepo repository.ReportHistoryRepository
+		agencyRepo  repository.AgencyRepository
+		jobService  JobService
+		db          *gorm.DB
+	}
+)
+
+func NewSLAService(
+	slaRepo repository.SLAPolicyRepository,
+	reportRepo repository.ReportRepository,
+	historyRepo repository.ReportHistoryRepository,
+	agencyRepo repository.AgencyRepository,
+	jobService JobService,
+	db *gorm.DB,
+) SLAService {
+	return &slaService{
+		slaRepo:     slaRepo,
+		reportRepo:  reportRepo,
+		historyRepo: historyRepo,
+		agencyRepo:  agencyRepo,
+		jobService:  jobService,
+		db:          db,
+	}
+}
+
+// slaStatuses are the statuses a report can wait in; rejected and completed
+// are final and carry no deadline.
+var slaStatuses = map[entity.ReportStatus]bool{
+	entity.StatusUnverified: true,
+	entity.StatusVerified:   true,
+	entity.StatusHandled:    true,
+}
+
+func (s *slaService) GetPolicies(ctx context.Context) ([]dto.SLAPolicyResponse, error) {
+	policies, err := s.slaRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetSLAPolicies
+	}
+
+	datas := make([]dto.SLAPolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		datas = append(datas, toSLAPolicyResponse(policy))
+	}
+
+	return datas, nil
+}
+
+func (s *slaService) SavePolicy(ctx context.Context, req dto.SaveSLAPolicyRequest) (dto.SLAPolicyResponse, error) {
+	status := entity.ReportStatus(req.Status)
+	if !slaStatuses[status] {
+		return dto.SLAPolicyResponse{}, dto.ErrSLAPolicyStatus
+	}
+
+	emails := make([]string, 0, len(req.SupervisorEmails))
+	for _, email := range req.SupervisorEmails {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+
+	policy, err := s.slaRepo.Save(ctx, nil, entity.SLAPolicy{
+		Class:            strings.TrimSpace(req.Class),
+		Status:           status,
+		DurationMinutes:  req.DurationMinutes,
+		SupervisorEmails: strings.Join(emails, ","),
+	})
+	if err != nil {
+		return dto.SLAPolicyResponse{}, dto.ErrSaveSLAPolicy
+	}
+
+	return toSLAPolicyResponse(policy), nil
+}
+
+func (s *slaService) DeletePolicy(ctx context.Context, policyId string) error {
+	if _, err := uuid.Parse(policyId); err != nil {
+		return dto.ErrSLAPolicyNotFound
+	}
+
+	deleted, err := s.slaRepo.Delete(ctx, nil, policyId)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return dto.ErrSLAPolicyNotFound
+	}
+
+	return nil
+}
+
+// CheckBreaches escalates every report past its SLA deadline once: it is
+// flagged, the breach goes into the history and the supervisors of the
+// policy and of the report's agency get an email.
+func (s *slaService) CheckBreaches(ctx context.Context) error {
+	now := time.Now()
+
+	for {
+		reports, err := s.reportRepo.GetSLABreaches(ctx, nil, now, dto.SLA_BREACH_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		for _, report := range reports {
+			if err := s.escalate(ctx, report, now); err != nil {
+				return fmt.Errorf("escalate report %s: %w", report.ID, err)
+			}
+		}
+
+		if len(reports) < dto.SLA_BREACH_BATCH_SIZE {
+			return nil
+		}
+	}
+}
+
+func (s *slaService) escalate(ctx context.Context, report entity.Report, now time.Time) error {
+	recipients := s.supervisors(ctx, report)
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	marked, err := s.reportRepo.MarkSLAEscalated(ctx, tx, report.ID.String(), now)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !marked {
+		tx.Rollback()
+		return nil
+	}
+
+	note := fmt.Sprintf("Melewati tenggat SLA status %s (%s)",
+		report.Status, report.SLADueAt.In(utils.JakartaLocation()).Format("2006-01-02 15:04"))
+	// history is public, so it counts the supervisors instead of naming them
+	if len(recipients) > 0 {
+		note += fmt.Sprintf(", dieskalasi ke %d penyelia", len(recipients))
+	}
+
+	if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+		ReportID: report.ID,
+		Event:    entity.HistorySLAEscalated,
+		Note:     note,
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(recipients) > 0 {
+		mail, err := utils.RenderEmail(utils.EmailSLAEscalation, dto.SLAEscalationMail{
+			ReportID: report.ID.String(),
+			Class:    reportClass(report),
+			Status:   string(report.Status),
+			Location: report.Location,
+			DueAt:    report.SLADueAt.In(utils.JakartaLocation()),
+		})
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		for _, recipient := range recipients {
+			mail.To = recipient
+			if _, err := s.jobService.Enqueue(ctx, tx, dto.JOB_SEND_EMAIL, mail); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// supervisors collects the addresses of the report's SLA policy and the
+// supervisors of its agency, without duplicates.
+func (s *slaService) supervisors(ctx context.Context, report entity.Report) []string {
+	seen := map[string]bool{}
+	var recipients []string
+	add := func(email string) {
+		key := strings.ToLower(email)
+		if email == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		recipients = append(recipients, email)
+	}
+
+	if report.SLAPolicyID != nil {
+		if policy, err := s.slaRepo.GetById(ctx, nil, report.SLAPolicyID.String()); err == nil {
+			for _, email := range policy.Emails() {
+				add(email)
+			}
+		}
+	}
+
+	if report.AgencyID != nil {
+		members, err := s.agencyRepo.GetMembers(ctx, nil, report.AgencyID.String())
+		if err != nil {
+			log.Printf("sla: agency supervisors of report %s: %v", report.ID, err)
+		}
+		for _, member := range members {
+			if member.Role == entity.AgencySupervisor {
+				add(member.User.Email)
+			}
+		}
+	}
+
+	return recipients
+}
+
+// ResolveSLAPolicy picks the policy for the class, falling back to the
+// class-wide default of the status.
+func ResolveSLAPolicy(policies []entity.SLAPolicy, class string, status entity.ReportStatus) *entity.SLAPolicy {
+	var fallback *entity.SLAPolicy
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Status != status {
+			continue
+		}
+		if class != "" && policy.Class == class {
+			return policy
+		}
+		if policy.Class == "" {
+			fallback = policy
+		}
+	}
+	return fallback
+}
+
+func toSLAPolicyResponse(policy entity.SLAPolicy) dto.SLAPolicyResponse {
+	emails := policy.Emails()
+	if emails == nil {
+		emails = []string{}
+	}
+
+	return dto.SLAPolicyResponse{
+		ID:               policy.ID.String(),
+		Class:            policy.Class,
+		Status:           string(policy.Status),
+		DurationMinutes:  policy.DurationMinutes,
+		SupervisorEmails: emails,
+	}
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+)
+
+const (
+	// exportFilePrefix is where finished spreadsheets are stored, so the
+	// API can serve what a worker wrote.
+	exportFilePrefix     = "exports/"
+	exportDateTimeLayout = "2006-01-02 15:04:05"
+)
+
+type spreadsheetColumn struct {
+	Key    string
+	Header string
+	Value  func(report entity.Report, reached map[entity.ReportStatus]time.Time) any
+}
+
+func statusReachedColumn(key, header string, status entity.ReportStatus) spreadsheetColumn {
+	return spreadsheetColumn{key, header, func(_ entity.Report, reached map[entity.ReportStatus]time.Time) any {
+		if at, ok := reached[status]; ok {
+			return formatJakarta(at)
+		}
+		return ""
+	}}
+}
+
+// spreadsheetColumns is also the default column order.
+var spreadsheetColumns = []spreadsheetColumn{
+	{"id", "ID", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return r.ID.String() }},
+	{"status", "Status", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return string(r.Status) }},
+	{"class", "Kelas", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return reportClass(r) }},
+	{"confidence", "Confidence", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any {
+		if r.PredConfidence == nil {
+			return ""
+		}
+		return *r.PredConfidence
+	}},
+	{"location", "Lokasi", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return r.Location }},
+	{"latitude", "Latitude", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any {
+		if lat, _, ok := r.Coordinates(); ok {
+			return lat
+		}
+		return ""
+	}},
+	{"longitude", "Longitude", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any {
+		if _, lng, ok := r.Coordinates(); ok {
+			return lng
+		}
+		return ""
+	}},
+	{"reporter_name", "Pelapor", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return r.User.Name }},
+	{"upvotes", "Upvotes", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return r.Upvotes }},
+	{"text", "Isi Laporan", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return r.Text }},
+	{"created_at", "Dibuat", func(r entity.Report, _ map[entity.ReportStatus]time.Time) any { return formatJakarta(r.CreatedAt) }},
+	statusReachedColumn("verified_at", "Diverifikasi", entity.StatusVerified),
+	statusReachedColumn("rejected_at", "Ditolak", entity.StatusRejected),
+	statusReachedColumn("handled_at", "Ditangani", entity.StatusHandled),
+	statusReachedColumn("completed_at", "Selesai", entity.StatusCompleted),
+}
+
+func formatJakarta(t time.Time) string {
+	return t.In(utils.JakartaLocation()).Format(exportDateTimeLayout)
+}
+
+func selectSpreadsheetColumns(columns string) ([]spreadsheetColumn, error) {
+	if strings.TrimSpace(columns) == "" {
+		return spreadsheetColumns, nil
+	}
+
+	byKey := make(map[string]spreadsheetColumn, len(spreadsheetColumns))
+	for _, column := range spreadsheetColumns {
+		byKey[column.Key] = column
+	}
+
+	var selected []spreadsheetColumn
+	for _, key := range strings.Split(columns, ",") {
+		column, ok := byKey[strings.TrimSpace(key)]
+		if !ok {
+			return nil, dto.ErrInvalidExportColumn
+		}
+		selected = append(selected, column)
+	}
+	return selected, nil
+}
+
+func (s *exportService) ValidateSpreadsheetExport(req dto.SpreadsheetExportRequest) error {
+	if req.Format != utils.SPREADSHEET_CSV && req.Format != utils.SPREADSHEET_XLSX {
+		return dto.ErrInvalidExportFormat
+	}
+
+	if _, err := selectSpreadsheetColumns(req.Columns); err != nil {
+		return err
+	}
+
+	return req.ReportFilterRequest.Validate()
+}
+
+func (s *exportService) ShouldExportInBackground(ctx context.Context, req dto.SpreadsheetExportRequest) (bool, error) {
+	if req.Async {
+		return true, nil
+	}
+
+	count, err := s.reportRepo.CountReports(ctx, nil, req.ReportFilterRequest)
+	if err != nil {
+		return false, dto.ErrExportReports
+	}
+
+	return count > dto.EXPORT_SYNC_LIMIT, nil
+}
+
+func (s *exportService) ExportSpreadsheet(ctx context.Context, req dto.SpreadsheetExportRequest, w io.Writer) error {
+	_, err := s.writeSpreadsheet(ctx, req, w)
+	return err
+}
+
+func (s *exportService) writeSpreadsheet(ctx context.Context, req dto.SpreadsheetExportRequest, w io.Writer) (int, error) {
+	columns, err := selectSpreadsheetColumns(req.Columns)
+	if err != nil {
+		return 0, err
+	}
+
+	writer, err := utils.NewRowWriter(req.Format, w)
+	if err != nil {
+		return 0, dto.ErrInvalidExportFormat
+	}
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column.Header
+	}
+	if err := writer.Write(header); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err = s.reportRepo.StreamReports(ctx, nil, req.ReportFilterRequest, dto.EXPORT_BATCH_SIZE, func(reports []entity.Report) error {
+		ids := make([]uuid.UUID, len(reports))
+		for i, report := range reports {
+			ids[i] = report.ID
+		}
+
+		reached, err := s.historyRepo.FirstStatusTimes(ctx, nil, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, report := range reports {
+			row := make([]any, len(columns))
+			for i, column := range columns {
+				row[i] = column.Value(report, reached[report.ID])
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+			rows++
+		}
+		return nil
+	})
+	if err != nil {
+		return rows, dto.ErrExportReports
+	}
+
+	return rows, writer.Close()
+}
+
+func (s *exportService) StartSpreadsheetExport(ctx context.Context, req dto.SpreadsheetExportRequest, userId string) (dto.ReportExportResponse, error) {
+	requestedBy, err := uuid.Parse(userId)
+	if err != nil {
+		return dto.ReportExportResponse{}, dto.ErrUserNotFound
+	}
+
+	filter, err := json.Marshal(req)
+	if err != nil {
+		return dto.ReportExportResponse{}, dto.ErrExportReports
+	}
+
+	export, err := s.exportRepo.Create(ctx, nil, entity.ReportExport{
+		RequestedBy: requestedBy,
+		Format:      req.Format,
+		Filter:      string(filter),
+		Status:      entity.ExportPending,
+	})
+	if err != nil {
+		return dto.ReportExportResponse{}, dto.ErrExportReports
+	}
+
+	if _, err := s.jobService.Enqueue(ctx, nil, dto.JOB_REPORT_EXPORT, dto.ReportExportJob{
+		ExportID: export.ID.String(),
+	}); err != nil {
+		export.Status = entity.ExportFailed
+		export.Error = err.Error()
+		if _, err := s.exportRepo.Update(ctx, nil, export); err != nil {
+			log.Printf("report export %s: %v", export.ID, err)
+		}
+		return dto.ReportExportResponse{}, dto.ErrExportReports
+	}
+
+	return toReportExportResponse(export), nil
+}
+
+// RunSpreadsheetExport is the JOB_REPORT_EXPORT handler. A returned error
+// makes the job queue retry the export.
+func (s *exportService) RunSpreadsheetExport(ctx context.Context, job dto.ReportExportJob) error {
+	export, err := s.exportRepo.GetById(ctx, nil, job.ExportID)
+	if err != nil {
+		return PermanentJobError(dto.ErrExportNotFound)
+	}
+	if export.Status == entity.ExportCompleted {
+		return nil
+	}
+
+	var req dto.SpreadsheetExportRequest
+	if err := json.Unmarshal([]byte(export.Filter), &req); err != nil {
+		return PermanentJobError(err)
+	}
+
+	export.Status = entity.ExportRunning
+	export.Error = ""
+	if _, err := s.exportRepo.Update(ctx, nil, export); err != nil {
+		return err
+	}
+
+	rows, err := s.writeSpreadsheetFile(ctx, &export, req)
+
+	now := time.Now()
+	export.CompletedAt = &now
+	export.RowCount = rows
+	if err != nil {
+		export.Status = entity.ExportFailed
+		export.Error = err.Error()
+	} else {
+		export.Status = entity.ExportCompleted
+	}
+
+	if _, updateErr := s.exportRepo.Update(ctx, nil, export); updateErr != nil {
+		log.Printf("report export %s: %v", export.ID, updateErr)
+	}
+
+	return err
+}
+
+// writeSpreadsheetFile stores the export and records its key on export,
+// which the caller saves once the job settles.
+func (s *exportService) writeSpreadsheetFile(ctx context.Context, export *entity.ReportExport, req dto.SpreadsheetExportRequest) (int, error) {
+	var buf bytes.Buffer
+	rows, err := s.writeSpreadsheet(ctx, req, &buf)
+	if err != nil {
+		return rows, err
+	}
+
+	key := fmt.Sprintf("%s%s.%s", exportFilePrefix, export.ID, export.Format)
+	if err := s.fileRepo.Save(ctx, nil, entity.StoredFile{Key: key, Content: buf.Bytes()}); err != nil {
+		return rows, err
+	}
+	export.FileKey = key
+
+	return rows, nil
+}
+
+func (s *exportService) GetReportExport(ctx context.Context, exportId string) (dto.ReportExportResponse, error) {
+	export, err := s.exportRepo.GetById(ctx, nil, exportId)
+	if err != nil {
+		return dto.ReportExportResponse{}, dto.ErrExportNotFound
+	}
+
+	return toReportExportResponse(export), nil
+}
+
+func (s *exportService) GetReportExportFile(ctx context.Context, exportId string) ([]byte, string, error) {
+	export, err := s.exportRepo.GetById(ctx, nil, exportId)
+	if err != nil {
+		return nil, "", dto.ErrExportNotFound
+	}
+
+	if export.Status != entity.ExportCompleted || export.FileKey == "" {
+		return nil, "", dto.ErrExportNotReady
+	}
+
+	file, err := s.fileRepo.Get(ctx, nil, export.FileKey)
+	if err != nil {
+		return nil, "", dto.ErrExportNotFound
+	}
+
+	filename := fmt.Sprintf("laporan-%s.%s", export.CreatedAt.In(utils.JakartaLocation()).Format("20060102-150405"), export.Format)
+	return file.Content, filename, nil
+}
+
+func (s *exportService) PurgeExports(ctx context.Context) error {
+	before := time.Now().Add(-dto.EXPORT_RETENTION)
+
+	if _, err := s.exportRepo.DeleteBefore(ctx, nil, before); err != nil {
+		return err
+	}
+	// files are written after their export is created, so none outlives it
+	_, err := s.fileRepo.DeleteBefore(ctx, nil, exportFilePrefix, before)
+	return err
+}
+
+func toReportExportResponse(export entity.ReportExport) dto.ReportExportResponse {
+	response := dto.ReportExportResponse{
+		ID:        export.ID.String(),
+		Format:    export.Format,
+		Status:    string(export.Status),
+		RowCount:  export.RowCount,
+		Error:     export.Error,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+	}
+
+	if export.CompletedAt != nil {
+		response.CompletedAt = export.CompletedAt.Format(time.RFC3339)
+	}
+
+	if export.Status == entity.ExportCompleted {
+		response.DownloadURL = fmt.Sprintf("/api/admin/reports/exports/%s/download", export.ID)
+	}
+
+	return response
+}
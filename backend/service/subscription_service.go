@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	SubscriptionService interface {
+		CreateSubscription(ctx context.Context, userId string, req dto.CreateSubscriptionRequest) (dto.SubscriptionResponse, error)
+		GetSubscriptions(ctx context.Context, userId string) ([]dto.SubscriptionResponse, error)
+		DeleteSubscription(ctx context.Context, userId string, subscriptionId string) error
+		// MatchSubscriptions is the JOB_MATCH_SUBSCRIPTIONS handler.
+		MatchSubscriptions(ctx context.Context, job dto.MatchSubscriptionsJob) error
+	}
+
+	subscriptionService struct {
+		subscriptionRepo    repository.SubscriptionRepository
+		reportRepo          repository.ReportRepository
+		notificationService NotificationService
+	}
+)
+
+func NewSubscriptionService(
+	subscriptionRepo repository.SubscriptionRepository,
+	reportRepo repository.ReportRepository,
+	notificationService NotificationService,
+) SubscriptionService {
+	return &subscriptionService{
+		subscriptionRepo:    subscriptionRepo,
+		reportRepo:          reportRepo,
+		notificationService: notificationService,
+	}
+}
+
+// subscriptionTitles is the notification title per report event.
+var subscriptionTitles = map[string]string{
+	dto.SUBSCRIPTION_EVENT_CREATED:   "Laporan baru",
+	dto.SUBSCRIPTION_EVENT_VERIFIED:  "Laporan terverifikasi",
+	dto.SUBSCRIPTION_EVENT_COMPLETED: "Laporan selesai ditangani",
+}
+
+func (s *subscriptionService) CreateSubscription(
+	ctx context.Context,
+	userId string,
+	req dto.CreateSubscriptionRequest,
+) (dto.SubscriptionResponse, error) {
+	ownerId, err := uuid.Parse(userId)
+	if err != nil {
+		return dto.SubscriptionResponse{}, dto.ErrUserNotFound
+	}
+
+	if err := req.Validate(); err != nil {
+		return dto.SubscriptionResponse{}, err
+	}
+
+	count, err := s.subscriptionRepo.CountByUserId(ctx, nil, userId)
+	if err != nil {
+		return dto.SubscriptionResponse{}, dto.ErrCreateSubscription
+	}
+	if count >= dto.SUBSCRIPTION_MAX_PER_USER {
+		return dto.SubscriptionResponse{}, dto.ErrSubscriptionLimit
+	}
+
+	subscription := entity.Subscription{
+		UserID:  ownerId,
+		Label:   req.Label,
+		Classes: strings.Join(req.NormalizedClasses(), ","),
+	}
+
+	var tag *entity.Tag
+	if req.TagID != "" {
+		found, err := s.reportRepo.GetTagById(ctx, nil, req.TagID)
+		if err != nil {
+			return dto.SubscriptionResponse{}, dto.ErrSubscriptionTagMissing
+		}
+		tag = &found
+		subscription.Kind = entity.SubscriptionTag
+		subscription.TagID = &found.ID
+	} else {
+		radius := req.RadiusMeters
+		subscription.Kind = entity.SubscriptionArea
+		subscription.Latitude = req.Latitude
+		subscription.Longitude = req.Longitude
+		subscription.RadiusMeters = &radius
+	}
+
+	created, err := s.subscriptionRepo.Create(ctx, nil, subscription)
+	if err != nil {
+		return dto.SubscriptionResponse{}, dto.ErrCreateSubscription
+	}
+	created.Tag = tag
+
+	return toSubscriptionResponse(created), nil
+}
+
+func (s *subscriptionService) GetSubscriptions(ctx context.Context, userId string) ([]dto.SubscriptionResponse, error) {
+	subscriptions, err := s.subscriptionRepo.GetByUserId(ctx, nil, userId)
+	if err != nil {
+		return nil, dto.ErrGetSubscriptions
+	}
+
+	datas := make([]dto.SubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		datas = append(datas, toSubscriptionResponse(subscription))
+	}
+
+	return datas, nil
+}
+
+func (s *subscriptionService) DeleteSubscription(ctx context.Context, userId string, subscriptionId string) error {
+	if _, err := uuid.Parse(subscriptionId); err != nil {
+		return dto.ErrSubscriptionNotFound
+	}
+
+	deleted, err := s.subscriptionRepo.Delete(ctx, nil, userId, subscriptionId)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return dto.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// MatchSubscriptions notifies every subscriber the report matches, once per
+// user and never the reporter. Failed notifications are logged rather than
+// retried so a retry cannot notify the others twice.
+func (s *subscriptionService) MatchSubscriptions(ctx context.Context, job dto.MatchSubscriptionsJob) error {
+	title, ok := subscriptionTitles[job.Event]
+	if !ok {
+		return PermanentJobError(fmt.Errorf("unknown subscription event %q", job.Event))
+	}
+
+	report, err := s.reportRepo.GetReportById(ctx, nil, job.ReportID)
+	if err != nil {
+		return PermanentJobError(dto.ErrGetReportById)
+	}
+
+	var lat, lng *float64
+	if reportLat, reportLng, ok := report.Coordinates(); ok {
+		lat, lng = &reportLat, &reportLng
+	}
+
+	candidates, err := s.subscriptionRepo.GetCandidates(ctx, nil, report.TagID, lat, lng)
+	if err != nil {
+		return err
+	}
+
+	notified := map[uuid.UUID]bool{}
+	for _, subscription := range candidates {
+		if notified[subscription.UserID] || subscription.UserID.String() == report.UserID {
+			continue
+		}
+		if !subscription.Matches(report) {
+			continue
+		}
+		notified[subscription.UserID] = true
+
+		if err := s.notificationService.Notify(ctx, subscription.UserID.String(), dto.NotificationMessage{
+			Type:     entity.NotificationSubscription,
+			Title:    title,
+			Body:     subscriptionBody(subscription, report),
+			ReportID: report.ID.String(),
+		}); err != nil {
+			log.Printf("notify subscription %s: %v", subscription.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func subscriptionBody(subscription entity.Subscription, report entity.Report) string {
+	where := report.Location
+	if where == "" {
+		where = "lokasi tanpa nama"
+	}
+
+	body := fmt.Sprintf("Laporan %s di %s", reportClass(report), where)
+	if subscription.Label != "" {
+		body += fmt.Sprintf(" (langganan \"%s\")", subscription.Label)
+	}
+	return body + "."
+}
+
+func toSubscriptionResponse(subscription entity.Subscription) dto.SubscriptionResponse {
+	data := dto.SubscriptionResponse{
+		ID:           subscription.ID.String(),
+		Kind:         string(subscription.Kind),
+		Label:        subscription.Label,
+		Latitude:     subscription.Latitude,
+		Longitude:    subscription.Longitude,
+		RadiusMeters: subscription.RadiusMeters,
+		Classes:      subscription.ClassList(),
+		CreatedAt:    subscription.CreatedAt.Format(time.RFC3339),
+	}
+	if data.Classes == nil {
+		data.Classes = []string{}
+	}
+	if subscription.TagID != nil {
+		data.TagID = subscription.TagID.String()
+	}
+	if subscription.Tag != nil {
+		data.TagLocation = subscription.Tag.Location
+	}
+	return data
+}
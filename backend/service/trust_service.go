@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	TrustService interface {
+		GetTrust(ctx context.Context, userId string) (dto.TrustResponse, error)
+		SetOverride(ctx context.Context, userId string, req dto.SetTrustRequest, adminId string) (dto.TrustResponse, error)
+		ClearOverride(ctx context.Context, userId string) (dto.TrustResponse, error)
+		// CheckReportQuota fails when the user may not file a report right
+		// now, and otherwise returns the policy the report falls under. It
+		// holds the user's report lock until tx ends, so the report should
+		// be filed in tx.
+		CheckReportQuota(ctx context.Context, tx *gorm.DB, userId string) (dto.TrustPolicy, error)
+	}
+
+	trustService struct {
+		userRepo     repository.UserRepository
+		reportRepo   repository.ReportRepository
+		overrideRepo repository.UserTrustOverrideRepository
+	}
+)
+
+func NewTrustService(
+	userRepo repository.UserRepository,
+	reportRepo repository.ReportRepository,
+	overrideRepo repository.UserTrustOverrideRepository,
+) TrustService {
+	return &trustService{
+		userRepo:     userRepo,
+		reportRepo:   reportRepo,
+		overrideRepo: overrideRepo,
+	}
+}
+
+var trustPolicies = map[entity.TrustLevel]dto.TrustPolicy{
+	entity.TrustLow:    {MaxReportsPerDay: 3, Cooldown: 10 * time.Minute, HoldForReview: true},
+	entity.TrustNormal: {MaxReportsPerDay: 10, Cooldown: 2 * time.Minute},
+	entity.TrustHigh:   {MaxReportsPerDay: 50},
+}
+
+func TrustPolicyFor(level entity.TrustLevel) dto.TrustPolicy {
+	policy, ok := trustPolicies[level]
+	if !ok {
+		policy = trustPolicies[entity.TrustLow]
+	}
+	policy.CooldownSeconds = int64(policy.Cooldown / time.Second)
+	return policy
+}
+
+// TrustScore weighs a user from 0 to 100: 20 points for a verified email,
+// up to 20 for an account three months old, up to 40 for how many of
+// their reports held up (half of it without a record) and up to 20 for the
+// upvotes those reports gathered. A record of mostly rejected reports
+// drags the whole score down with it.
+func TrustScore(input dto.TrustInput) int {
+	score := 0.0
+	if input.EmailVerified {
+		score += 20
+	}
+
+	maturity := math.Min(max(input.AccountAge.Hours(), 0)/(90*24), 1)
+	score += 20 * maturity
+
+	record := input.Record
+	accuracy := float64(record.Accepted+1) / float64(record.Accepted+record.Rejected+2)
+	score += 40 * accuracy
+
+	support := math.Min(float64(max(record.Upvotes, 0))/50, 1)
+	score += 20 * support
+
+	if accuracy < 0.5 {
+		score *= 2 * accuracy
+	}
+
+	return int(math.Round(score))
+}
+
+func TrustLevelFor(score int) entity.TrustLevel {
+	switch {
+	case score < dto.TRUST_LOW_BELOW:
+		return entity.TrustLow
+	case score >= dto.TRUST_HIGH_FROM:
+		return entity.TrustHigh
+	}
+	return entity.TrustNormal
+}
+
+// CheckQuota tells whether one more report fits the policy given the
+// user's recent activity.
+func CheckQuota(policy dto.TrustPolicy, activity dto.ReportActivity, now time.Time) error {
+	if activity.Count >= int64(policy.MaxReportsPerDay) {
+		return dto.ErrReportQuotaExceeded
+	}
+	if policy.Cooldown > 0 && activity.LastAt != nil && now.Sub(*activity.LastAt) < policy.Cooldown {
+		return dto.ErrReportCooldown
+	}
+	return nil
+}
+
+func (s *trustService) GetTrust(ctx context.Context, userId string) (dto.TrustResponse, error) {
+	user, err := s.getUser(ctx, userId)
+	if err != nil {
+		return dto.TrustResponse{}, err
+	}
+
+	return s.evaluate(ctx, user, time.Now())
+}
+
+func (s *trustService) SetOverride(ctx context.Context, userId string, req dto.SetTrustRequest, adminId string) (dto.TrustResponse, error) {
+	level := entity.TrustLevel(req.Level)
+	if !level.IsValid() {
+		return dto.TrustResponse{}, dto.ErrInvalidTrustLevel
+	}
+
+	user, err := s.getUser(ctx, userId)
+	if err != nil {
+		return dto.TrustResponse{}, err
+	}
+
+	if err := s.overrideRepo.Save(ctx, nil, entity.UserTrustOverride{
+		UserID:  user.ID,
+		Level:   level,
+		Note:    strings.TrimSpace(req.Note),
+		SetByID: parseActorId(adminId),
+	}); err != nil {
+		return dto.TrustResponse{}, dto.ErrSetTrust
+	}
+
+	return s.evaluate(ctx, user, time.Now())
+}
+
+func (s *trustService) ClearOverride(ctx context.Context, userId string) (dto.TrustResponse, error) {
+	user, err := s.getUser(ctx, userId)
+	if err != nil {
+		return dto.TrustResponse{}, err
+	}
+
+	if err := s.overrideRepo.Delete(ctx, nil, userId); err != nil {
+		return dto.TrustResponse{}, dto.ErrSetTrust
+	}
+
+	return s.evaluate(ctx, user, time.Now())
+}
+
+func (s *trustService) CheckReportQuota(ctx context.Context, tx *gorm.DB, userId string) (dto.TrustPolicy, error) {
+	user, err := s.getUser(ctx, userId)
+	if err != nil {
+		return dto.TrustPolicy{}, err
+	}
+
+	// count and file under the lock so parallel requests cannot all pass
+	// on the same count
+	if err := s.reportRepo.LockUserReports(ctx, tx, userId); err != nil {
+		return dto.TrustPolicy{}, dto.ErrGetTrust
+	}
+
+	now := time.Now()
+	trust, err := s.evaluate(ctx, user, now)
+	if err != nil {
+		return dto.TrustPolicy{}, err
+	}
+
+	activity, err := s.reportRepo.GetReportActivity(ctx, tx, userId, now.Add(-dto.TRUST_QUOTA_WINDOW))
+	if err != nil {
+		return dto.TrustPolicy{}, dto.ErrGetTrust
+	}
+	if err := CheckQuota(trust.Policy, activity, now); err != nil {
+		return dto.TrustPolicy{}, err
+	}
+
+	return trust.Policy, nil
+}
+
+func (s *trustService) getUser(ctx context.Context, userId string) (entity.User, error) {
+	if _, err := uuid.Parse(userId); err != nil {
+		return entity.User{}, dto.ErrUserNotFound
+	}
+
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return entity.User{}, dto.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// evaluate scores the user and applies an admin override. Staff are
+// always trusted.
+func (s *trustService) evaluate(ctx context.Context, user entity.User, now time.Time) (dto.TrustResponse, error) {
+	userId := user.ID.String()
+
+	record, err := s.reportRepo.GetVoterRecord(ctx, nil, userId)
+	if err != nil {
+		return dto.TrustResponse{}, dto.ErrGetTrust
+	}
+	activity, err := s.reportRepo.GetReportActivity(ctx, nil, userId, now.Add(-dto.TRUST_QUOTA_WINDOW))
+	if err != nil {
+		return dto.TrustResponse{}, dto.ErrGetTrust
+	}
+
+	score := TrustScore(dto.TrustInput{
+		EmailVerified: user.IsVerified,
+		AccountAge:    now.Sub(user.CreatedAt),
+		Record:        record,
+	})
+	computed := TrustLevelFor(score)
+	if user.Role == constants.ENUM_ROLE_ADMIN || user.Role == constants.ENUM_ROLE_OFFICER {
+		computed = entity.TrustHigh
+	}
+
+	response := dto.TrustResponse{
+		UserID:        userId,
+		Score:         score,
+		ComputedLevel: string(computed),
+		Level:         string(computed),
+		ReportsToday:  activity.Count,
+		Accepted:      record.Accepted,
+		Rejected:      record.Rejected,
+		Upvotes:       record.Upvotes,
+	}
+
+	override, err := s.overrideRepo.GetByUserId(ctx, nil, userId)
+	switch {
+	case err == nil:
+		response.Override = string(override.Level)
+		response.OverrideNote = override.Note
+		response.Level = string(override.Level)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return dto.TrustResponse{}, dto.ErrGetTrust
+	}
+
+	response.Policy = TrustPolicyFor(entity.TrustLevel(response.Level))
+	return response, nil
+}
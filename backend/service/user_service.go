@@ -1,12 +1,9 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"html/template"
-	"os"
 	"strings"
 	"time"
 
@@ -30,10 +27,12 @@ type (
 		SendVerificationEmail(ctx context.Context, req dto.SendVerificationEmailRequest) error
 		VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) (dto.VerifyEmailResponse, error)
 		Update(ctx context.Context, req dto.UserUpdateRequest, userId string) (dto.UserUpdateResponse, error)
+		UpdateRole(ctx context.Context, userId string, role string) (dto.UserResponse, error)
 		Delete(ctx context.Context, userId string) error
 		Verify(ctx context.Context, req dto.UserLoginRequest) (dto.TokenResponse, error)
 		RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (dto.TokenResponse, error)
 		RevokeRefreshToken(ctx context.Context, userID string) error
+		PurgeExpiredRefreshTokens(ctx context.Context) error
 	}
 
 	userService struct {
@@ -119,45 +118,29 @@ func (s *userService) Register(ctx context.Context, req dto.UserCreateRequest) (
 	}, nil
 }
 
-func makeVerificationEmail(receiverEmail string) (map[string]string, error) {
+func makeVerificationEmail(receiverEmail string) (utils.Mail, error) {
 	expired := time.Now().Add(time.Hour * 24).Format("2006-01-02 15:04:05")
 	plainText := receiverEmail + "_" + expired
 	token, err := utils.AESEncrypt(plainText)
 	if err != nil {
-		return nil, err
+		return utils.Mail{}, err
 	}
 
 	verifyLink := LOCAL_URL + "/" + VERIFY_EMAIL_ROUTE + "?token=" + token
 
-	readHtml, err := os.ReadFile("utils/email-template/base_mail.html")
-	if err != nil {
-		return nil, err
-	}
-
-	data := struct {
+	mail, err := utils.RenderEmail(utils.EmailVerification, struct {
 		Email  string
 		Verify string
 	}{
 		Email:  receiverEmail,
 		Verify: verifyLink,
-	}
-
-	tmpl, err := template.New("custom").Parse(string(readHtml))
+	})
 	if err != nil {
-		return nil, err
-	}
-
-	var strMail bytes.Buffer
-	if err := tmpl.Execute(&strMail, data); err != nil {
-		return nil, err
-	}
-
-	draftEmail := map[string]string{
-		"subject": "Cakno - Go Gin Template",
-		"body":    strMail.String(),
+		return utils.Mail{}, err
 	}
+	mail.To = receiverEmail
 
-	return draftEmail, nil
+	return mail, nil
 }
 
 func (s *userService) SendVerificationEmail(ctx context.Context, req dto.SendVerificationEmailRequest) error {
@@ -166,17 +149,17 @@ func (s *userService) SendVerificationEmail(ctx context.Context, req dto.SendVer
 		return dto.ErrEmailNotFound
 	}
 
-	draftEmail, err := makeVerificationEmail(user.Email)
+	mail, err := makeVerificationEmail(user.Email)
 	if err != nil {
 		return err
 	}
 
-	err = utils.SendMail(user.Email, draftEmail["subject"], draftEmail["body"])
+	mailer, err := utils.DefaultMailer()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return mailer.Send(ctx, mail)
 }
 
 func (s *userService) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) (dto.VerifyEmailResponse, error) {
@@ -332,6 +315,18 @@ func (s *userService) Update(ctx context.Context, req dto.UserUpdateRequest, use
 	}, nil
 }
 
+func (s *userService) UpdateRole(ctx context.Context, userId string, role string) (dto.UserResponse, error) {
+	if _, err := s.userRepo.GetUserById(ctx, nil, userId); err != nil {
+		return dto.UserResponse{}, dto.ErrUserNotFound
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, nil, userId, role); err != nil {
+		return dto.UserResponse{}, dto.ErrUpdateUser
+	}
+
+	return s.GetUserById(ctx, userId)
+}
+
 func (s *userService) Delete(ctx context.Context, userId string) error {
 	tx := s.db.Begin()
 	defer SafeRollback(tx)
@@ -485,3 +480,7 @@ func (s *userService) RevokeRefreshToken(ctx context.Context, userID string) err
 
 	return nil
 }
+
+func (s *userService) PurgeExpiredRefreshTokens(ctx context.Context) error {
+	return s.refreshTokenRepo.DeleteExpired(ctx, nil)
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+)
+
+type (
+	VoteService interface {
+		VoteReport(ctx context.Context, reportId string, req dto.VoteReportRequest, userId string) (dto.ReportVotesResponse, error)
+		GetVotes(ctx context.Context, reportId string, userId string) (dto.ReportVotesResponse, error)
+		UpvoteReport(ctx context.Context, reportId string, userId string) (dto.UpvoteResponse, error)
+		RemoveUpvote(ctx context.Context, reportId string, userId string) (dto.UpvoteResponse, error)
+	}
+
+	voteService struct {
+		*ReportWorkflow
+		voteRepo           repository.ReportVoteRepository
+		upvoteRepo         repository.ReportUpvoteRepository
+		verificationConfig config.VerificationConfig
+	}
+)
+
+func NewVoteService(
+	workflow *ReportWorkflow,
+	voteRepo repository.ReportVoteRepository,
+	upvoteRepo repository.ReportUpvoteRepository,
+	verificationConfig config.VerificationConfig,
+) VoteService {
+	return &voteService{
+		ReportWorkflow:     workflow,
+		voteRepo:           voteRepo,
+		upvoteRepo:         upvoteRepo,
+		verificationConfig: verificationConfig,
+	}
+}
+
+// VoteReport records a verified citizen's confirm or dispute vote. Once
+// the votes reach the quorum the report is verified when enough of the
+// weight confirms it, and flagged for an admin otherwise.
+func (s *voteService) VoteReport(ctx context.Context, reportId string, req dto.VoteReportRequest, userId string) (dto.ReportVotesResponse, error) {
+	kind := entity.VoteKind(req.Kind)
+	if !kind.IsValid() {
+		return dto.ReportVotesResponse{}, dto.ErrInvalidVoteKind
+	}
+
+	voter, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ReportVotesResponse{}, dto.ErrUserNotFound
+	}
+	if !voter.IsVerified {
+		return dto.ReportVotesResponse{}, dto.ErrVoterNotVerified
+	}
+
+	record, err := s.reportRepo.GetVoterRecord(ctx, nil, userId)
+	if err != nil {
+		return dto.ReportVotesResponse{}, dto.ErrVoteReport
+	}
+	weight := VoteWeight(time.Since(voter.CreatedAt), record, s.verificationConfig.FullWeightAge)
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportForUpdate(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.ReportVotesResponse{}, dto.ErrGetReportById
+	}
+	if report.UserID == userId {
+		tx.Rollback()
+		return dto.ReportVotesResponse{}, dto.ErrVoteOwnReport
+	}
+	if report.Status == entity.StatusPendingReview {
+		tx.Rollback()
+		return dto.ReportVotesResponse{}, dto.ErrReportHeld
+	}
+	if report.Status != entity.StatusUnverified {
+		tx.Rollback()
+		return dto.ReportVotesResponse{}, dto.ErrReportNotUnverified
+	}
+
+	if err := s.voteRepo.Save(ctx, tx, entity.ReportVote{
+		ReportID: report.ID,
+		UserID:   voter.ID,
+		Kind:     kind,
+		Weight:   weight,
+	}); err != nil {
+		tx.Rollback()
+		return dto.ReportVotesResponse{}, dto.ErrVoteReport
+	}
+
+	votes, err := s.voteRepo.GetByReportId(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.ReportVotesResponse{}, dto.ErrVoteReport
+	}
+	tally := TallyVotes(votes)
+
+	status := report.Status
+	flagged := false
+	reached, confirmed := CommunityVerdict(tally, s.verificationConfig.Quorum, s.verificationConfig.ConfirmRatio)
+	switch {
+	case reached && confirmed:
+		if _, err := s.changeStatus(ctx, tx, report, entity.StatusVerified, "", "Dikonfirmasi oleh warga"); err != nil {
+			tx.Rollback()
+			return dto.ReportVotesResponse{}, dto.ErrVoteReport
+		}
+		status = entity.StatusVerified
+	case reached:
+		flagged, err = s.reportRepo.MarkVoteFlagged(ctx, tx, reportId, time.Now())
+		if err != nil {
+			tx.Rollback()
+			return dto.ReportVotesResponse{}, dto.ErrVoteReport
+		}
+		if flagged {
+			if _, err := s.historyRepo.Create(ctx, tx, entity.ReportHistory{
+				ReportID: report.ID,
+				Event:    entity.HistoryVoteFlagged,
+				Note:     fmt.Sprintf("Konfirmasi %.2f, sanggahan %.2f", tally.ConfirmWeight, tally.DisputeWeight),
+			}); err != nil {
+				tx.Rollback()
+				return dto.ReportVotesResponse{}, dto.ErrVoteReport
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.ReportVotesResponse{}, dto.ErrVoteReport
+	}
+
+	if status == entity.StatusVerified {
+		s.notifyOwner(ctx, report, dto.NotificationMessage{
+			Type:  entity.NotificationStatusChanged,
+			Title: "Laporan Anda terverifikasi",
+			Body:  "Warga sekitar mengonfirmasi laporan Anda.",
+		})
+	}
+	if flagged {
+		s.notifyVoteFlagged(ctx, report, tally)
+	}
+
+	report.Status = status
+	if flagged {
+		now := time.Now()
+		report.VoteFlaggedAt = &now
+	}
+	return s.toReportVotesResponse(report, votes, tally, voter.ID), nil
+}
+
+// notifyVoteFlagged asks the admins to settle a report the community
+// could not agree on.
+func (s *voteService) notifyVoteFlagged(ctx context.Context, report entity.Report, tally dto.VoteTally) {
+	admins, err := s.userRepo.GetUsersByRole(ctx, nil, constants.ENUM_ROLE_ADMIN)
+	if err != nil {
+		log.Printf("vote flagged: admins for report %s: %v", report.ID, err)
+		return
+	}
+
+	for _, admin := range admins {
+		if err := s.notificationService.Notify(ctx, admin.ID.String(), dto.NotificationMessage{
+			Type:     entity.NotificationVoteFlagged,
+			Title:    "Laporan perlu ditinjau",
+			Body:     fmt.Sprintf("Suara warga tidak sepakat: konfirmasi %.2f, sanggahan %.2f.", tally.ConfirmWeight, tally.DisputeWeight),
+			ReportID: report.ID.String(),
+		}); err != nil {
+			log.Printf("notify admin %s of flagged report %s: %v", admin.ID, report.ID, err)
+		}
+	}
+}
+
+func (s *voteService) GetVotes(ctx context.Context, reportId string, userId string) (dto.ReportVotesResponse, error) {
+	report, err := s.reportRepo.GetReportById(ctx, nil, reportId)
+	if err != nil || !s.canSeeReport(ctx, report, userId) {
+		return dto.ReportVotesResponse{}, dto.ErrGetReportById
+	}
+
+	votes, err := s.voteRepo.GetByReportId(ctx, nil, reportId)
+	if err != nil {
+		return dto.ReportVotesResponse{}, dto.ErrGetVotes
+	}
+
+	voterId, _ := uuid.Parse(userId)
+	return s.toReportVotesResponse(report, votes, TallyVotes(votes), voterId), nil
+}
+
+func (s *voteService) toReportVotesResponse(
+	report entity.Report,
+	votes []entity.ReportVote,
+	tally dto.VoteTally,
+	voterId uuid.UUID,
+) dto.ReportVotesResponse {
+	response := dto.ReportVotesResponse{
+		ReportID:  report.ID.String(),
+		Status:    string(report.Status),
+		VoteTally: tally,
+		Quorum:    s.verificationConfig.Quorum,
+		Flagged:   report.VoteFlaggedAt != nil,
+	}
+	for _, vote := range votes {
+		if vote.UserID == voterId {
+			response.MyVote = string(vote.Kind)
+			response.MyWeight = vote.Weight
+		}
+	}
+
+	return response
+}
+
+// VoteWeight grows from 0.5 for a new account to 1 once the account is
+// fullWeightAge old, and is scaled by how often the voter's own reports
+// held up: from nearly 0 for a voter whose reports were all rejected to
+// nearly 2 for one whose reports were all accepted. Voters without a
+// record count as half right.
+func VoteWeight(accountAge time.Duration, record dto.VoterRecord, fullWeightAge time.Duration) float64 {
+	maturity := 1.0
+	if fullWeightAge > 0 && accountAge < fullWeightAge {
+		maturity = max(accountAge.Hours(), 0) / fullWeightAge.Hours()
+	}
+
+	accuracy := float64(record.Accepted+1) / float64(record.Accepted+record.Rejected+2)
+	return (0.5 + 0.5*maturity) * 2 * accuracy
+}
+
+func TallyVotes(votes []entity.ReportVote) dto.VoteTally {
+	var tally dto.VoteTally
+	for _, vote := range votes {
+		switch vote.Kind {
+		case entity.VoteConfirm:
+			tally.ConfirmWeight += vote.Weight
+			tally.ConfirmCount++
+		case entity.VoteDispute:
+			tally.DisputeWeight += vote.Weight
+			tally.DisputeCount++
+		}
+	}
+	return tally
+}
+
+// CommunityVerdict tells whether the votes reached the quorum and, if so,
+// whether at least confirmRatio of their weight confirms the report.
+func CommunityVerdict(tally dto.VoteTally, quorum float64, confirmRatio float64) (reached bool, confirmed bool) {
+	total := tally.ConfirmWeight + tally.DisputeWeight
+	if total <= 0 || total < quorum {
+		return false, false
+	}
+	return true, tally.ConfirmWeight/total >= confirmRatio
+}
+
+// UpvoteReport adds the user's support to a report. The reporter earns a
+// point for the first upvote of each user.
+func (s *voteService) UpvoteReport(ctx context.Context, reportId string, userId string) (dto.UpvoteResponse, error) {
+	voterId, err := uuid.Parse(userId)
+	if err != nil {
+		return dto.UpvoteResponse{}, dto.ErrUserNotFound
+	}
+
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportById(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.UpvoteResponse{}, dto.ErrGetReportById
+	}
+	if report.UserID == userId {
+		tx.Rollback()
+		return dto.UpvoteResponse{}, dto.ErrUpvoteOwnReport
+	}
+	// upvotes would pay the reporter before anyone reviewed the report
+	if report.Status == entity.StatusPendingReview {
+		tx.Rollback()
+		return dto.UpvoteResponse{}, dto.ErrReportHeld
+	}
+
+	created, err := s.upvoteRepo.Create(ctx, tx, entity.ReportUpvote{ReportID: report.ID, UserID: voterId})
+	if err != nil {
+		tx.Rollback()
+		return dto.UpvoteResponse{}, dto.ErrUpvoteReport
+	}
+
+	upvotes := report.Upvotes
+	if created {
+		upvotes, err = s.reportRepo.AddUpvotes(ctx, tx, reportId, 1)
+		if err != nil {
+			tx.Rollback()
+			return dto.UpvoteResponse{}, dto.ErrUpvoteReport
+		}
+		if err := s.awardReputation(ctx, tx, report, entity.ReputationReportUpvoted, dto.REPUTATION_POINTS_UPVOTED, userId); err != nil {
+			tx.Rollback()
+			return dto.UpvoteResponse{}, dto.ErrUpvoteReport
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.UpvoteResponse{}, dto.ErrUpvoteReport
+	}
+
+	return dto.UpvoteResponse{ReportID: report.ID.String(), Upvotes: upvotes, Upvoted: true}, nil
+}
+
+// RemoveUpvote withdraws the user's support. The reporter keeps the point,
+// which is never paid twice for the same user.
+func (s *voteService) RemoveUpvote(ctx context.Context, reportId string, userId string) (dto.UpvoteResponse, error) {
+	tx := s.db.Begin()
+	defer SafeRollback(tx)
+
+	report, err := s.reportRepo.GetReportById(ctx, tx, reportId)
+	if err != nil {
+		tx.Rollback()
+		return dto.UpvoteResponse{}, dto.ErrGetReportById
+	}
+
+	deleted, err := s.upvoteRepo.Delete(ctx, tx, reportId, userId)
+	if err != nil {
+		tx.Rollback()
+		return dto.UpvoteResponse{}, dto.ErrUpvoteReport
+	}
+
+	upvotes := report.Upvotes
+	if deleted {
+		upvotes, err = s.reportRepo.AddUpvotes(ctx, tx, reportId, -1)
+		if err != nil {
+			tx.Rollback()
+			return dto.UpvoteResponse{}, dto.ErrUpvoteReport
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.UpvoteResponse{}, dto.ErrUpvoteReport
+	}
+
+	return dto.UpvoteResponse{ReportID: report.ID.String(), Upvotes: upvotes, Upvoted: false}, nil
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_ResolveAgencyRoute(t *testing.T) {
+	publicWorks, parks, cityWorks := uuid.New(), uuid.New(), uuid.New()
+	routes := []entity.AgencyRoute{
+		{Class: "jalan_rusak", AgencyID: publicWorks},
+		{Class: "jalan_rusak", Region: "Surabaya", AgencyID: cityWorks},
+		{Class: "pohon_tumbang", AgencyID: parks},
+	}
+
+	route := service.ResolveAgencyRoute(routes, []string{"jalan_rusak"}, "Jl. Darmo, surabaya")
+	assert.Equal(t, cityWorks, route.AgencyID)
+
+	route = service.ResolveAgencyRoute(routes, []string{"jalan_rusak"}, "Malang")
+	assert.Equal(t, publicWorks, route.AgencyID)
+
+	// the first class with a route wins
+	route = service.ResolveAgencyRoute(routes, []string{"banjir", "pohon_tumbang"}, "")
+	assert.Equal(t, parks, route.AgencyID)
+
+	assert.Nil(t, service.ResolveAgencyRoute(routes, []string{"banjir"}, "Surabaya"))
+}
+
+// fakeStreamedReportRepository records the filter of the last stream.
+type fakeStreamedReportRepository struct {
+	fakeReportRepository
+	streamed dto.ReportFilterRequest
+}
+
+func (r *fakeStreamedReportRepository) StreamReports(ctx context.Context, tx *gorm.DB, req dto.ReportFilterRequest, batchSize int, fn func([]entity.Report) error) error {
+	r.streamed = req
+	return nil
+}
+
+func Test_ExportGeoJSON_AgencyScope(t *testing.T) {
+	agencyId := uuid.NewString()
+	reportRepo := &fakeStreamedReportRepository{}
+	exportController := controller.NewExportController(service.NewExportService(reportRepo, nil, nil, nil, nil))
+
+	r := SetUpRoutes()
+	r.GET("/api/reports/export/geojson", func(ctx *gin.Context) {
+		ctx.Set("agency_ids", []string{agencyId})
+	}, exportController.ExportGeoJSON)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/reports/export/geojson", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{agencyId}, reportRepo.streamed.AgencyIDs)
+}
+
+func Test_CreateComment_AgencyScope(t *testing.T) {
+	agencyId := uuid.New()
+	staff := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_OFFICER}
+	report := entity.Report{ID: uuid.New(), Status: entity.StatusVerified, AgencyID: &agencyId}
+	commentService := service.NewCommentService(nil,
+		&fakeReportRepository{reports: []entity.Report{report}},
+		&fakeUserRepository{users: []entity.User{staff}},
+		nil,
+	)
+
+	// staff of another agency cannot comment on the report
+	_, err := commentService.CreateComment(context.Background(), report.ID.String(),
+		dto.CreateCommentRequest{Body: "Sudah dicek"}, staff.ID.String(), []string{uuid.NewString()})
+	assert.Equal(t, dto.ErrGetReportById, err)
+}
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type fakeAnalyticsRepository struct {
+	repository.AnalyticsRepository
+	counts     []dto.ReportBucketCount
+	compliance []dto.SLAComplianceCount
+
+	from, to time.Time
+}
+
+func (r *fakeAnalyticsRepository) CountReportsByBucket(
+	ctx context.Context,
+	tx *gorm.DB,
+	interval string,
+	groupBy string,
+	from time.Time,
+	to time.Time,
+) ([]dto.ReportBucketCount, error) {
+	r.from, r.to = from, to
+	return r.counts, nil
+}
+
+func (r *fakeAnalyticsRepository) MedianTimeToStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	status entity.ReportStatus,
+	from time.Time,
+	to time.Time,
+) (dto.ReportTransitionMedian, error) {
+	return dto.ReportTransitionMedian{}, nil
+}
+
+func (r *fakeAnalyticsRepository) SLACompliance(ctx context.Context, tx *gorm.DB, from time.Time, to time.Time) ([]dto.SLAComplianceCount, error) {
+	return r.compliance, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func Test_ReportAnalytics_WeeklyBuckets(t *testing.T) {
+	analyticsRepo := &fakeAnalyticsRepository{
+		counts: []dto.ReportBucketCount{
+			{Bucket: "2025-03-10", Key: "pothole", Count: 2},
+			{Bucket: "2025-03-10", Key: "flood", Count: 1},
+			{Bucket: "2025-03-17", Key: "flood", Count: 5},
+			// outside the requested range
+			{Bucket: "2025-02-24", Key: "pothole", Count: 9},
+		},
+	}
+	analyticsService := service.NewAnalyticsService(analyticsRepo)
+
+	// Wednesday to the Tuesday two weeks later
+	result, err := analyticsService.GetReportAnalytics(context.Background(), dto.ReportAnalyticsRequest{
+		From:     date(2025, 3, 5),
+		To:       date(2025, 3, 18),
+		Interval: dto.ANALYTICS_INTERVAL_WEEK,
+		GroupBy:  dto.ANALYTICS_GROUP_CLASS,
+	})
+	assert.NoError(t, err)
+
+	// weeks start on Monday, like date_trunc
+	assert.Equal(t, []string{"2025-03-03", "2025-03-10", "2025-03-17"}, result.Buckets)
+	assert.Equal(t, []int64{0, 3, 5}, result.Totals)
+	assert.Len(t, result.Series, 2)
+	assert.Equal(t, dto.ReportAnalyticsSeries{Key: "flood", Counts: []int64{0, 1, 5}, Total: 6}, result.Series[0])
+	assert.Equal(t, dto.ReportAnalyticsSeries{Key: "pothole", Counts: []int64{0, 2, 0}, Total: 2}, result.Series[1])
+
+	// the days are Jakarta days, and the last one is included
+	loc := utils.JakartaLocation()
+	assert.True(t, time.Date(2025, 3, 5, 0, 0, 0, 0, loc).Equal(analyticsRepo.from), analyticsRepo.from)
+	assert.True(t, time.Date(2025, 3, 19, 0, 0, 0, 0, loc).Equal(analyticsRepo.to), analyticsRepo.to)
+}
+
+func Test_ReportAnalytics_MonthlyBuckets(t *testing.T) {
+	analyticsService := service.NewAnalyticsService(&fakeAnalyticsRepository{})
+
+	result, err := analyticsService.GetReportAnalytics(context.Background(), dto.ReportAnalyticsRequest{
+		From:     date(2025, 1, 31),
+		To:       date(2025, 3, 1),
+		Interval: dto.ANALYTICS_INTERVAL_MONTH,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2025-01-01", "2025-02-01", "2025-03-01"}, result.Buckets)
+	assert.Equal(t, []int64{0, 0, 0}, result.Totals)
+	assert.Empty(t, result.Series)
+}
+
+func Test_ReportAnalytics_DefaultsToDays(t *testing.T) {
+	analyticsService := service.NewAnalyticsService(&fakeAnalyticsRepository{})
+
+	result, err := analyticsService.GetReportAnalytics(context.Background(), dto.ReportAnalyticsRequest{
+		From: date(2025, 2, 27),
+		To:   date(2025, 3, 1),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, dto.ANALYTICS_INTERVAL_DAY, result.Interval)
+	assert.Equal(t, []string{"2025-02-27", "2025-02-28", "2025-03-01"}, result.Buckets)
+}
+
+func Test_ReportAnalytics_SLACompliance(t *testing.T) {
+	analyticsService := service.NewAnalyticsService(&fakeAnalyticsRepository{
+		compliance: []dto.SLAComplianceCount{
+			{Status: string(entity.StatusVerified), Met: 3, Total: 4},
+			{Status: string(entity.StatusHandled), Met: 1, Total: 4},
+			{Status: string(entity.StatusCompleted), Met: 0, Total: 0},
+		},
+	})
+
+	result, err := analyticsService.GetReportAnalytics(context.Background(), dto.ReportAnalyticsRequest{
+		From: date(2025, 3, 1),
+		To:   date(2025, 3, 1),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 75.0, *result.Metrics.SLACompliance[0].Percent)
+	assert.Nil(t, result.Metrics.SLACompliance[2].Percent)
+	assert.Equal(t, 50.0, *result.Metrics.SLACompliancePercent)
+}
+
+func Test_ReportAnalytics_Rejects(t *testing.T) {
+	analyticsService := service.NewAnalyticsService(&fakeAnalyticsRepository{})
+
+	tests := []struct {
+		name string
+		req  dto.ReportAnalyticsRequest
+		err  error
+	}{
+		{"unknown interval", dto.ReportAnalyticsRequest{Interval: "hour"}, dto.ErrInvalidAnalyticsInterval},
+		{"unknown group", dto.ReportAnalyticsRequest{GroupBy: "user"}, dto.ErrInvalidAnalyticsGroup},
+		{"reversed dates", dto.ReportAnalyticsRequest{From: date(2025, 3, 2), To: date(2025, 3, 1)}, dto.ErrInvalidDateRange},
+		{"too many days", dto.ReportAnalyticsRequest{From: date(2024, 1, 1), To: date(2025, 3, 1)}, dto.ErrAnalyticsRangeTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := analyticsService.GetReportAnalytics(context.Background(), tt.req)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_CountReportsByBucket_ExcludesHeld(t *testing.T) {
+	db := SetUpDryRunDatabase()
+	var sql string
+	db.Callback().Row().After("gorm:row").Register("test:analytics", func(tx *gorm.DB) {
+		sql = tx.Statement.SQL.String()
+	})
+
+	// a dry run cannot scan rows, only the statement matters here
+	repository.NewAnalyticsRepository(db).CountReportsByBucket(context.Background(), nil,
+		dto.ANALYTICS_INTERVAL_DAY, dto.ANALYTICS_GROUP_STATUS, date(2025, 3, 1), date(2025, 3, 2))
+
+	assert.Contains(t, sql, "reports.status <> $")
+}
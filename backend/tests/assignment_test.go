@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CanChangeReportStatus(t *testing.T) {
+	officer := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_OFFICER}
+	other := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_OFFICER}
+	admin := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_ADMIN}
+	reporter := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_USER}
+
+	report := entity.Report{Status: entity.StatusHandled, AssigneeID: &officer.ID}
+
+	assert.True(t, service.CanChangeReportStatus(officer, report, entity.StatusCompleted))
+	assert.False(t, service.CanChangeReportStatus(officer, report, entity.StatusRejected))
+	assert.False(t, service.CanChangeReportStatus(other, report, entity.StatusCompleted))
+	assert.False(t, service.CanChangeReportStatus(reporter, report, entity.StatusCompleted))
+	assert.True(t, service.CanChangeReportStatus(admin, report, entity.StatusRejected))
+
+	report.Status = entity.StatusVerified
+	assert.False(t, service.CanChangeReportStatus(officer, report, entity.StatusHandled))
+
+	report.Status = entity.StatusCompleted
+	assert.True(t, service.CanChangeReportStatus(officer, report, entity.StatusHandled))
+}
+
+func Test_AssignReport_Rejects(t *testing.T) {
+	officer := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_OFFICER}
+	reporter := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_USER}
+	unverified := entity.Report{ID: uuid.New(), Status: entity.StatusUnverified}
+	assignmentService := service.NewAssignmentService(SetupReportWorkflow(
+		&fakeUserRepository{users: []entity.User{officer, reporter}},
+		&fakeReportRepository{reports: []entity.Report{unverified}},
+	), nil)
+	ctx := context.Background()
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	_, err := assignmentService.AssignReport(ctx, unverified.ID.String(),
+		dto.AssignReportRequest{OfficerID: officer.ID.String(), DueAt: time.Now().Add(-time.Hour)}, "")
+	assert.Equal(t, dto.ErrInvalidDueDate, err)
+
+	_, err = assignmentService.AssignReport(ctx, unverified.ID.String(),
+		dto.AssignReportRequest{OfficerID: reporter.ID.String(), DueAt: tomorrow}, "")
+	assert.Equal(t, dto.ErrAssigneeNotOfficer, err)
+
+	_, err = assignmentService.AssignReport(ctx, unverified.ID.String(),
+		dto.AssignReportRequest{OfficerID: officer.ID.String(), DueAt: tomorrow}, "")
+	assert.Equal(t, dto.ErrReportNotAssignable, err)
+
+	_, err = assignmentService.UnassignReport(ctx, unverified.ID.String(), "")
+	assert.Equal(t, dto.ErrReportNotAssigned, err)
+}
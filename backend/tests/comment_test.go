@@ -0,0 +1,313 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeCommentRepository filters its comments the way the queries do and
+// records what is written.
+type fakeCommentRepository struct {
+	repository.CommentRepository
+	comments []entity.Comment
+
+	created []entity.Comment
+	updated []string
+}
+
+func (r *fakeCommentRepository) Create(ctx context.Context, tx *gorm.DB, comment entity.Comment) (entity.Comment, error) {
+	comment.ID = uuid.New()
+	r.created = append(r.created, comment)
+	return comment, nil
+}
+
+func (r *fakeCommentRepository) GetById(ctx context.Context, tx *gorm.DB, commentId string) (entity.Comment, error) {
+	for _, comment := range r.comments {
+		if comment.ID.String() == commentId {
+			return comment, nil
+		}
+	}
+	return entity.Comment{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeCommentRepository) GetThreads(
+	ctx context.Context,
+	tx *gorm.DB,
+	reportId string,
+	includeInternal bool,
+	req dto.PaginationRequest,
+) (dto.GetAllCommentRepositoryResponse, error) {
+	var threads []entity.Comment
+	for _, comment := range r.comments {
+		if comment.ReportID.String() == reportId && comment.ParentID == nil && (includeInternal || comment.Visibility == entity.CommentPublic) {
+			threads = append(threads, comment)
+		}
+	}
+	return dto.GetAllCommentRepositoryResponse{Comments: threads}, nil
+}
+
+func (r *fakeCommentRepository) GetReplies(ctx context.Context, tx *gorm.DB, parentIds []uuid.UUID, includeInternal bool) ([]entity.Comment, error) {
+	var replies []entity.Comment
+	for _, comment := range r.comments {
+		if comment.ParentID != nil && (includeInternal || comment.Visibility == entity.CommentPublic) {
+			replies = append(replies, comment)
+		}
+	}
+	return replies, nil
+}
+
+func (r *fakeCommentRepository) GetParticipants(ctx context.Context, tx *gorm.DB, reportId string) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *fakeCommentRepository) UpdateBody(ctx context.Context, tx *gorm.DB, commentId string, body string, at time.Time) error {
+	r.updated = append(r.updated, commentId)
+	return nil
+}
+
+// commentThread is a report with a public and an internal thread, each with
+// a reply, a comment hidden by a moderator and a deleted one.
+type commentThread struct {
+	reporter, admin entity.User
+	report          entity.Report
+
+	public, internal, publicReply, internalReply, hidden, deleted entity.Comment
+}
+
+func newCommentThread() commentThread {
+	c := commentThread{
+		reporter: entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_USER},
+		admin:    entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_ADMIN},
+	}
+	c.report = entity.Report{ID: uuid.New(), UserID: c.reporter.ID.String(), Status: entity.StatusVerified}
+
+	comment := func(visibility entity.CommentVisibility, body string, parent *entity.Comment) entity.Comment {
+		comment := entity.Comment{ID: uuid.New(), ReportID: c.report.ID, UserID: c.reporter.ID, Visibility: visibility, Body: body}
+		if parent != nil {
+			comment.ParentID = &parent.ID
+		}
+		return comment
+	}
+	deletedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	c.public = comment(entity.CommentPublic, "Lubangnya makin besar", nil)
+	c.internal = comment(entity.CommentInternal, "Tunggu anggaran bulan depan", nil)
+	c.internal.UserID = c.admin.ID
+	c.publicReply = comment(entity.CommentPublic, "Sudah kami jadwalkan", &c.public)
+	c.internalReply = comment(entity.CommentInternal, "Kontraktor sudah dihubungi", &c.internal)
+	c.hidden = comment(entity.CommentPublic, "Kata-kata kasar", nil)
+	c.hidden.Hidden = true
+	c.hidden.ModerationReason = "Bahasa kasar"
+	c.deleted = comment(entity.CommentPublic, "Salah laporan", nil)
+	c.deleted.DeletedAt = &deletedAt
+	return c
+}
+
+func (c commentThread) service() (service.CommentService, *fakeCommentRepository) {
+	commentRepo := &fakeCommentRepository{
+		comments: []entity.Comment{c.public, c.internal, c.publicReply, c.internalReply, c.hidden, c.deleted},
+	}
+	commentService := service.NewCommentService(
+		commentRepo,
+		&fakeReportRepository{reports: []entity.Report{c.report}},
+		&fakeUserRepository{users: []entity.User{c.reporter, c.admin}},
+		nil,
+	)
+	return commentService, commentRepo
+}
+
+func Test_ParseMentions(t *testing.T) {
+	got := service.ParseMentions("@Budi@Example.com tolong cek, cc @siti@dinas.go.id. lihat juga @budi@example.com dan email@tanpa-mention.com")
+	assert.Equal(t, []string{"budi@example.com", "siti@dinas.go.id"}, got)
+}
+
+func Test_ParseMentionsLimit(t *testing.T) {
+	var body strings.Builder
+	for i := 0; i < dto.COMMENT_MAX_MENTIONS+5; i++ {
+		body.WriteString(" @user")
+		body.WriteByte(byte('a' + i))
+		body.WriteString("@example.com")
+	}
+
+	assert.Len(t, service.ParseMentions(body.String()), dto.COMMENT_MAX_MENTIONS)
+}
+
+func Test_GetComments_Visibility(t *testing.T) {
+	c := newCommentThread()
+	commentService, _ := c.service()
+	ctx := context.Background()
+
+	// the reporter sees neither the internal thread nor its reply
+	result, err := commentService.GetComments(ctx, c.report.ID.String(), dto.PaginationRequest{}, c.reporter.ID.String(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 3)
+	assert.Equal(t, c.public.ID.String(), result.Data[0].ID)
+	assert.Len(t, result.Data[0].Replies, 1)
+	assert.Equal(t, "Sudah kami jadwalkan", result.Data[0].Replies[0].Body)
+	for _, comment := range result.Data {
+		assert.Equal(t, string(entity.CommentPublic), comment.Visibility)
+	}
+
+	result, err = commentService.GetComments(ctx, c.report.ID.String(), dto.PaginationRequest{}, c.admin.ID.String(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 4)
+	assert.Equal(t, c.internal.ID.String(), result.Data[1].ID)
+	assert.Equal(t, "Kontraktor sudah dihubungi", result.Data[1].Replies[0].Body)
+}
+
+func Test_GetComments_HiddenAndDeletedBodies(t *testing.T) {
+	c := newCommentThread()
+	commentService, _ := c.service()
+	ctx := context.Background()
+
+	bodies := func(viewer entity.User) map[string]dto.CommentResponse {
+		result, err := commentService.GetComments(ctx, c.report.ID.String(), dto.PaginationRequest{}, viewer.ID.String(), nil)
+		assert.NoError(t, err)
+		comments := map[string]dto.CommentResponse{}
+		for _, comment := range result.Data {
+			comments[comment.ID] = comment
+		}
+		return comments
+	}
+
+	// the moderation reason explains the blank body to everyone
+	hidden := bodies(c.reporter)[c.hidden.ID.String()]
+	assert.True(t, hidden.Hidden)
+	assert.Empty(t, hidden.Body)
+	assert.Equal(t, "Bahasa kasar", hidden.ModerationReason)
+	assert.Equal(t, "Kata-kata kasar", bodies(c.admin)[c.hidden.ID.String()].Body)
+
+	// nobody reads a deleted comment
+	for _, viewer := range []entity.User{c.reporter, c.admin} {
+		deleted := bodies(viewer)[c.deleted.ID.String()]
+		assert.True(t, deleted.Deleted)
+		assert.Empty(t, deleted.Body)
+	}
+}
+
+func Test_CommentRepository_InternalFilter(t *testing.T) {
+	db := SetUpDryRunDatabase()
+	var statements []string
+	db.Callback().Query().After("gorm:query").Register("test:comments", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	})
+	commentRepo := repository.NewCommentRepository(db)
+	ctx := context.Background()
+
+	_, err := commentRepo.GetThreads(ctx, nil, uuid.NewString(), false, dto.PaginationRequest{})
+	assert.NoError(t, err)
+	_, err = commentRepo.GetReplies(ctx, nil, []uuid.UUID{uuid.New()}, false)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, statements)
+	for _, sql := range statements {
+		assert.Contains(t, sql, "visibility = $")
+	}
+
+	statements = nil
+	_, err = commentRepo.GetThreads(ctx, nil, uuid.NewString(), true, dto.PaginationRequest{})
+	assert.NoError(t, err)
+	_, err = commentRepo.GetReplies(ctx, nil, []uuid.UUID{uuid.New()}, true)
+	assert.NoError(t, err)
+	for _, sql := range statements {
+		assert.NotContains(t, sql, "visibility")
+	}
+}
+
+func Test_CreateComment_Replies(t *testing.T) {
+	c := newCommentThread()
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		author entity.User
+		req    dto.CreateCommentRequest
+		err    error
+	}{
+		{"internal by a citizen", c.reporter, dto.CreateCommentRequest{Body: "Kapan?", Visibility: string(entity.CommentInternal)}, dto.ErrInternalCommentDenied},
+		{"public reply to internal", c.admin, dto.CreateCommentRequest{Body: "Segera", ParentID: c.internal.ID.String()}, dto.ErrCommentVisibilityReply},
+		{"unknown parent", c.reporter, dto.CreateCommentRequest{Body: "Kapan?", ParentID: uuid.NewString()}, dto.ErrCommentNotFound},
+		{"parent not a uuid", c.reporter, dto.CreateCommentRequest{Body: "Kapan?", ParentID: "1"}, dto.ErrCommentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService, commentRepo := c.service()
+			_, err := commentService.CreateComment(ctx, c.report.ID.String(), tt.req, tt.author.ID.String(), nil)
+			assert.Equal(t, tt.err, err)
+			assert.Empty(t, commentRepo.created)
+		})
+	}
+
+	t.Run("parent on another report", func(t *testing.T) {
+		commentService, commentRepo := c.service()
+		commentRepo.comments[0].ReportID = uuid.New()
+		_, err := commentService.CreateComment(ctx, c.report.ID.String(),
+			dto.CreateCommentRequest{Body: "Kapan?", ParentID: c.public.ID.String()}, c.reporter.ID.String(), nil)
+		assert.Equal(t, dto.ErrCommentParentMismatch, err)
+	})
+
+	t.Run("internal reply to internal", func(t *testing.T) {
+		commentService, commentRepo := c.service()
+		result, err := commentService.CreateComment(ctx, c.report.ID.String(), dto.CreateCommentRequest{
+			Body: "Anggaran cair", Visibility: string(entity.CommentInternal), ParentID: c.internal.ID.String(),
+		}, c.admin.ID.String(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, c.internal.ID.String(), result.ParentID)
+		assert.Len(t, commentRepo.created, 1)
+	})
+
+	t.Run("reply to a reply", func(t *testing.T) {
+		commentService, commentRepo := c.service()
+		result, err := commentService.CreateComment(ctx, c.report.ID.String(),
+			dto.CreateCommentRequest{Body: "Terima kasih", ParentID: c.publicReply.ID.String()}, c.reporter.ID.String(), nil)
+		assert.NoError(t, err)
+		// threads are one level deep
+		assert.Equal(t, c.public.ID.String(), result.ParentID)
+		assert.Equal(t, &c.public.ID, commentRepo.created[0].ParentID)
+	})
+}
+
+func Test_UpdateComment_AuthorOnly(t *testing.T) {
+	c := newCommentThread()
+	ctx := context.Background()
+	req := dto.UpdateCommentRequest{Body: "Lubangnya sudah sebesar ban mobil"}
+
+	tests := []struct {
+		name    string
+		comment entity.Comment
+		editor  entity.User
+		err     error
+	}{
+		{"another user", c.internal, c.reporter, dto.ErrCommentForbidden},
+		{"an admin", c.public, c.admin, dto.ErrCommentForbidden},
+		{"hidden", c.hidden, c.reporter, dto.ErrCommentForbidden},
+		{"deleted", c.deleted, c.reporter, dto.ErrCommentDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService, commentRepo := c.service()
+			_, err := commentService.UpdateComment(ctx, tt.comment.ID.String(), req, tt.editor.ID.String())
+			assert.Equal(t, tt.err, err)
+			assert.Empty(t, commentRepo.updated)
+		})
+	}
+
+	commentService, commentRepo := c.service()
+	result, err := commentService.UpdateComment(ctx, c.public.ID.String(), req, c.reporter.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.Edited)
+	assert.Equal(t, req.Body, result.Body)
+	assert.Equal(t, []string{c.public.ID.String()}, commentRepo.updated)
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func photo(name string, size int64) *multipart.FileHeader {
+	return &multipart.FileHeader{Filename: name, Size: size}
+}
+
+func Test_DistanceMeters(t *testing.T) {
+	assert.Zero(t, utils.DistanceMeters(-7.2575, 112.7521, -7.2575, 112.7521))
+	// a hundredth of a degree of latitude is about 1.1 km
+	assert.InDelta(t, 1112, utils.DistanceMeters(-7.2575, 112.7521, -7.2675, 112.7521), 1)
+}
+
+func Test_UpdateReportStatus_CompletionEvidence(t *testing.T) {
+	lat, lng := -7.2575, 112.7521
+	farLat, outOfRange := -7.2675, 95.0
+	admin := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_ADMIN}
+	report := entity.Report{ID: uuid.New(), Status: entity.StatusHandled, Latitude: &lat, Longitude: &lng}
+	reportService := SetupReportService(
+		&fakeUserRepository{users: []entity.User{admin}},
+		&fakeReportRepository{reports: []entity.Report{report}},
+	)
+
+	valid := []*multipart.FileHeader{photo("after.jpg", 1024)}
+	tooMany := make([]*multipart.FileHeader, dto.COMPLETION_MAX_PHOTOS+1)
+	for i := range tooMany {
+		tooMany[i] = photo("after.png", 1024)
+	}
+
+	tests := []struct {
+		name string
+		req  dto.UpdateStatusReportRequest
+		err  error
+	}{
+		{"no note", dto.UpdateStatusReportRequest{Photos: valid, Note: "  "}, dto.ErrEvidenceRequired},
+		{"no photos", dto.UpdateStatusReportRequest{Note: "Lubang sudah ditambal"}, dto.ErrEvidenceRequired},
+		{"too many photos", dto.UpdateStatusReportRequest{Photos: tooMany, Note: "Lubang sudah ditambal"}, dto.ErrTooManyEvidencePhotos},
+		{"not an image", dto.UpdateStatusReportRequest{
+			Photos: []*multipart.FileHeader{photo("after.gif", 1024)}, Note: "Lubang sudah ditambal",
+		}, dto.ErrInvalidImageExtension},
+		{"empty photo", dto.UpdateStatusReportRequest{
+			Photos: []*multipart.FileHeader{photo("after.jpg", 0)}, Note: "Lubang sudah ditambal",
+		}, dto.ErrEmptyFileUploaded},
+		{"photo too large", dto.UpdateStatusReportRequest{
+			Photos: []*multipart.FileHeader{photo("after.jpg", service.MaxImageSize+1)}, Note: "Lubang sudah ditambal",
+		}, dto.ErrImageSizeTooLarge},
+		{"half a geotag", dto.UpdateStatusReportRequest{
+			Photos: valid, Note: "Lubang sudah ditambal", Latitude: &lat,
+		}, dto.ErrInvalidCoordinates},
+		{"geotag out of range", dto.UpdateStatusReportRequest{
+			Photos: valid, Note: "Lubang sudah ditambal", Latitude: &outOfRange, Longitude: &lng,
+		}, dto.ErrInvalidCoordinates},
+		{"geotag too far", dto.UpdateStatusReportRequest{
+			Photos: valid, Note: "Lubang sudah ditambal", Latitude: &farLat, Longitude: &lng,
+		}, dto.ErrEvidenceTooFar},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Status = entity.StatusCompleted
+			_, err := reportService.UpdateReportStatus(context.Background(), report.ID.String(), tt.req, admin.ID.String())
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
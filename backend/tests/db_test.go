@@ -54,3 +54,16 @@ func Test_DBConnection(t *testing.T) {
 	assert.NoError(t, db.Error, "Expected no error during database connection")
 	assert.NotNil(t, db, "Expected a non-nil database connection")
 }
+
+// SetUpDryRunDatabase builds statements without running them, so the SQL a
+// scope produces can be checked without a database.
+func SetUpDryRunDatabase() *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: "host=localhost",
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		panic("Failed to open dry run database: " + err.Error())
+	}
+
+	return db
+}
@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeEventBroker hands every subscriber the same replay and live events,
+// then closes the live channel as if the client fell behind.
+type fakeEventBroker struct {
+	service.EventBroker
+	replay []dto.ReportEvent
+	live   []dto.ReportEvent
+
+	lastEventId  int64
+	unsubscribed bool
+}
+
+func (b *fakeEventBroker) Subscribe(lastEventId int64) (<-chan dto.ReportEvent, []dto.ReportEvent, func()) {
+	b.lastEventId = lastEventId
+
+	ch := make(chan dto.ReportEvent, len(b.live))
+	for _, event := range b.live {
+		ch <- event
+	}
+	close(ch)
+
+	return ch, b.replay, func() { b.unsubscribed = true }
+}
+
+type fakeUserService struct {
+	service.UserService
+	user dto.UserResponse
+}
+
+func (s *fakeUserService) GetUserById(ctx context.Context, userId string) (dto.UserResponse, error) {
+	return s.user, nil
+}
+
+func streamEvents(t *testing.T, broker *fakeEventBroker, user dto.UserResponse, lastEventId string) string {
+	r := SetUpRoutes()
+	r.GET("/api/events", func(ctx *gin.Context) {
+		ctx.Set("user_id", user.ID)
+	}, controller.NewEventController(broker, &fakeUserService{user: user}).Stream)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/events", nil)
+	if lastEventId != "" {
+		req.Header.Set("Last-Event-ID", lastEventId)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.True(t, broker.unsubscribed)
+	return w.Body.String()
+}
+
+func eventIds(body string) []string {
+	var ids []string
+	for _, line := range strings.Split(body, "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func Test_EventsAfter(t *testing.T) {
+	// event 4 was published first but committed last
+	buffer := []dto.ReportEvent{{ID: 3}, {ID: 5}, {ID: 4}, {ID: 6}}
+
+	assert.Equal(t, []dto.ReportEvent{{ID: 5}, {ID: 4}, {ID: 6}}, service.EventsAfter(buffer, 3))
+	// a client that saw 5 has not seen 4 yet
+	assert.Equal(t, []dto.ReportEvent{{ID: 4}, {ID: 6}}, service.EventsAfter(buffer, 5))
+	assert.Empty(t, service.EventsAfter(buffer, 6))
+	// a client further behind than the buffer gets all of it
+	assert.Equal(t, buffer, service.EventsAfter(buffer, 1))
+	// without a Last-Event-ID the client starts live
+	assert.Empty(t, service.EventsAfter(buffer, 0))
+}
+
+func Test_Logger_RedactsAccessToken(t *testing.T) {
+	var buf bytes.Buffer
+	writer := gin.DefaultWriter
+	gin.DefaultWriter = &buf
+	t.Cleanup(func() { gin.DefaultWriter = writer })
+
+	r := gin.New()
+	r.Use(middleware.Logger())
+	r.GET("/api/events", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/events?access_token=eyJhbGciOi.secret&since=7", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, buf.String(), "secret")
+	assert.Contains(t, buf.String(), `"/api/events?access_token=redacted&since=7"`)
+}
+
+func Test_EventStream_Replay(t *testing.T) {
+	owner := dto.UserResponse{ID: "owner", Role: constants.ENUM_ROLE_USER}
+	broker := &fakeEventBroker{
+		replay: []dto.ReportEvent{{ID: 8, Type: dto.EVENT_STATUS_CHANGED, UserID: owner.ID, Status: "verified"}},
+		live:   []dto.ReportEvent{{ID: 9, Type: dto.EVENT_STATUS_CHANGED, UserID: owner.ID, Status: "handled"}},
+	}
+
+	body := streamEvents(t, broker, owner, "7")
+
+	assert.Equal(t, int64(7), broker.lastEventId)
+	assert.True(t, strings.HasPrefix(body, "retry: 3000\n\n"), body)
+	// replayed events come before live ones
+	assert.Equal(t, []string{"8", "9"}, eventIds(body))
+	assert.Contains(t, body, "event: "+dto.EVENT_STATUS_CHANGED+"\ndata: {\"id\":8,")
+}
+
+func Test_EventStream_Visibility(t *testing.T) {
+	events := []dto.ReportEvent{
+		{ID: 1, Type: dto.EVENT_REPORT_CREATED, UserID: "owner"},
+		{ID: 2, Type: dto.EVENT_REPORT_CREATED, UserID: "neighbour"},
+	}
+
+	// citizens only hear about their own reports, replayed or live
+	owner := dto.UserResponse{ID: "owner", Role: constants.ENUM_ROLE_USER}
+	body := streamEvents(t, &fakeEventBroker{replay: events[:1], live: events[1:]}, owner, "")
+	assert.Equal(t, []string{"1"}, eventIds(body))
+	body = streamEvents(t, &fakeEventBroker{replay: events[1:], live: events[:1]}, owner, "")
+	assert.Equal(t, []string{"1"}, eventIds(body))
+
+	admin := dto.UserResponse{ID: "admin", Role: constants.ENUM_ROLE_ADMIN}
+	body = streamEvents(t, &fakeEventBroker{live: events}, admin, "")
+	assert.Equal(t, []string{"1", "2"}, eventIds(body))
+}
@@ -0,0 +1,206 @@
+package tests
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeReportExportRepository replaces the whole row on Update, like the
+// GORM Save behind the real one.
+type fakeReportExportRepository struct {
+	exports map[string]entity.ReportExport
+}
+
+func (r *fakeReportExportRepository) Create(ctx context.Context, tx *gorm.DB, export entity.ReportExport) (entity.ReportExport, error) {
+	export.ID = uuid.New()
+	export.CreatedAt = time.Now()
+	r.exports[export.ID.String()] = export
+	return export, nil
+}
+
+func (r *fakeReportExportRepository) GetById(ctx context.Context, tx *gorm.DB, exportId string) (entity.ReportExport, error) {
+	export, ok := r.exports[exportId]
+	if !ok {
+		return entity.ReportExport{}, gorm.ErrRecordNotFound
+	}
+	return export, nil
+}
+
+func (r *fakeReportExportRepository) Update(ctx context.Context, tx *gorm.DB, export entity.ReportExport) (entity.ReportExport, error) {
+	r.exports[export.ID.String()] = export
+	return export, nil
+}
+
+func (r *fakeReportExportRepository) DeleteBefore(ctx context.Context, tx *gorm.DB, before time.Time) (int64, error) {
+	var deleted int64
+	for id, export := range r.exports {
+		if export.CreatedAt.Before(before) {
+			delete(r.exports, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// fakeStoredFileRepository keeps files in memory.
+type fakeStoredFileRepository struct {
+	repository.StoredFileRepository
+	files map[string]entity.StoredFile
+}
+
+func (r *fakeStoredFileRepository) Save(ctx context.Context, tx *gorm.DB, file entity.StoredFile) error {
+	file.Size = int64(len(file.Content))
+	file.UpdatedAt = time.Now()
+	r.files[file.Key] = file
+	return nil
+}
+
+func (r *fakeStoredFileRepository) Get(ctx context.Context, tx *gorm.DB, key string) (entity.StoredFile, error) {
+	file, ok := r.files[key]
+	if !ok {
+		return entity.StoredFile{}, gorm.ErrRecordNotFound
+	}
+	return file, nil
+}
+
+func (r *fakeStoredFileRepository) DeleteBefore(ctx context.Context, tx *gorm.DB, prefix string, before time.Time) (int64, error) {
+	var deleted int64
+	for key, file := range r.files {
+		if strings.HasPrefix(key, prefix) && file.UpdatedAt.Before(before) {
+			delete(r.files, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// fakeJobService keeps enqueued jobs instead of running them.
+type fakeJobService struct {
+	service.JobService
+	jobs []entity.Job
+}
+
+func (s *fakeJobService) Enqueue(ctx context.Context, tx *gorm.DB, jobType string, payload any) (entity.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return entity.Job{}, err
+	}
+	job := entity.Job{Type: jobType, Payload: string(raw)}
+	s.jobs = append(s.jobs, job)
+	return job, nil
+}
+
+func Test_SpreadsheetExport_Job(t *testing.T) {
+	reportRepo := &fakeReportRepository{}
+	for _, text := range []string{"Jalan berlubang", "=HYPERLINK(\"http://example.com\")"} {
+		report := entity.Report{ID: uuid.New(), Text: text, Status: entity.StatusUnverified, Location: "Bandung"}
+		report.CreatedAt = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+		reportRepo.reports = append(reportRepo.reports, report)
+	}
+	exportRepo := &fakeReportExportRepository{exports: map[string]entity.ReportExport{}}
+	fileRepo := &fakeStoredFileRepository{files: map[string]entity.StoredFile{}}
+	jobService := &fakeJobService{}
+	exportService := service.NewExportService(reportRepo, &fakeReportHistoryRepository{}, exportRepo, fileRepo, jobService)
+
+	ctx := context.Background()
+	req := dto.SpreadsheetExportRequest{Format: utils.SPREADSHEET_CSV, Columns: "id,text"}
+	started, err := exportService.StartSpreadsheetExport(ctx, req, uuid.NewString())
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.ExportPending), started.Status)
+
+	// run the queued job the way the worker would
+	assert.Len(t, jobService.jobs, 1)
+	var job dto.ReportExportJob
+	assert.NoError(t, json.Unmarshal([]byte(jobService.jobs[0].Payload), &job))
+	assert.NoError(t, exportService.RunSpreadsheetExport(ctx, job))
+
+	finished, err := exportService.GetReportExport(ctx, started.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.ExportCompleted), finished.Status)
+	assert.Equal(t, 2, finished.RowCount)
+	assert.NotEmpty(t, finished.DownloadURL)
+
+	// the worker leaves nothing on its own disk; another instance serves
+	// the file
+	assert.NoDirExists(t, "storage")
+	apiService := service.NewExportService(reportRepo, nil, exportRepo, fileRepo, nil)
+	r := SetUpRoutes()
+	r.GET("/api/admin/reports/exports/:id/download", controller.NewExportController(apiService).DownloadReportExport)
+
+	download, _ := http.NewRequest(http.MethodGet, finished.DownloadURL, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, download)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="laporan-\d{8}-\d{6}\.csv"$`, w.Header().Get("Content-Disposition"))
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"ID", "Isi Laporan"},
+		{reportRepo.reports[0].ID.String(), "Jalan berlubang"},
+		{reportRepo.reports[1].ID.String(), "'=HYPERLINK(\"http://example.com\")"},
+	}, records)
+}
+
+func Test_EscapeSpreadsheetCell(t *testing.T) {
+	tests := map[string]string{
+		"":             "",
+		"Jalan rusak":  "Jalan rusak",
+		"=1+1":         "'=1+1",
+		"+62812":       "'+62812",
+		"-2+3":         "'-2+3",
+		"@SUM(A1)":     "'@SUM(A1)",
+		"\t=1":         "'\t=1",
+		"Total = 1+1":  "Total = 1+1",
+		"email@host.x": "email@host.x",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, utils.EscapeSpreadsheetCell(in), in)
+	}
+}
+
+func Test_PurgeExports(t *testing.T) {
+	now := time.Now()
+	old := entity.ReportExport{ID: uuid.New(), Format: utils.SPREADSHEET_CSV, Status: entity.ExportCompleted}
+	old.CreatedAt = now.Add(-dto.EXPORT_RETENTION - time.Hour)
+	recent := entity.ReportExport{ID: uuid.New(), Format: utils.SPREADSHEET_CSV, Status: entity.ExportCompleted}
+	recent.CreatedAt = now.Add(-time.Hour)
+
+	exportRepo := &fakeReportExportRepository{exports: map[string]entity.ReportExport{
+		old.ID.String():    old,
+		recent.ID.String(): recent,
+	}}
+	fileRepo := &fakeStoredFileRepository{files: map[string]entity.StoredFile{
+		"exports/old.csv":            {Key: "exports/old.csv", Timestamp: entity.Timestamp{UpdatedAt: old.CreatedAt}},
+		"exports/recent.csv":         {Key: "exports/recent.csv", Timestamp: entity.Timestamp{UpdatedAt: recent.CreatedAt}},
+		"open-data/2025-03-01/x.csv": {Key: "open-data/2025-03-01/x.csv", Timestamp: entity.Timestamp{UpdatedAt: old.CreatedAt}},
+	}}
+	exportService := service.NewExportService(nil, nil, exportRepo, fileRepo, nil)
+
+	assert.NoError(t, exportService.PurgeExports(context.Background()))
+
+	assert.Len(t, exportRepo.exports, 1)
+	assert.Contains(t, exportRepo.exports, recent.ID.String())
+	// only export files are purged
+	assert.Len(t, fileRepo.files, 2)
+	assert.Contains(t, fileRepo.files, "exports/recent.csv")
+	assert.Contains(t, fileRepo.files, "open-data/2025-03-01/x.csv")
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func reportQuery(scopes ...func(*gorm.DB) *gorm.DB) *gorm.Statement {
+	var reports []entity.Report
+	return SetUpDryRunDatabase().Model(&entity.Report{}).Scopes(scopes...).Find(&reports).Statement
+}
+
+func Test_ReportFilter_Statuses(t *testing.T) {
+	req := dto.ReportFilterRequest{Status: []string{"verified, handled", "", "completed"}}
+
+	assert.Equal(t, []entity.ReportStatus{entity.StatusVerified, entity.StatusHandled, entity.StatusCompleted}, req.Statuses())
+}
+
+func Test_ReportFilter_Validate(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  dto.ReportFilterRequest
+		err  error
+	}{
+		{"empty", dto.ReportFilterRequest{}, nil},
+		{"statuses", dto.ReportFilterRequest{Status: []string{"verified,handled"}}, nil},
+		{"unknown status", dto.ReportFilterRequest{Status: []string{"verified,closed"}}, dto.ErrInvalidReportStatus},
+		{"sort", dto.ReportFilterRequest{SortBy: dto.REPORT_SORT_UPVOTES, SortDir: "ASC"}, nil},
+		{"unknown sort", dto.ReportFilterRequest{SortBy: "text"}, dto.ErrInvalidReportSort},
+		{"unknown direction", dto.ReportFilterRequest{SortDir: "up"}, dto.ErrInvalidReportSort},
+		{"single day", dto.ReportFilterRequest{CreatedFrom: day, CreatedTo: day}, nil},
+		{"reversed dates", dto.ReportFilterRequest{CreatedFrom: day, CreatedTo: day.AddDate(0, 0, -1)}, dto.ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.req.Validate())
+		})
+	}
+}
+
+func Test_ReportFilter_Default(t *testing.T) {
+	req := dto.ReportFilterRequest{}
+	req.Default()
+	assert.Equal(t, dto.REPORT_SORT_CREATED_AT, req.SortBy)
+	assert.Equal(t, dto.REPORT_SORT_DESC, req.SortDir)
+
+	// a search is ranked by relevance unless the caller sorts otherwise
+	req = dto.ReportFilterRequest{}
+	req.Search = "banjir"
+	req.Default()
+	assert.Equal(t, dto.REPORT_SORT_RELEVANCE, req.SortBy)
+}
+
+func Test_FilterReports(t *testing.T) {
+	req := dto.ReportFilterRequest{
+		Status:     []string{"verified,handled"},
+		Class:      "pothole",
+		Location:   "Sukolilo",
+		CreatedTo:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		MinUpvotes: 3,
+	}
+
+	stmt := reportQuery(repository.FilterReports(req))
+	sql := stmt.SQL.String()
+
+	assert.Contains(t, sql, "reports.status IN ($1,$2)")
+	assert.Contains(t, sql, "reports.status <> $3")
+	assert.Contains(t, sql, "reports.tag_id IN (SELECT id FROM \"tags\" WHERE class = $4)")
+	assert.Contains(t, sql, "reports.location ILIKE $5")
+	assert.Contains(t, sql, "reports.created_at < $6")
+	assert.Contains(t, sql, "reports.upvotes >= $7")
+	assert.NotContains(t, sql, "assignee_id")
+
+	assert.Equal(t, entity.StatusPendingReview, stmt.Vars[2])
+	assert.Equal(t, "%Sukolilo%", stmt.Vars[4])
+	// created_to covers the whole day
+	assert.Equal(t, time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), stmt.Vars[5])
+}
+
+func Test_FilterReports_IncludeHeld(t *testing.T) {
+	req := dto.ReportFilterRequest{IncludeHeld: true}
+
+	sql := reportQuery(repository.FilterReports(req)).SQL.String()
+	assert.NotContains(t, sql, "reports.status <>")
+}
+
+func Test_GetAllReports_InvalidFilter(t *testing.T) {
+	r := SetUpRoutes()
+	r.GET("/api/reports", SetupControllerReport(&fakeReportRepository{}).GetAllReports)
+
+	for _, query := range []string{"status=closed", "sort_by=text", "created_from=2025-03-02&created_to=2025-03-01"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/reports?"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
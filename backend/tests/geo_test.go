@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseLatLng(t *testing.T) {
+	tests := []struct {
+		input    string
+		lat, lng float64
+		ok       bool
+	}{
+		{"-7.2575, 112.7521", -7.2575, 112.7521, true},
+		{"-7.2575,112.7521", -7.2575, 112.7521, true},
+		{"Jl. Raya Darmo", 0, 0, false},
+		{"-7.2575", 0, 0, false},
+		{"1,2,3", 0, 0, false},
+		{"91,0", 0, 0, false},
+		{"0,181", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		lat, lng, ok := utils.ParseLatLng(tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+		assert.Equal(t, tt.lat, lat, tt.input)
+		assert.Equal(t, tt.lng, lng, tt.input)
+	}
+}
+
+// geoReports has a report with coordinates, one with a "lat,lng"
+// location from an older client and one that cannot be placed.
+func geoReports() []entity.Report {
+	lat, lng := -7.2575, 112.7521
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	reports := []entity.Report{
+		{
+			ID: uuid.New(), Status: entity.StatusVerified, Upvotes: 4, Location: "Darmo <Surabaya> & sekitarnya",
+			Latitude: &lat, Longitude: &lng, Tag: entity.Tag{Class: "pothole"},
+		},
+		{ID: uuid.New(), Status: entity.StatusUnverified, Location: "-6.2, 106.8"},
+		{ID: uuid.New(), Status: entity.StatusUnverified, Location: "Jl. Raya Darmo"},
+	}
+	for i := range reports {
+		reports[i].CreatedAt = createdAt
+	}
+	return reports
+}
+
+func Test_ExportGeoJSON(t *testing.T) {
+	reports := geoReports()
+	exportService := service.NewExportService(&fakeReportRepository{reports: reports}, nil, nil, nil, nil)
+
+	var buf bytes.Buffer
+	assert.NoError(t, exportService.ExportGeoJSON(context.Background(), dto.ReportFilterRequest{}, &buf))
+
+	var collection struct {
+		Type     string               `json:"type"`
+		Features []dto.GeoJSONFeature `json:"features"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &collection), buf.String())
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	assert.Len(t, collection.Features, 3)
+
+	// positions are longitude first
+	first := collection.Features[0]
+	assert.Equal(t, reports[0].ID.String(), first.ID)
+	assert.Equal(t, &dto.GeoJSONGeometry{Type: "Point", Coordinates: [2]float64{112.7521, -7.2575}}, first.Geometry)
+	assert.Equal(t, dto.GeoJSONReportProperties{
+		Status:    "verified",
+		Class:     "pothole",
+		Upvotes:   4,
+		Location:  "Darmo <Surabaya> & sekitarnya",
+		CreatedAt: "2025-03-01T10:00:00Z",
+	}, first.Properties)
+
+	assert.Equal(t, [2]float64{106.8, -6.2}, collection.Features[1].Geometry.Coordinates)
+	assert.Equal(t, "unclassified", collection.Features[1].Properties.Class)
+
+	// a report without a position is kept, with a null geometry
+	assert.Nil(t, collection.Features[2].Geometry)
+}
+
+func Test_ExportGeoJSON_Empty(t *testing.T) {
+	exportService := service.NewExportService(&fakeReportRepository{}, nil, nil, nil, nil)
+
+	var buf bytes.Buffer
+	assert.NoError(t, exportService.ExportGeoJSON(context.Background(), dto.ReportFilterRequest{}, &buf))
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, buf.String())
+}
+
+func Test_ExportKML(t *testing.T) {
+	reports := geoReports()
+	exportService := service.NewExportService(&fakeReportRepository{reports: reports}, nil, nil, nil, nil)
+
+	var buf bytes.Buffer
+	assert.NoError(t, exportService.ExportKML(context.Background(), dto.ReportFilterRequest{}, &buf))
+
+	var kml struct {
+		XMLName  xml.Name `xml:"http://www.opengis.net/kml/2.2 kml"`
+		Document struct {
+			Name       string             `xml:"name"`
+			Placemarks []dto.KMLPlacemark `xml:"Placemark"`
+		} `xml:"Document"`
+	}
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &kml), buf.String())
+	assert.Equal(t, "Laporan RAPID", kml.Document.Name)
+
+	// only reports with a position become placemarks
+	placemarks := kml.Document.Placemarks
+	assert.Len(t, placemarks, 2)
+
+	assert.Equal(t, reports[0].ID.String(), placemarks[0].ID)
+	assert.Equal(t, "pothole", placemarks[0].Name)
+	assert.Equal(t, "Darmo <Surabaya> & sekitarnya", placemarks[0].Description)
+	assert.Equal(t, "112.752100,-7.257500", placemarks[0].Coordinates)
+	assert.Contains(t, placemarks[0].ExtendedData, dto.KMLData{Name: "upvotes", Value: "4"})
+	assert.Contains(t, placemarks[0].ExtendedData, dto.KMLData{Name: "created_at", Value: "2025-03-01T10:00:00Z"})
+
+	assert.Equal(t, "106.800000,-6.200000", placemarks[1].Coordinates)
+	assert.Contains(t, placemarks[1].ExtendedData, dto.KMLData{Name: "status", Value: "unverified"})
+}
@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JobBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, service.JobBackoff(1))
+	assert.Equal(t, time.Minute, service.JobBackoff(2))
+	assert.Equal(t, 4*time.Minute, service.JobBackoff(4))
+	assert.Equal(t, time.Hour, service.JobBackoff(20))
+}
+
+func Test_JobHandler_DecodesPayload(t *testing.T) {
+	type payload struct {
+		ExportID string `json:"export_id"`
+	}
+
+	var got payload
+	handler := service.NewJobHandler(func(_ context.Context, p payload) error {
+		got = p
+		return nil
+	})
+
+	err := handler.Handle(context.Background(), entity.Job{Type: "test", Payload: `{"export_id":"abc"}`})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", got.ExportID)
+
+	// undecodable payloads are not retried
+	err = handler.Handle(context.Background(), entity.Job{Type: "test", Payload: `not json`})
+	assert.Error(t, err)
+	assert.True(t, service.IsPermanentJobError(err))
+	assert.False(t, service.IsPermanentJobError(errors.New("smtp timeout")))
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RenderEmail_Templates(t *testing.T) {
+	mail, err := utils.RenderEmail(utils.EmailVerification, struct {
+		Email  string
+		Verify string
+	}{Email: "warga@example.com", Verify: "http://localhost/verify?token=abc"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Verifikasi akun Anda", mail.Subject)
+	assert.Contains(t, mail.HTML, "warga@example.com")
+	assert.Contains(t, mail.Text, "http://localhost/verify?token=abc")
+
+	mail, err = utils.RenderEmail(utils.EmailReportStatus, struct {
+		Name  string
+		Title string
+		Body  string
+	}{Name: "Budi", Title: "Status laporan diperbarui", Body: "<b>ditangani</b>"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Status laporan diperbarui", mail.Subject)
+	// HTML is escaped, plain text is not
+	assert.Contains(t, mail.HTML, "&lt;b&gt;ditangani&lt;/b&gt;")
+	assert.Contains(t, mail.Text, "<b>ditangani</b>")
+
+	_, err = utils.RenderEmail("missing", nil)
+	assert.Error(t, err)
+}
+
+func Test_Mailer_Capture(t *testing.T) {
+	memory := utils.NewMailer(&config.EmailConfig{Driver: config.MAIL_DRIVER_MEMORY})
+	assert.NoError(t, memory.Send(context.Background(), utils.Mail{To: "a@example.com", Subject: "hi", Text: "hello"}))
+	assert.Len(t, memory.(*utils.MemoryMailer).Sent(), 1)
+
+	dir := t.TempDir()
+	file := utils.NewMailer(&config.EmailConfig{Driver: config.MAIL_DRIVER_FILE, Dir: dir, AuthEmail: "no-reply@example.com"})
+	assert.NoError(t, file.Send(context.Background(), utils.Mail{To: "a@example.com", Subject: "hi", HTML: "<p>hello</p>", Text: "hello"}))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	raw, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(raw), "Subject: hi"))
+	assert.True(t, strings.Contains(string(raw), "multipart/alternative"))
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakeNotificationRepository records the notifications put in an inbox.
+type fakeNotificationRepository struct {
+	repository.NotificationRepository
+	created []entity.Notification
+}
+
+func (r *fakeNotificationRepository) Create(ctx context.Context, tx *gorm.DB, notification entity.Notification) (entity.Notification, error) {
+	notification.ID = uuid.New()
+	r.created = append(r.created, notification)
+	return notification, nil
+}
+
+// fakeNotificationChannel records what it is asked to send.
+type fakeNotificationChannel struct {
+	enabled func(prefs entity.NotificationPreferences) bool
+	sent    []entity.Notification
+}
+
+func (c *fakeNotificationChannel) Enabled(prefs entity.NotificationPreferences) bool {
+	return c.enabled(prefs)
+}
+
+func (c *fakeNotificationChannel) Send(ctx context.Context, user entity.User, notification entity.Notification) error {
+	c.sent = append(c.sent, notification)
+	return nil
+}
+
+// fakePreferenceUserRepository records the preferences it saves.
+type fakePreferenceUserRepository struct {
+	fakeUserRepository
+	saved *entity.NotificationPreferences
+}
+
+func (r *fakePreferenceUserRepository) UpdateNotificationPreferences(
+	ctx context.Context,
+	tx *gorm.DB,
+	userId string,
+	prefs entity.NotificationPreferences,
+) error {
+	r.saved = &prefs
+	return nil
+}
+
+func notify(t *testing.T, prefs entity.NotificationPreferences) (*fakeNotificationRepository, *fakeNotificationChannel, *fakeNotificationChannel) {
+	user := entity.User{ID: uuid.New(), NotificationPreferences: prefs}
+	notificationRepo := &fakeNotificationRepository{}
+	email := &fakeNotificationChannel{enabled: func(prefs entity.NotificationPreferences) bool { return prefs.Email }}
+	push := &fakeNotificationChannel{enabled: func(prefs entity.NotificationPreferences) bool { return prefs.Push }}
+	notificationService := service.NewNotificationService(&fakeUserRepository{users: []entity.User{user}}, notificationRepo, email, push)
+
+	err := notificationService.Notify(context.Background(), user.ID.String(), dto.NotificationMessage{
+		Type:     entity.NotificationStatusChanged,
+		Title:    "Laporan diverifikasi",
+		ReportID: uuid.NewString(),
+	})
+	assert.NoError(t, err)
+	return notificationRepo, email, push
+}
+
+func Test_Notify(t *testing.T) {
+	notificationRepo, email, push := notify(t, entity.NotificationPreferences{InApp: true, Email: true, Push: false})
+
+	assert.Len(t, notificationRepo.created, 1)
+	assert.NotNil(t, notificationRepo.created[0].ReportID)
+	// channels get the stored notification
+	assert.Equal(t, notificationRepo.created, email.sent)
+	assert.Empty(t, push.sent)
+}
+
+func Test_Notify_InAppDisabled(t *testing.T) {
+	notificationRepo, email, push := notify(t, entity.NotificationPreferences{InApp: false, Email: true, Push: true})
+
+	assert.Empty(t, notificationRepo.created)
+	// the other channels still deliver
+	assert.Len(t, email.sent, 1)
+	assert.Len(t, push.sent, 1)
+	assert.Equal(t, "Laporan diverifikasi", push.sent[0].Title)
+}
+
+// notificationDatabase is a dry run database that answers queries with the
+// stored notification when they are limited to its id and owner, and
+// records the updates it is sent. Updates skip the default transaction,
+// which would need a connection.
+func notificationDatabase(stored entity.Notification) (*gorm.DB, *[]string) {
+	db := SetUpDryRunDatabase().Session(&gorm.Session{SkipDefaultTransaction: true})
+	var updates []string
+
+	db.Callback().Query().After("gorm:query").Register("test:notification", func(tx *gorm.DB) {
+		vars := tx.Statement.Vars
+		if slices.Contains(vars, any(stored.ID.String())) && slices.Contains(vars, any(stored.UserID.String())) {
+			*tx.Statement.Dest.(*entity.Notification) = stored
+			return
+		}
+		tx.AddError(gorm.ErrRecordNotFound)
+	})
+	db.Callback().Update().After("gorm:update").Register("test:notification", func(tx *gorm.DB) {
+		updates = append(updates, tx.Statement.SQL.String())
+	})
+
+	return db, &updates
+}
+
+func Test_MarkRead(t *testing.T) {
+	stored := entity.Notification{ID: uuid.New(), UserID: uuid.New(), Type: entity.NotificationComment}
+
+	t.Run("owner only", func(t *testing.T) {
+		db, updates := notificationDatabase(stored)
+		notificationService := service.NewNotificationService(nil, repository.NewNotificationRepository(db))
+
+		_, err := notificationService.MarkRead(context.Background(), uuid.NewString(), stored.ID.String())
+		assert.Equal(t, dto.ErrNotificationNotFound, err)
+		assert.Empty(t, *updates)
+	})
+
+	t.Run("unread", func(t *testing.T) {
+		db, updates := notificationDatabase(stored)
+		notificationService := service.NewNotificationService(nil, repository.NewNotificationRepository(db))
+
+		result, err := notificationService.MarkRead(context.Background(), stored.UserID.String(), stored.ID.String())
+		assert.NoError(t, err)
+		assert.True(t, result.IsRead)
+		assert.Len(t, *updates, 1)
+		assert.Contains(t, (*updates)[0], `SET "read_at"=`)
+	})
+
+	t.Run("already read", func(t *testing.T) {
+		readAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+		read := stored
+		read.ReadAt = &readAt
+		db, updates := notificationDatabase(read)
+		notificationService := service.NewNotificationService(nil, repository.NewNotificationRepository(db))
+
+		result, err := notificationService.MarkRead(context.Background(), stored.UserID.String(), stored.ID.String())
+		assert.NoError(t, err)
+		// the first read time is kept
+		assert.Equal(t, "2025-03-01T10:00:00Z", result.ReadAt)
+		assert.Empty(t, *updates)
+	})
+}
+
+func Test_UpdatePreferences(t *testing.T) {
+	user := entity.User{ID: uuid.New(), NotificationPreferences: entity.NotificationPreferences{InApp: true, Email: true, Push: true}}
+	off := false
+
+	tests := []struct {
+		name string
+		req  dto.NotificationPreferencesRequest
+		want entity.NotificationPreferences
+	}{
+		{"nothing", dto.NotificationPreferencesRequest{}, entity.NotificationPreferences{InApp: true, Email: true, Push: true}},
+		{"email", dto.NotificationPreferencesRequest{Email: &off}, entity.NotificationPreferences{InApp: true, Email: false, Push: true}},
+		{"in app and push", dto.NotificationPreferencesRequest{InApp: &off, Push: &off}, entity.NotificationPreferences{InApp: false, Email: true, Push: false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &fakePreferenceUserRepository{fakeUserRepository: fakeUserRepository{users: []entity.User{user}}}
+			notificationService := service.NewNotificationService(userRepo, nil)
+
+			result, err := notificationService.UpdatePreferences(context.Background(), user.ID.String(), tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, &tt.want, userRepo.saved)
+			assert.Equal(t, dto.NotificationPreferencesResponse{InApp: tt.want.InApp, Email: tt.want.Email, Push: tt.want.Push}, result)
+		})
+	}
+}
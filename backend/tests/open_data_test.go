@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func (r *fakeStoredFileRepository) SaveAll(ctx context.Context, tx *gorm.DB, files []entity.StoredFile) error {
+	for _, file := range files {
+		r.Save(ctx, tx, file)
+	}
+	return nil
+}
+
+func (r *fakeStoredFileRepository) Find(ctx context.Context, tx *gorm.DB, pattern string) ([]entity.StoredFile, error) {
+	like := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), "%", ".*") + "$")
+
+	var files []entity.StoredFile
+	for key, file := range r.files {
+		if like.MatchString(key) {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// openDataReports has five potholes in one grid cell and a sixth in the
+// next cell along.
+func openDataReports() []entity.Report {
+	lats := []float64{-7.2501, -7.2525, -7.2550, -7.2575, -7.2599, -7.2401}
+	lng := 112.7521
+
+	reports := make([]entity.Report, len(lats))
+	for i := range lats {
+		reports[i] = entity.Report{
+			ID: uuid.New(), Status: entity.StatusVerified, Text: "Jalan berlubang di depan rumah Pak Budi",
+			Latitude: &lats[i], Longitude: &lng, Tag: entity.Tag{Class: "pothole"},
+		}
+		reports[i].CreatedAt = time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	}
+	return reports
+}
+
+func generateOpenData(t *testing.T, cfg config.OpenDataConfig) (*fakeStoredFileRepository, dto.OpenDataSnapshotResponse) {
+	fileRepo := &fakeStoredFileRepository{files: map[string]entity.StoredFile{}}
+	openDataService := service.NewOpenDataService(&fakeReportRepository{reports: openDataReports()}, fileRepo, cfg)
+
+	snapshot, err := openDataService.GenerateSnapshot(context.Background())
+	assert.NoError(t, err)
+	return fileRepo, snapshot
+}
+
+func Test_OpenDataSnapshot(t *testing.T) {
+	fileRepo, snapshot := generateOpenData(t, config.OpenDataConfig{GridDegrees: 0.01, K: 5})
+
+	// the report alone in its cell is held back
+	assert.Equal(t, 5, snapshot.RecordCount)
+	assert.Equal(t, 1, snapshot.SuppressedCount)
+	assert.False(t, snapshot.IncludesText)
+
+	version := time.Now().In(utils.JakartaLocation()).Format("2006-01-02")
+	assert.Equal(t, version, snapshot.Version)
+	assert.Len(t, fileRepo.files, 4)
+
+	rows, err := csv.NewReader(strings.NewReader(string(fileRepo.files["open-data/"+version+"/reports.csv"].Content))).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 6)
+	assert.Equal(t, []string{"created_date", "status", "class", "grid_lat", "grid_lng", "upvotes"}, rows[0])
+	// every position is snapped to the centre of its cell, and the date is
+	// the Jakarta day
+	for _, row := range rows[1:] {
+		assert.Equal(t, []string{"2025-03-02", "verified", "pothole", "-7.255", "112.755", "0"}, row)
+	}
+
+	var records []map[string]any
+	assert.NoError(t, json.Unmarshal(fileRepo.files["open-data/"+version+"/reports.json"].Content, &records))
+	assert.Len(t, records, 5)
+	assert.NotContains(t, records[0], "text")
+	assert.Equal(t, -7.255, records[0]["grid_lat"])
+
+	var schema dto.TableSchema
+	assert.NoError(t, json.Unmarshal(fileRepo.files["open-data/"+version+"/schema.json"].Content, &schema))
+	assert.Len(t, schema.Fields, 6)
+}
+
+func Test_OpenDataSnapshot_IncludeText(t *testing.T) {
+	fileRepo, snapshot := generateOpenData(t, config.OpenDataConfig{GridDegrees: 0.01, K: 5, IncludeText: true})
+	assert.True(t, snapshot.IncludesText)
+
+	content := string(fileRepo.files["open-data/"+snapshot.Version+"/reports.csv"].Content)
+	assert.True(t, strings.HasPrefix(content, "created_date,status,class,grid_lat,grid_lng,upvotes,text\n"), content)
+	assert.Contains(t, content, "Pak Budi")
+}
+
+func Test_OpenDataSnapshot_GridSize(t *testing.T) {
+	// with a coarser grid all six reports share a cell
+	_, snapshot := generateOpenData(t, config.OpenDataConfig{GridDegrees: 0.1, K: 5})
+	assert.Equal(t, 6, snapshot.RecordCount)
+	assert.Zero(t, snapshot.SuppressedCount)
+}
+
+func Test_GetSnapshotFile(t *testing.T) {
+	fileRepo, snapshot := generateOpenData(t, config.OpenDataConfig{GridDegrees: 0.01, K: 5})
+	fileRepo.files["exports/secret.csv"] = entity.StoredFile{Key: "exports/secret.csv", Content: []byte("secret")}
+
+	// another instance serves what the generating one stored
+	openDataService := service.NewOpenDataService(nil, fileRepo, config.OpenDataConfig{})
+
+	snapshots, err := openDataService.ListSnapshots(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, snapshot.Version, snapshots[0].Version)
+
+	content, err := openDataService.GetSnapshotFile(context.Background(), dto.OPEN_DATA_LATEST, dto.OPEN_DATA_FILE_CSV)
+	assert.NoError(t, err)
+	assert.Equal(t, fileRepo.files["open-data/"+snapshot.Version+"/reports.csv"].Content, content)
+
+	tests := []struct {
+		name    string
+		version string
+		file    string
+		err     error
+	}{
+		{"unknown file", snapshot.Version, "secret.csv", dto.ErrInvalidOpenDataFile},
+		{"missing version", "2020-01-01", dto.OPEN_DATA_FILE_CSV, dto.ErrOpenDataNotFound},
+		{"not a date", "..", dto.OPEN_DATA_FILE_CSV, dto.ErrOpenDataNotFound},
+		{"path", snapshot.Version + "/../../exports", dto.OPEN_DATA_FILE_CSV, dto.ErrOpenDataNotFound},
+		{"pattern", "%", dto.OPEN_DATA_FILE_CSV, dto.ErrOpenDataNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := openDataService.GetSnapshotFile(context.Background(), tt.version, tt.file)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
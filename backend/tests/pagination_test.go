@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cursor_RoundTrip(t *testing.T) {
+	cursor := dto.Cursor{
+		CreatedAt: time.Date(2025, 3, 1, 10, 0, 0, 123456000, time.UTC),
+		ID:        "5b0f4b5e-4a4e-4a57-9a43-0f3a8f8b1f10",
+		Prev:      true,
+	}
+
+	decoded, err := dto.DecodeCursor(cursor.Encode())
+	assert.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.True(t, decoded.Prev)
+}
+
+func Test_Cursor_Invalid(t *testing.T) {
+	decoded, err := dto.DecodeCursor("")
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	_, err = dto.DecodeCursor("not-a-cursor")
+	assert.ErrorIs(t, err, dto.ErrInvalidCursor)
+}
+
+func Test_KeysetPage(t *testing.T) {
+	key := func(id string) dto.Cursor {
+		return dto.Cursor{CreatedAt: time.Unix(1, 0), ID: id}
+	}
+
+	// first page with one extra row: next cursor only
+	rows, next, prev := repository.KeysetPage([]string{"a", "b", "c"}, nil, 2, key)
+	assert.Equal(t, []string{"a", "b"}, rows)
+	assert.NotEmpty(t, next)
+	assert.Empty(t, prev)
+
+	// backwards page comes in reverse order and is flipped back
+	cursor := &dto.Cursor{CreatedAt: time.Unix(1, 0), ID: "d", Prev: true}
+	rows, next, prev = repository.KeysetPage([]string{"c", "b"}, cursor, 2, key)
+	assert.Equal(t, []string{"b", "c"}, rows)
+	assert.NotEmpty(t, next)
+	assert.Empty(t, prev)
+}
+
+func Test_GetAllReports_CursorPage(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	reportRepo := &fakeReportRepository{}
+	for i := 0; i < 5; i++ {
+		report := entity.Report{ID: uuid.New(), Status: entity.StatusUnverified}
+		report.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+		reportRepo.reports = append(reportRepo.reports, report)
+	}
+
+	r := SetUpRoutes()
+	r.GET("/api/reports", SetupControllerReport(reportRepo).GetAllReports)
+
+	page := func(cursor string) (ids []string, next string) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/reports?paging=cursor&per_page=2&cursor="+cursor, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var res struct {
+			Data struct {
+				Data []struct {
+					ID string `json:"id"`
+				} `json:"data"`
+				NextCursor string `json:"next_cursor"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		for _, report := range res.Data.Data {
+			ids = append(ids, report.ID)
+		}
+		return ids, res.Data.NextCursor
+	}
+
+	first, next := page("")
+	assert.Len(t, first, 2)
+	assert.NotEmpty(t, next, "a full cursor page must hand out the cursor to continue with")
+
+	second, next := page(next)
+	assert.Len(t, second, 2)
+	assert.NotEmpty(t, next)
+	assert.NotContains(t, second, first[0])
+
+	last, next := page(next)
+	assert.Len(t, last, 1)
+	assert.Empty(t, next)
+}
+
+func Test_GetAllReports_InvalidCursor(t *testing.T) {
+	r := SetUpRoutes()
+	r.GET("/api/reports", SetupControllerReport(&fakeReportRepository{}).GetAllReports)
+
+	for _, query := range []string{"cursor=not-a-cursor", "paging=cursor&sort_by=upvotes"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/reports?"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
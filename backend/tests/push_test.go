@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const deviceToken = "fGx1cZq0Rk2mQ9vTn4sLp8:APA91bHx7Wd3Yq2eR6tUiOp0aSdFgHjKl"
+
+func fcmError(t *testing.T, status int, body string) utils.PushSender {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return utils.NewFCMSender(server.URL, server.Client())
+}
+
+func Test_FCMSender_InvalidToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		invalid bool
+	}{
+		{"unregistered", http.StatusNotFound,
+			`{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`, true},
+		{"malformed token", http.StatusBadRequest,
+			`{"error":{"status":"INVALID_ARGUMENT","details":[{"errorCode":"INVALID_ARGUMENT"}]}}`, true},
+		{"invalid argument on another status", http.StatusInternalServerError,
+			`{"error":{"status":"INTERNAL","details":[{"errorCode":"INVALID_ARGUMENT"}]}}`, false},
+		{"not found", http.StatusNotFound, `{"error":{"status":"NOT_FOUND"}}`, true},
+		{"not found status on another code", http.StatusBadRequest, `{"error":{"status":"NOT_FOUND"}}`, false},
+		{"unavailable", http.StatusServiceUnavailable,
+			`{"error":{"status":"UNAVAILABLE","details":[{"errorCode":"UNAVAILABLE"}]}}`, false},
+		{"not json", http.StatusNotFound, `not found`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fcmError(t, tt.status, tt.body).Send(context.Background(), deviceToken, utils.PushMessage{Title: "hi"})
+			assert.Error(t, err)
+			assert.Equal(t, tt.invalid, errors.Is(err, utils.ErrInvalidPushToken), err)
+		})
+	}
+}
+
+type fakePushSender struct {
+	err error
+}
+
+func (s fakePushSender) Send(ctx context.Context, token string, msg utils.PushMessage) error {
+	return s.err
+}
+
+// fakeDeviceTokenRepository records the tokens it deletes.
+type fakeDeviceTokenRepository struct {
+	repository.DeviceTokenRepository
+	deleted []string
+}
+
+func (r *fakeDeviceTokenRepository) DeleteByToken(ctx context.Context, tx *gorm.DB, token string) error {
+	r.deleted = append(r.deleted, token)
+	return nil
+}
+
+func Test_SendPush_PrunesInvalidToken(t *testing.T) {
+	job := dto.SendPushJob{Token: deviceToken, Title: "Laporan diverifikasi"}
+
+	deviceRepo := &fakeDeviceTokenRepository{}
+	deviceService := service.NewDeviceService(deviceRepo, fakePushSender{err: utils.ErrInvalidPushToken}, nil)
+	// the job is done once the token is gone
+	assert.NoError(t, deviceService.SendPush(context.Background(), job))
+	assert.Equal(t, []string{deviceToken}, deviceRepo.deleted)
+
+	// other failures are retried and keep the token
+	deviceRepo = &fakeDeviceTokenRepository{}
+	deviceService = service.NewDeviceService(deviceRepo, fakePushSender{err: errors.New("fcm: 503")}, nil)
+	assert.Error(t, deviceService.SendPush(context.Background(), job))
+	assert.Empty(t, deviceRepo.deleted)
+}
+
+func Test_LogPushSender_TruncatesToken(t *testing.T) {
+	var buf bytes.Buffer
+	writer := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(writer) })
+
+	sender, err := utils.NewPushSender(context.Background(), config.PushConfig{})
+	assert.NoError(t, err)
+	assert.NoError(t, sender.Send(context.Background(), deviceToken, utils.PushMessage{Title: "Laporan diverifikasi"}))
+
+	assert.NotContains(t, buf.String(), deviceToken)
+	assert.Contains(t, buf.String(), "push to fGx1cZq0...: Laporan diverifikasi")
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/middleware"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TakeToken(t *testing.T) {
+	policy := config.RateLimitPolicy{Limit: 5, Period: time.Minute}
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	var bucket dto.TokenBucket
+	var result dto.RateLimitResult
+	for i := 0; i < 5; i++ {
+		bucket, result = service.TakeToken(bucket, policy, start)
+		assert.True(t, result.Allowed, "request %d", i+1)
+		assert.Equal(t, 4-i, result.Remaining, "request %d", i+1)
+	}
+
+	// a sixth request within the minute is refused
+	bucket, result = service.TakeToken(bucket, policy, start)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 12*time.Second, result.RetryAfter)
+	assert.Equal(t, time.Minute, result.Reset)
+
+	// one token refills every 12 seconds
+	bucket, result = service.TakeToken(bucket, policy, start.Add(12*time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// an idle bucket never holds more than the limit
+	_, result = service.TakeToken(bucket, policy, start.Add(time.Hour))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 4, result.Remaining)
+}
+
+func Test_ParseRateLimitPolicy(t *testing.T) {
+	tests := []struct {
+		in     string
+		policy config.RateLimitPolicy
+		ok     bool
+	}{
+		{"5/1m", config.RateLimitPolicy{Limit: 5, Period: time.Minute}, true},
+		{" 100/24h ", config.RateLimitPolicy{Limit: 100, Period: 24 * time.Hour}, true},
+		{"", config.RateLimitPolicy{}, false},
+		{"5", config.RateLimitPolicy{}, false},
+		{"0/1m", config.RateLimitPolicy{}, false},
+		{"5/soon", config.RateLimitPolicy{}, false},
+	}
+
+	for _, tt := range tests {
+		policy, ok := config.ParseRateLimitPolicy(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		assert.Equal(t, tt.policy, policy, tt.in)
+	}
+}
+
+func setUpRateLimitedRoute(t *testing.T, trustedProxies string) *gin.Engine {
+	t.Setenv("TRUSTED_PROXIES", trustedProxies)
+	limiter := service.NewRateLimiter(config.RateLimitConfig{
+		Enabled: true,
+		Backend: config.RATE_LIMIT_BACKEND_MEMORY,
+		Policies: map[string]config.RateLimitPolicy{
+			config.RATE_LIMIT_LOGIN: {Limit: 2, Period: time.Minute},
+		},
+	}, nil, nil)
+
+	r := SetUpRoutes()
+	assert.NoError(t, r.SetTrustedProxies(config.TrustedProxies()))
+	// stands in for Authenticate
+	authenticate := func(ctx *gin.Context) {
+		if userId := ctx.GetHeader("X-User-Id"); userId != "" {
+			ctx.Set("user_id", userId)
+		}
+	}
+	r.POST("/api/user/login", authenticate, middleware.RateLimit(limiter, config.RATE_LIMIT_LOGIN), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	return r
+}
+
+func login(r *gin.Engine, remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+	return loginAs(r, remoteAddr, forwardedFor, "")
+}
+
+func loginAs(r *gin.Engine, remoteAddr string, forwardedFor string, userId string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/api/user/login", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+	req.Header.Set("X-User-Id", userId)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func Test_RateLimit_IgnoresSpoofedForwardedFor(t *testing.T) {
+	r := setUpRateLimitedRoute(t, "")
+
+	// a new X-Forwarded-For on every request does not buy a new bucket
+	var w *httptest.ResponseRecorder
+	for i := 1; i <= 3; i++ {
+		w = login(r, "203.0.113.7:40000", fmt.Sprintf("198.51.100.%d", i))
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+}
+
+func Test_RateLimit_TrustedProxy(t *testing.T) {
+	// nginx on the compose network
+	r := setUpRateLimitedRoute(t, "172.28.0.0/16")
+
+	// behind a trusted proxy every client has a bucket of its own
+	for i := 1; i <= 3; i++ {
+		w := login(r, "172.28.0.3:40000", fmt.Sprintf("198.51.100.%d", i))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	}
+
+	login(r, "172.28.0.3:40000", "198.51.100.1")
+	w := login(r, "172.28.0.3:40000", "198.51.100.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func Test_RateLimit_UserRefusalKeepsIPToken(t *testing.T) {
+	r := setUpRateLimitedRoute(t, "")
+
+	// the user spends their two tokens from two networks
+	assert.Equal(t, http.StatusOK, loginAs(r, "203.0.113.7:40000", "", "alice").Code)
+	assert.Equal(t, http.StatusOK, loginAs(r, "192.0.2.9:40000", "", "alice").Code)
+
+	w := loginAs(r, "203.0.113.7:40000", "", "alice")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// the refused request left the shared address its last token
+	w = loginAs(r, "203.0.113.7:40000", "", "bob")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+}
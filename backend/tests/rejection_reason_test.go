@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_RejectionReasonDescription(t *testing.T) {
+	reason := entity.RejectionReason{
+		Code:          "duplicate",
+		DescriptionID: "Laporan serupa sudah ada",
+		DescriptionEN: "A similar report already exists",
+	}
+
+	assert.Equal(t, "A similar report already exists", reason.Description(utils.PreferredLanguage("en-US,en;q=0.9")))
+	assert.Equal(t, "Laporan serupa sudah ada", reason.Description(utils.PreferredLanguage("id-ID,en;q=0.8")))
+	assert.Equal(t, "Laporan serupa sudah ada", reason.Description(utils.PreferredLanguage("fr, de")))
+	assert.Equal(t, "Laporan serupa sudah ada", reason.Description(utils.PreferredLanguage("")))
+
+	// untranslated codes fall back to Indonesian
+	reason.DescriptionEN = ""
+	assert.Equal(t, "Laporan serupa sudah ada", reason.Description(utils.LANG_EN))
+}
+
+func Test_FileAppeal_Rejects(t *testing.T) {
+	reporter := uuid.New()
+	rejected := entity.Report{ID: uuid.New(), UserID: reporter.String(), Status: entity.StatusRejected}
+	verified := entity.Report{ID: uuid.New(), UserID: reporter.String(), Status: entity.StatusVerified}
+	appealService := service.NewAppealService(SetupReportWorkflow(
+		&fakeUserRepository{},
+		&fakeReportRepository{reports: []entity.Report{rejected, verified}},
+	), nil)
+	ctx := context.Background()
+
+	_, err := appealService.FileAppeal(ctx, rejected.ID.String(), dto.FileAppealRequest{Text: "  "}, reporter.String())
+	assert.Equal(t, dto.ErrAppealEmpty, err)
+
+	_, err = appealService.FileAppeal(ctx, rejected.ID.String(), dto.FileAppealRequest{Text: "bukan spam"}, uuid.NewString())
+	assert.Equal(t, dto.ErrNotReportReporter, err)
+
+	_, err = appealService.FileAppeal(ctx, verified.ID.String(), dto.FileAppealRequest{Text: "bukan spam"}, reporter.String())
+	assert.Equal(t, dto.ErrReportNotRejected, err)
+}
+
+type fakeAppealRepository struct {
+	repository.ReportAppealRepository
+	created []entity.ReportAppeal
+}
+
+func (r *fakeAppealRepository) GetByReportId(ctx context.Context, tx *gorm.DB, reportId string) (entity.ReportAppeal, error) {
+	return entity.ReportAppeal{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeAppealRepository) Create(ctx context.Context, tx *gorm.DB, appeal entity.ReportAppeal) (entity.ReportAppeal, error) {
+	r.created = append(r.created, appeal)
+	return appeal, nil
+}
+
+func Test_FileAppeal_KeepsTextOutOfHistory(t *testing.T) {
+	reporter := uuid.New()
+	rejected := entity.Report{ID: uuid.New(), UserID: reporter.String(), Status: entity.StatusRejected}
+	appealRepo := &fakeAppealRepository{}
+	historyRepo := &fakeHistoryRecorder{}
+	workflow := service.NewReportWorkflow(
+		&fakeUserRepository{},
+		&fakeReportRepository{reports: []entity.Report{rejected}},
+		historyRepo, nil, nil, nil, nil, nil, SetUpDryRunDatabase(),
+	)
+
+	// the dry run cannot commit, the entries are written before that
+	service.NewAppealService(workflow, appealRepo).FileAppeal(context.Background(), rejected.ID.String(),
+		dto.FileAppealRequest{Text: "Nomor telepon saya 0812-3456-7890"}, reporter.String())
+
+	assert.Len(t, appealRepo.created, 1)
+	assert.Len(t, historyRepo.created, 1)
+	assert.Equal(t, entity.HistoryAppealFiled, historyRepo.created[0].Event)
+	assert.Equal(t, "Banding "+appealRepo.created[0].ID.String(), historyRepo.created[0].Note)
+}
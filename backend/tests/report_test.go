@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/controller"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeReportRepository keeps reports in memory, newest first. Methods it
+// does not implement panic through the nil embedded interface.
+type fakeReportRepository struct {
+	repository.ReportRepository
+	reports []entity.Report
+}
+
+func (r *fakeReportRepository) GetReports(ctx context.Context, tx *gorm.DB, req dto.ReportFilterRequest) (dto.GetAllReportResponse, error) {
+	req.Default()
+
+	reports := append([]entity.Report(nil), r.reports...)
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].CreatedAt.After(reports[j].CreatedAt)
+	})
+
+	cursor, err := dto.DecodeCursor(req.Cursor)
+	if err != nil {
+		return dto.GetAllReportResponse{}, err
+	}
+	start := 0
+	if cursor != nil {
+		for i, report := range reports {
+			if report.ID.String() == cursor.ID {
+				start = i + 1
+			}
+		}
+	}
+	// one row more than the page, like KeysetPaginate
+	end := min(start+req.PerPage+1, len(reports))
+
+	response := dto.GetAllReportResponse{
+		PaginationResponse: dto.PaginationResponse{Page: req.Page, PerPage: req.PerPage},
+	}
+	response.Reports, response.NextCursor, response.PrevCursor = repository.KeysetPage(reports[start:end], cursor, req.PerPage,
+		func(report entity.Report) dto.Cursor {
+			return dto.Cursor{CreatedAt: report.CreatedAt, ID: report.ID.String()}
+		},
+	)
+
+	return response, nil
+}
+
+func (r *fakeReportRepository) StreamReports(ctx context.Context, tx *gorm.DB, req dto.ReportFilterRequest, batchSize int, fn func([]entity.Report) error) error {
+	for start := 0; start < len(r.reports); start += batchSize {
+		if err := fn(r.reports[start:min(start+batchSize, len(r.reports))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeReportHistoryRepository has no history.
+type fakeReportHistoryRepository struct {
+	repository.ReportHistoryRepository
+}
+
+func (r *fakeReportHistoryRepository) FirstStatusTimes(ctx context.Context, tx *gorm.DB, reportIds []uuid.UUID) (map[uuid.UUID]map[entity.ReportStatus]time.Time, error) {
+	return map[uuid.UUID]map[entity.ReportStatus]time.Time{}, nil
+}
+
+func (r *fakeReportRepository) GetReportById(ctx context.Context, tx *gorm.DB, reportId string) (entity.Report, error) {
+	for _, report := range r.reports {
+		if report.ID.String() == reportId {
+			return report, nil
+		}
+	}
+	return entity.Report{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeReportRepository) GetReportForUpdate(ctx context.Context, tx *gorm.DB, reportId string) (entity.Report, error) {
+	return r.GetReportById(ctx, tx, reportId)
+}
+
+func (r *fakeReportRepository) CountReportStatus(ctx context.Context, tx *gorm.DB, agencyIds []string) (dto.CountReportResponse, error) {
+	var counts dto.CountReportResponse
+	for _, report := range r.reports {
+		switch report.Status {
+		case entity.StatusPendingReview:
+			counts.PendingReview++
+		case entity.StatusUnverified:
+			counts.Unverified++
+		case entity.StatusVerified:
+			counts.Verified++
+		case entity.StatusRejected:
+			counts.Rejected++
+		case entity.StatusHandled:
+			counts.Handled++
+		case entity.StatusCompleted:
+			counts.Completed++
+		}
+		counts.Total++
+	}
+	return counts, nil
+}
+
+func (r *fakeReportRepository) GetVoterRecord(ctx context.Context, tx *gorm.DB, userId string) (dto.VoterRecord, error) {
+	return dto.VoterRecord{}, nil
+}
+
+// fakeUserRepository only looks users up by id.
+type fakeUserRepository struct {
+	repository.UserRepository
+	users []entity.User
+}
+
+func (r *fakeUserRepository) GetUserById(ctx context.Context, tx *gorm.DB, userId string) (entity.User, error) {
+	for _, user := range r.users {
+		if user.ID.String() == userId {
+			return user, nil
+		}
+	}
+	return entity.User{}, gorm.ErrRecordNotFound
+}
+
+// SetupReportWorkflow wires the shared report workflow to the given
+// fakes. It runs against a dry run database, so paths that commit a
+// transaction fail; tests exercise the checks made before that.
+func SetupReportWorkflow(userRepo repository.UserRepository, reportRepo repository.ReportRepository) *service.ReportWorkflow {
+	return service.NewReportWorkflow(userRepo, reportRepo, nil, nil, nil, nil, nil, nil, SetUpDryRunDatabase())
+}
+
+func SetupReportService(userRepo repository.UserRepository, reportRepo repository.ReportRepository) service.ReportService {
+	return service.NewReportService(SetupReportWorkflow(userRepo, reportRepo), nil, nil, nil, nil)
+}
+
+func SetupControllerReport(reportRepo repository.ReportRepository) controller.ReportController {
+	return controller.NewReportController(SetupReportService(&fakeUserRepository{}, reportRepo))
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/Caknoooo/go-gin-clean-starter/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReputationSummary(t *testing.T) {
+	summary := service.ReputationSummary([]dto.ReputationReasonCount{
+		{Reason: entity.ReputationReportVerified, Count: 12, Points: 120},
+		{Reason: entity.ReputationReportUpvoted, Count: 30, Points: 30},
+		{Reason: entity.ReputationReportSpam, Count: 1, Points: -25},
+	})
+
+	assert.Equal(t, int64(125), summary.Score)
+
+	var codes []string
+	for _, badge := range summary.Badges {
+		codes = append(codes, badge.Code)
+	}
+	assert.Equal(t, []string{"first_verified", "trusted_reporter"}, codes)
+
+	empty := service.ReputationSummary(nil)
+	assert.Zero(t, empty.Score)
+	assert.Empty(t, empty.Badges)
+}
+
+func Test_LeaderboardSince(t *testing.T) {
+	loc := utils.JakartaLocation()
+	// a Thursday evening in Jakarta
+	now := time.Date(2026, 10, 15, 21, 30, 0, 0, loc)
+
+	weekly, err := service.LeaderboardSince(dto.LEADERBOARD_WEEKLY, now)
+	assert.NoError(t, err)
+	assert.True(t, weekly.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, loc)), weekly)
+
+	monthly, err := service.LeaderboardSince(dto.LEADERBOARD_MONTHLY, now)
+	assert.NoError(t, err)
+	assert.True(t, monthly.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, loc)), monthly)
+
+	allTime, err := service.LeaderboardSince(dto.LEADERBOARD_ALL_TIME, now)
+	assert.NoError(t, err)
+	assert.Nil(t, allTime)
+
+	_, err = service.LeaderboardSince("daily", now)
+	assert.Equal(t, dto.ErrInvalidLeaderboardRange, err)
+}
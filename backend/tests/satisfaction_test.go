@@ -0,0 +1,27 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SatisfactionScores(t *testing.T) {
+	scores := service.SatisfactionScores([]dto.SatisfactionCount{
+		{Key: "banjir", Score: 5, Count: 1},
+		{Key: "jalan_rusak", Score: 2, Count: 2},
+		{Key: "jalan_rusak", Score: 5, Count: 1},
+		{Key: "jalan_rusak", Score: 9, Count: 4},
+	})
+
+	assert.Len(t, scores, 2)
+	assert.Equal(t, "jalan_rusak", scores[0].Key)
+	assert.Equal(t, int64(3), scores[0].Count)
+	assert.InDelta(t, 3.0, *scores[0].Average, 0.001)
+	assert.Equal(t, []int64{0, 2, 0, 0, 1}, scores[0].Distribution)
+	assert.InDelta(t, 5.0, *scores[1].Average, 0.001)
+
+	assert.Empty(t, service.SatisfactionScores(nil))
+}
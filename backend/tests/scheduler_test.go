@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewScheduler_ValidatesTasks(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+
+	_, err := service.NewScheduler(nil, nil, []service.ScheduledTask{
+		{Name: "hourly", Schedule: "0 * * * *", Run: noop},
+		{Name: "nightly", Schedule: "30 1 * * *", Run: noop},
+	})
+	assert.NoError(t, err)
+
+	_, err = service.NewScheduler(nil, nil, []service.ScheduledTask{
+		{Name: "broken", Schedule: "every hour", Run: noop},
+	})
+	assert.ErrorIs(t, err, dto.ErrInvalidScheduleSpec)
+
+	_, err = service.NewScheduler(nil, nil, []service.ScheduledTask{
+		{Name: "hourly", Schedule: "0 * * * *", Run: noop},
+		{Name: "hourly", Schedule: "0 2 * * *", Run: noop},
+	})
+	assert.ErrorIs(t, err, dto.ErrDuplicateScheduledTask)
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SearchReports_EscapesHighlight(t *testing.T) {
+	req := dto.ReportFilterRequest{}
+	req.Search = "jalan rusak"
+
+	sql := reportQuery(repository.SearchReports(req)).SQL.String()
+
+	// the headline is built from the escaped text, so report text cannot
+	// add markup of its own next to the <mark> tags
+	headline := sql[strings.Index(sql, "ts_headline("):]
+	assert.True(t, strings.HasPrefix(headline, "ts_headline($4::regconfig, replace("), headline)
+	assert.Contains(t, headline, `'<', '&lt;'`)
+	assert.Contains(t, headline, `'&', '&amp;'`)
+	assert.Contains(t, sql, "AS search_rank")
+}
+
+func Test_SearchReports_NoSearch(t *testing.T) {
+	sql := reportQuery(repository.SearchReports(dto.ReportFilterRequest{})).SQL.String()
+
+	assert.NotContains(t, sql, "ts_headline")
+	assert.NotContains(t, sql, "search_rank")
+}
+
+func Test_SortReports_Relevance(t *testing.T) {
+	req := dto.ReportFilterRequest{SortBy: dto.REPORT_SORT_RELEVANCE}
+
+	// relevance without a search term falls back to the newest first
+	sql := reportQuery(repository.SortReports(req)).SQL.String()
+	assert.Contains(t, sql, "ORDER BY reports.created_at DESC,reports.id DESC")
+
+	req.Search = "banjir"
+	sql = reportQuery(repository.SearchReports(req), repository.SortReports(req)).SQL.String()
+	assert.Contains(t, sql, "ORDER BY search_rank DESC,reports.created_at DESC,reports.id DESC")
+}
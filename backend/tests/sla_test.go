@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_ResolveSLAPolicy(t *testing.T) {
+	fallback, roads, verified := uuid.New(), uuid.New(), uuid.New()
+	policies := []entity.SLAPolicy{
+		{ID: roads, Class: "jalan_rusak", Status: entity.StatusUnverified, DurationMinutes: 60},
+		{ID: fallback, Status: entity.StatusUnverified, DurationMinutes: 240},
+		{ID: verified, Status: entity.StatusVerified, DurationMinutes: 1440},
+	}
+
+	assert.Equal(t, roads, service.ResolveSLAPolicy(policies, "jalan_rusak", entity.StatusUnverified).ID)
+	assert.Equal(t, fallback, service.ResolveSLAPolicy(policies, "banjir", entity.StatusUnverified).ID)
+
+	// unclassified reports use the default of the status
+	assert.Equal(t, fallback, service.ResolveSLAPolicy(policies, "", entity.StatusUnverified).ID)
+	assert.Equal(t, verified, service.ResolveSLAPolicy(policies, "jalan_rusak", entity.StatusVerified).ID)
+
+	assert.Nil(t, service.ResolveSLAPolicy(policies, "jalan_rusak", entity.StatusHandled))
+}
+
+// fakeHistoryRecorder keeps the history entries written.
+type fakeHistoryRecorder struct {
+	fakeReportHistoryRepository
+	created []entity.ReportHistory
+}
+
+func (r *fakeHistoryRecorder) Create(ctx context.Context, tx *gorm.DB, history entity.ReportHistory) (entity.ReportHistory, error) {
+	r.created = append(r.created, history)
+	return history, nil
+}
+
+// fakeBreachReportRepository reports its reports as breached, once.
+type fakeBreachReportRepository struct {
+	fakeReportRepository
+}
+
+func (r *fakeBreachReportRepository) GetSLABreaches(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.Report, error) {
+	reports := r.reports
+	r.reports = nil
+	return reports, nil
+}
+
+func (r *fakeBreachReportRepository) MarkSLAEscalated(ctx context.Context, tx *gorm.DB, reportId string, at time.Time) (bool, error) {
+	return true, nil
+}
+
+type fakeSLAPolicyRepository struct {
+	repository.SLAPolicyRepository
+	policy entity.SLAPolicy
+}
+
+func (r *fakeSLAPolicyRepository) GetById(ctx context.Context, tx *gorm.DB, policyId string) (entity.SLAPolicy, error) {
+	return r.policy, nil
+}
+
+func Test_CheckBreaches_KeepsEmailsOutOfHistory(t *testing.T) {
+	policy := entity.SLAPolicy{ID: uuid.New(), SupervisorEmails: "kepala@dinas.go.id,wakil@dinas.go.id"}
+	dueAt := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	report := entity.Report{ID: uuid.New(), Status: entity.StatusVerified, SLAPolicyID: &policy.ID, SLADueAt: &dueAt}
+	historyRepo := &fakeHistoryRecorder{}
+	jobService := &fakeJobService{}
+	slaService := service.NewSLAService(
+		&fakeSLAPolicyRepository{policy: policy},
+		&fakeBreachReportRepository{fakeReportRepository{reports: []entity.Report{report}}},
+		historyRepo, nil, jobService, SetUpDryRunDatabase(),
+	)
+
+	// the dry run cannot commit, the entries are written before that
+	slaService.CheckBreaches(context.Background())
+
+	assert.Len(t, jobService.jobs, 2)
+	assert.Len(t, historyRepo.created, 1)
+	assert.Equal(t, "Melewati tenggat SLA status verified (2025-03-01 10:00), dieskalasi ke 2 penyelia", historyRepo.created[0].Note)
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Subscription_MatchesArea(t *testing.T) {
+	lat, lng, radius := -6.2, 106.816666, 1000
+	subscription := entity.Subscription{
+		Kind:         entity.SubscriptionArea,
+		Latitude:     &lat,
+		Longitude:    &lng,
+		RadiusMeters: &radius,
+	}
+
+	near := -6.205
+	far := -6.3
+	assert.True(t, subscription.Matches(entity.Report{Latitude: &near, Longitude: &lng}))
+	assert.False(t, subscription.Matches(entity.Report{Latitude: &far, Longitude: &lng}))
+	assert.True(t, subscription.Matches(entity.Report{Location: "-6.2001,106.8167"}))
+	assert.False(t, subscription.Matches(entity.Report{Location: "Jakarta"}))
+}
+
+func Test_Subscription_MatchesTagAndClass(t *testing.T) {
+	tagId := uuid.New()
+	subscription := entity.Subscription{
+		Kind:    entity.SubscriptionTag,
+		TagID:   &tagId,
+		Classes: "banjir,jalan_rusak",
+	}
+
+	assert.True(t, subscription.Matches(entity.Report{TagID: tagId, Tag: entity.Tag{Class: "banjir"}}))
+	assert.False(t, subscription.Matches(entity.Report{TagID: tagId, Tag: entity.Tag{Class: "sampah"}}))
+	assert.False(t, subscription.Matches(entity.Report{TagID: uuid.New(), Tag: entity.Tag{Class: "banjir"}}))
+}
+
+func Test_CreateSubscriptionRequest_Validate(t *testing.T) {
+	lat, lng := -6.2, 106.8
+
+	assert.ErrorIs(t, dto.CreateSubscriptionRequest{}.Validate(), dto.ErrSubscriptionTarget)
+	assert.ErrorIs(t, dto.CreateSubscriptionRequest{
+		TagID: uuid.NewString(), Latitude: &lat, Longitude: &lng, RadiusMeters: 500,
+	}.Validate(), dto.ErrSubscriptionTarget)
+	assert.ErrorIs(t, dto.CreateSubscriptionRequest{
+		Latitude: &lat, Longitude: &lng, RadiusMeters: 10,
+	}.Validate(), dto.ErrSubscriptionRadius)
+	assert.NoError(t, dto.CreateSubscriptionRequest{
+		Latitude: &lat, Longitude: &lng, RadiusMeters: 500,
+	}.Validate())
+	assert.NoError(t, dto.CreateSubscriptionRequest{TagID: uuid.NewString()}.Validate())
+
+	req := dto.CreateSubscriptionRequest{Classes: []string{" banjir", "banjir", "", "a,b"}}
+	assert.Equal(t, []string{"banjir"}, req.NormalizedClasses())
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/repository"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_TrustScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		input dto.TrustInput
+		score int
+		level entity.TrustLevel
+	}{
+		{"new unverified account", dto.TrustInput{}, 20, entity.TrustLow},
+		{"new verified account", dto.TrustInput{EmailVerified: true}, 40, entity.TrustNormal},
+		{"spammer", dto.TrustInput{EmailVerified: true, AccountAge: 90 * 24 * time.Hour, Record: dto.VoterRecord{Rejected: 18}}, 4, entity.TrustLow},
+		{"mixed record", dto.TrustInput{EmailVerified: true, AccountAge: 90 * 24 * time.Hour, Record: dto.VoterRecord{Accepted: 3, Rejected: 3}}, 60, entity.TrustNormal},
+		{"established reporter", dto.TrustInput{
+			EmailVerified: true,
+			AccountAge:    365 * 24 * time.Hour,
+			Record:        dto.VoterRecord{Accepted: 9, Upvotes: 80},
+		}, 96, entity.TrustHigh},
+	}
+
+	for _, tt := range tests {
+		score := service.TrustScore(tt.input)
+		assert.Equal(t, tt.score, score, tt.name)
+		assert.Equal(t, tt.level, service.TrustLevelFor(score), tt.name)
+	}
+}
+
+func Test_CheckQuota(t *testing.T) {
+	now := time.Now()
+	policy := service.TrustPolicyFor(entity.TrustLow)
+	recent := now.Add(-time.Minute)
+	earlier := now.Add(-time.Hour)
+
+	assert.NoError(t, service.CheckQuota(policy, dto.ReportActivity{}, now), "first report")
+	assert.Equal(t, dto.ErrReportCooldown, service.CheckQuota(policy, dto.ReportActivity{Count: 1, LastAt: &recent}, now))
+	assert.NoError(t, service.CheckQuota(policy, dto.ReportActivity{Count: 1, LastAt: &earlier}, now), "report after cool-down")
+	assert.Equal(t, dto.ErrReportQuotaExceeded,
+		service.CheckQuota(policy, dto.ReportActivity{Count: int64(policy.MaxReportsPerDay), LastAt: &earlier}, now))
+}
+
+// fakeQuotaReportRepository records the transactions the quota check locks
+// and counts in.
+type fakeQuotaReportRepository struct {
+	fakeReportRepository
+	activity dto.ReportActivity
+
+	lockedTx, countedTx *gorm.DB
+}
+
+func (r *fakeQuotaReportRepository) LockUserReports(ctx context.Context, tx *gorm.DB, userId string) error {
+	r.lockedTx = tx
+	return nil
+}
+
+func (r *fakeQuotaReportRepository) GetReportActivity(ctx context.Context, tx *gorm.DB, userId string, since time.Time) (dto.ReportActivity, error) {
+	if tx != nil {
+		r.countedTx = tx
+	}
+	return r.activity, nil
+}
+
+type fakeTrustOverrideRepository struct {
+	repository.UserTrustOverrideRepository
+}
+
+func (r *fakeTrustOverrideRepository) GetByUserId(ctx context.Context, tx *gorm.DB, userId string) (entity.UserTrustOverride, error) {
+	return entity.UserTrustOverride{}, gorm.ErrRecordNotFound
+}
+
+func Test_CheckReportQuota_CountsUnderLock(t *testing.T) {
+	user := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_USER, IsVerified: true}
+	earlier := time.Now().Add(-time.Hour)
+	reportRepo := &fakeQuotaReportRepository{activity: dto.ReportActivity{Count: 1, LastAt: &earlier}}
+	trustService := service.NewTrustService(&fakeUserRepository{users: []entity.User{user}}, reportRepo, &fakeTrustOverrideRepository{})
+	tx := SetUpDryRunDatabase()
+
+	policy, err := trustService.CheckReportQuota(context.Background(), tx, user.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, policy.HoldForReview)
+	// the count that decides is taken in the locked transaction
+	assert.Same(t, tx, reportRepo.lockedTx)
+	assert.Same(t, tx, reportRepo.countedTx)
+
+	reportRepo.activity.Count = int64(policy.MaxReportsPerDay)
+	_, err = trustService.CheckReportQuota(context.Background(), tx, user.ID.String())
+	assert.Equal(t, dto.ErrReportQuotaExceeded, err)
+}
+
+func Test_CountReportStatus_IncludesHeld(t *testing.T) {
+	reportRepo := &fakeReportRepository{reports: []entity.Report{
+		{ID: uuid.New(), Status: entity.StatusPendingReview},
+		{ID: uuid.New(), Status: entity.StatusUnverified},
+		{ID: uuid.New(), Status: entity.StatusCompleted},
+	}}
+
+	counts, err := SetupReportService(&fakeUserRepository{}, reportRepo).CountReportStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, counts.PendingReview)
+	sum := counts.PendingReview + counts.Unverified + counts.Verified + counts.Rejected + counts.Handled + counts.Completed
+	assert.Equal(t, counts.Total, sum)
+}
+
+func Test_UpdateReportStatus_HeldReports(t *testing.T) {
+	admin := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_ADMIN}
+	held := entity.Report{ID: uuid.New(), Status: entity.StatusPendingReview}
+	published := entity.Report{ID: uuid.New(), Status: entity.StatusUnverified}
+	reportService := SetupReportService(
+		&fakeUserRepository{users: []entity.User{admin}},
+		&fakeReportRepository{reports: []entity.Report{held, published}},
+	)
+	ctx := context.Background()
+
+	// nothing is moved into the hold by hand
+	_, err := reportService.UpdateReportStatus(ctx, published.ID.String(),
+		dto.UpdateStatusReportRequest{Status: entity.StatusPendingReview}, admin.ID.String())
+	assert.Equal(t, dto.ErrUpdateReportStatus, err)
+
+	// nor out of it, that is what the release endpoint is for
+	_, err = reportService.UpdateReportStatus(ctx, held.ID.String(),
+		dto.UpdateStatusReportRequest{Status: entity.StatusVerified}, admin.ID.String())
+	assert.Equal(t, dto.ErrReportHeld, err)
+}
+
+func Test_HeldReport_Hidden(t *testing.T) {
+	reporter := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_USER, IsVerified: true}
+	neighbour := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_USER, IsVerified: true}
+	admin := entity.User{ID: uuid.New(), Role: constants.ENUM_ROLE_ADMIN}
+	held := entity.Report{ID: uuid.New(), UserID: reporter.ID.String(), Status: entity.StatusPendingReview}
+	users := &fakeUserRepository{users: []entity.User{reporter, neighbour, admin}}
+	reports := &fakeReportRepository{reports: []entity.Report{held}}
+	reportService := SetupReportService(users, reports)
+	voteService := service.NewVoteService(SetupReportWorkflow(users, reports), nil, nil, config.NewVerificationConfig())
+	ctx := context.Background()
+
+	for _, viewer := range []entity.User{reporter, admin} {
+		_, err := reportService.GetReportById(ctx, held.ID.String(), viewer.ID.String())
+		assert.NoError(t, err, viewer.Role)
+	}
+	_, err := reportService.GetReportById(ctx, held.ID.String(), neighbour.ID.String())
+	assert.Equal(t, dto.ErrGetReportById, err)
+	_, err = voteService.GetVotes(ctx, held.ID.String(), neighbour.ID.String())
+	assert.Equal(t, dto.ErrGetReportById, err)
+	_, err = reportService.GetReportHistory(ctx, held.ID.String(), neighbour.ID.String())
+	assert.Equal(t, dto.ErrGetReportById, err)
+
+	commentService := service.NewCommentService(nil, reports, users, nil)
+	_, err = commentService.GetComments(ctx, held.ID.String(), dto.PaginationRequest{}, neighbour.ID.String(), nil)
+	assert.Equal(t, dto.ErrGetReportById, err)
+	_, err = commentService.CreateComment(ctx, held.ID.String(), dto.CreateCommentRequest{Body: "Sudah lama begini"}, neighbour.ID.String(), nil)
+	assert.Equal(t, dto.ErrGetReportById, err)
+
+	_, err = voteService.UpvoteReport(ctx, held.ID.String(), neighbour.ID.String())
+	assert.Equal(t, dto.ErrReportHeld, err)
+	vote := dto.VoteReportRequest{Kind: string(entity.VoteConfirm)}
+	_, err = voteService.VoteReport(ctx, held.ID.String(), vote, neighbour.ID.String())
+	assert.Equal(t, dto.ErrReportHeld, err)
+}
+
+func Test_ReleaseReport_NotHeld(t *testing.T) {
+	published := entity.Report{ID: uuid.New(), Status: entity.StatusUnverified}
+	heldReportService := service.NewHeldReportService(SetupReportWorkflow(
+		&fakeUserRepository{},
+		&fakeReportRepository{reports: []entity.Report{published}},
+	))
+
+	_, err := heldReportService.ReleaseReport(context.Background(), published.ID.String(), uuid.NewString())
+	assert.Equal(t, dto.ErrReportNotHeld, err)
+}
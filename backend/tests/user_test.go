@@ -28,7 +28,8 @@ func SetupControllerUser() controller.UserController {
 		jwtService     = service.NewJWTService()
 		refreshTokenRepo = repository.NewRefreshTokenRepository(db)
 		userService    = service.NewUserService(userRepo, refreshTokenRepo, jwtService, db)
-		userController = controller.NewUserController(userService)
+		reputationService = service.NewReputationService(repository.NewReputationRepository(db))
+		userController = controller.NewUserController(userService, reputationService)
 	)
 
 	return userController
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/dto"
+	"github.com/Caknoooo/go-gin-clean-starter/entity"
+	"github.com/Caknoooo/go-gin-clean-starter/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VoteWeight(t *testing.T) {
+	fullAge := 180 * 24 * time.Hour
+	tests := []struct {
+		name   string
+		age    time.Duration
+		record dto.VoterRecord
+		want   float64
+	}{
+		{"new account without record", 0, dto.VoterRecord{}, 0.5},
+		{"mature account without record", 2 * fullAge, dto.VoterRecord{}, 1},
+		{"half mature account", fullAge / 2, dto.VoterRecord{}, 0.75},
+		{"accurate reporter", fullAge, dto.VoterRecord{Accepted: 8}, 1.8},
+		{"inaccurate reporter", fullAge, dto.VoterRecord{Rejected: 8}, 0.2},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, service.VoteWeight(tt.age, tt.record, fullAge), 1e-9, tt.name)
+	}
+}
+
+func Test_CommunityVerdict(t *testing.T) {
+	votes := []entity.ReportVote{
+		{Kind: entity.VoteConfirm, Weight: 1.5},
+		{Kind: entity.VoteConfirm, Weight: 2},
+		{Kind: entity.VoteDispute, Weight: 1},
+	}
+	tally := service.TallyVotes(votes)
+	assert.Equal(t, 2, tally.ConfirmCount)
+	assert.Equal(t, 1, tally.DisputeCount)
+	assert.Equal(t, 3.5, tally.ConfirmWeight)
+	assert.Equal(t, 1.0, tally.DisputeWeight)
+
+	// a quorum of 5 is not reached with a weight of 4.5
+	reached, _ := service.CommunityVerdict(tally, 5, 0.7)
+	assert.False(t, reached)
+
+	reached, confirmed := service.CommunityVerdict(tally, 4, 0.7)
+	assert.True(t, reached)
+	assert.True(t, confirmed)
+
+	reached, confirmed = service.CommunityVerdict(tally, 4, 0.8)
+	assert.True(t, reached)
+	assert.False(t, confirmed)
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+type EmailTemplate string
+
+const (
+	EmailVerification  EmailTemplate = "verification"
+	EmailPasswordReset EmailTemplate = "password_reset"
+	EmailReportStatus  EmailTemplate = "report_status"
+	// EmailSubscriptionAlert is sent for reports matching a subscription.
+	EmailSubscriptionAlert EmailTemplate = "subscription_alert"
+	// EmailSLAEscalation goes to supervisors when a report breaches its SLA.
+	EmailSLAEscalation EmailTemplate = "sla_escalation"
+)
+
+//go:embed email-template/*.html email-template/*.txt
+var emailTemplateFS embed.FS
+
+// emailSubjects are text templates rendered with the same data as the body.
+var emailSubjects = map[EmailTemplate]string{
+	EmailVerification:      "Verifikasi akun Anda",
+	EmailPasswordReset:     "Atur ulang kata sandi Anda",
+	EmailReportStatus:      "{{ .Title }}",
+	EmailSubscriptionAlert: "{{ .Title }}",
+	EmailSLAEscalation:     "Eskalasi SLA: laporan {{ .Class }} melewati tenggat",
+}
+
+var (
+	emailHTML = htmltemplate.Must(htmltemplate.ParseFS(emailTemplateFS, "email-template/*.html"))
+	emailText = texttemplate.Must(texttemplate.ParseFS(emailTemplateFS, "email-template/*.txt"))
+)
+
+// RenderEmail renders the subject, HTML and plain-text variants of a
+// registered template. The caller fills in Mail.To.
+func RenderEmail(name EmailTemplate, data any) (Mail, error) {
+	subject, ok := emailSubjects[name]
+	if !ok {
+		return Mail{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var mail Mail
+	var buf bytes.Buffer
+
+	subjectTmpl, err := texttemplate.New("subject").Parse(subject)
+	if err != nil {
+		return Mail{}, err
+	}
+	if err := subjectTmpl.Execute(&buf, data); err != nil {
+		return Mail{}, err
+	}
+	mail.Subject = buf.String()
+
+	buf.Reset()
+	if err := emailHTML.ExecuteTemplate(&buf, string(name)+".html", data); err != nil {
+		return Mail{}, err
+	}
+	mail.HTML = buf.String()
+
+	buf.Reset()
+	if err := emailText.ExecuteTemplate(&buf, string(name)+".txt", data); err != nil {
+		return Mail{}, err
+	}
+	mail.Text = buf.String()
+
+	return mail, nil
+}
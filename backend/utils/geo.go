@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const earthRadiusMeters = 6371000.0
+
+// ParseLatLng reads a "lat,lng" pair, which is how older clients put
+// coordinates into the free-text location field.
+func ParseLatLng(s string) (float64, float64, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	if !ValidLatLng(lat, lng) {
+		return 0, 0, false
+	}
+	return lat, lng, true
+}
+
+func ValidLatLng(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// DistanceMeters is the haversine distance between two points.
+func DistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
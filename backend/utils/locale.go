@@ -0,0 +1,23 @@
+package utils
+
+import "strings"
+
+const (
+	LANG_ID = "id"
+	LANG_EN = "en"
+)
+
+// PreferredLanguage picks the first supported language of an
+// Accept-Language header, defaulting to Indonesian.
+func PreferredLanguage(acceptLanguage string) string {
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag := strings.ToLower(strings.TrimSpace(strings.SplitN(part, ";", 2)[0]))
+		switch strings.SplitN(tag, "-", 2)[0] {
+		case LANG_ID:
+			return LANG_ID
+		case LANG_EN:
+			return LANG_EN
+		}
+	}
+	return LANG_ID
+}
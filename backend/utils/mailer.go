@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"gopkg.in/gomail.v2"
+)
+
+type (
+	// Mail is a rendered message. Text is the plain-text alternative and may
+	// be empty for HTML-only mail.
+	Mail struct {
+		To      string
+		Subject string
+		HTML    string
+		Text    string
+	}
+
+	Mailer interface {
+		Send(ctx context.Context, mail Mail) error
+	}
+
+	smtpMailer struct {
+		from   string
+		dialer *gomail.Dialer
+	}
+
+	fileMailer struct {
+		from string
+		dir  string
+	}
+
+	// MemoryMailer keeps every message it is given, for tests and for
+	// running locally without an SMTP server.
+	MemoryMailer struct {
+		mu    sync.Mutex
+		mails []Mail
+	}
+)
+
+var (
+	defaultMailer     Mailer
+	defaultMailerErr  error
+	defaultMailerOnce sync.Once
+)
+
+// NewMailer builds the Mailer selected by cfg.Driver.
+func NewMailer(cfg *config.EmailConfig) Mailer {
+	switch cfg.Driver {
+	case config.MAIL_DRIVER_FILE:
+		return &fileMailer{from: cfg.From(), dir: cfg.Dir}
+	case config.MAIL_DRIVER_MEMORY:
+		return &MemoryMailer{}
+	default:
+		return &smtpMailer{
+			from:   cfg.From(),
+			dialer: gomail.NewDialer(cfg.Host, cfg.Port, cfg.AuthEmail, cfg.AuthPassword),
+		}
+	}
+}
+
+// DefaultMailer returns the process-wide Mailer, built from the environment
+// on first use. A broken mail configuration is an error, except when running
+// on localhost, where mail is captured in memory instead.
+func DefaultMailer() (Mailer, error) {
+	defaultMailerOnce.Do(func() {
+		if defaultMailer != nil {
+			return
+		}
+		cfg, err := config.NewEmailConfig()
+		if err != nil {
+			if os.Getenv("APP_ENV") != "localhost" {
+				defaultMailerErr = fmt.Errorf("mail config: %w", err)
+				return
+			}
+			log.Printf("mail config: %v, capturing mail in memory", err)
+			defaultMailer = &MemoryMailer{}
+			return
+		}
+		defaultMailer = NewMailer(cfg)
+	})
+	return defaultMailer, defaultMailerErr
+}
+
+// SetDefaultMailer replaces the process-wide Mailer, e.g. with a
+// MemoryMailer in tests. It must be called before the first DefaultMailer.
+func SetDefaultMailer(mailer Mailer) {
+	defaultMailer = mailer
+}
+
+func newMessage(from string, mail Mail) *gomail.Message {
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", from)
+	msg.SetHeader("To", mail.To)
+	msg.SetHeader("Subject", mail.Subject)
+
+	switch {
+	case mail.Text != "" && mail.HTML != "":
+		msg.SetBody("text/plain", mail.Text)
+		msg.AddAlternative("text/html", mail.HTML)
+	case mail.HTML != "":
+		msg.SetBody("text/html", mail.HTML)
+	default:
+		msg.SetBody("text/plain", mail.Text)
+	}
+
+	return msg
+}
+
+func (m *smtpMailer) Send(_ context.Context, mail Mail) error {
+	return m.dialer.DialAndSend(newMessage(m.from, mail))
+}
+
+// Send writes the message as an .eml file that any mail client can open.
+func (m *fileMailer) Send(_ context.Context, mail Mail) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(mail.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), recipient)
+
+	f, err := os.Create(filepath.Join(m.dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = newMessage(m.from, mail).WriteTo(f)
+	return err
+}
+
+func (m *MemoryMailer) Send(_ context.Context, mail Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
+// Sent returns a copy of every message sent so far.
+func (m *MemoryMailer) Sent() []Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Mail(nil), m.mails...)
+}
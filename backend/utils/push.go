@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/Caknoooo/go-gin-clean-starter/config"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// ErrInvalidPushToken means the provider will never deliver to the token
+// again, so it should be forgotten.
+var ErrInvalidPushToken = errors.New("invalid push token")
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+type (
+	PushMessage struct {
+		Title string
+		Body  string
+		// Data is delivered to the app alongside the notification, e.g. the
+		// report to open when it is tapped.
+		Data map[string]string
+	}
+
+	PushSender interface {
+		Send(ctx context.Context, token string, msg PushMessage) error
+	}
+
+	fcmSender struct {
+		url    string
+		client *http.Client
+	}
+
+	logPushSender struct{}
+)
+
+// NewPushSender builds the PushSender selected by cfg.Driver.
+func NewPushSender(ctx context.Context, cfg config.PushConfig) (PushSender, error) {
+	if cfg.Driver != config.PUSH_DRIVER_FCM {
+		return logPushSender{}, nil
+	}
+
+	if cfg.ProjectID == "" {
+		return nil, errors.New("push: FCM_PROJECT_ID is not set")
+	}
+
+	var creds *google.Credentials
+	var err error
+	if cfg.CredentialsFile != "" {
+		var raw []byte
+		raw, err = os.ReadFile(cfg.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		creds, err = google.CredentialsFromJSON(ctx, raw, fcmScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, fcmScope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("push: load credentials: %w", err)
+	}
+
+	return NewFCMSender(
+		fmt.Sprintf("https://fcm.googleapis.com/v1/projects/%s/messages:send", cfg.ProjectID),
+		oauth2.NewClient(ctx, creds.TokenSource),
+	), nil
+}
+
+// NewFCMSender sends through the FCM HTTP v1 endpoint at url. The client
+// must add the credentials.
+func NewFCMSender(url string, client *http.Client) PushSender {
+	return &fcmSender{
+		url:    url,
+		client: client,
+	}
+}
+
+// Send delivers one message through the FCM HTTP v1 API.
+func (s *fcmSender) Send(ctx context.Context, token string, msg PushMessage) error {
+	body, err := json.Marshal(map[string]any{
+		"message": map[string]any{
+			"token": token,
+			"notification": map[string]string{
+				"title": msg.Title,
+				"body":  msg.Body,
+			},
+			"data": msg.Data,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	raw, _ := io.ReadAll(io.LimitReader(res.Body, 64<<10))
+	if isInvalidFCMToken(res.StatusCode, raw) {
+		return ErrInvalidPushToken
+	}
+	return fmt.Errorf("fcm: %s: %s", res.Status, raw)
+}
+
+// isInvalidFCMToken recognises the errors FCM documents for tokens that
+// are expired, unregistered or malformed.
+func isInvalidFCMToken(status int, body []byte) bool {
+	var fcmErr struct {
+		Error struct {
+			Status  string `json:"status"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &fcmErr); err != nil {
+		return false
+	}
+
+	for _, detail := range fcmErr.Error.Details {
+		switch detail.ErrorCode {
+		case "UNREGISTERED":
+			return true
+		case "INVALID_ARGUMENT":
+			// the token is the only argument clients control
+			return status == http.StatusBadRequest
+		}
+	}
+
+	return status == http.StatusNotFound && fcmErr.Error.Status == "NOT_FOUND"
+}
+
+func (logPushSender) Send(_ context.Context, token string, msg PushMessage) error {
+	log.Printf("push to %s: %s: %s %v", shortToken(token), msg.Title, msg.Body, msg.Data)
+	return nil
+}
+
+// shortToken keeps enough of a device token to tell devices apart in the
+// logs without making it usable.
+func shortToken(token string) string {
+	if len(token) <= 8 {
+		return token
+	}
+	return token[:8] + "..."
+}
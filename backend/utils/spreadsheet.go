@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	SPREADSHEET_CSV  = "csv"
+	SPREADSHEET_XLSX = "xlsx"
+)
+
+// RowWriter writes tabular rows to CSV or XLSX. Close must be called to
+// finish the file; for XLSX nothing reaches the writer before Close.
+type RowWriter interface {
+	Write(row []any) error
+	Close() error
+}
+
+func NewRowWriter(format string, w io.Writer) (RowWriter, error) {
+	switch format {
+	case SPREADSHEET_CSV:
+		return &csvRowWriter{writer: csv.NewWriter(w)}, nil
+	case SPREADSHEET_XLSX:
+		return newXLSXRowWriter(w)
+	}
+	return nil, fmt.Errorf("unsupported spreadsheet format %q", format)
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvRowWriter) Write(row []any) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = EscapeSpreadsheetCell(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return c.writer.Write(record)
+}
+
+// EscapeSpreadsheetCell keeps text that spreadsheet programs would read as
+// a formula from being evaluated when a CSV file is opened, by prefixing
+// it with a quote. Numbers are written as they are, so only text needs it.
+func EscapeSpreadsheetCell(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
+
+func (c *csvRowWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// xlsxRowWriter uses the excelize stream writer, which spills rows to a
+// temporary file instead of keeping the sheet in memory.
+type xlsxRowWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+func newXLSXRowWriter(w io.Writer) (*xlsxRowWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxRowWriter{file: file, stream: stream, out: w}, nil
+}
+
+func (x *xlsxRowWriter) Write(row []any) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, row)
+}
+
+func (x *xlsxRowWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.out)
+	return err
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/Caknoooo/go-gin-clean-starter/constants"
+)
+
+// JakartaLocation falls back to a fixed WIB offset when the host has no
+// tzdata (e.g. minimal container images).
+func JakartaLocation() *time.Location {
+	loc, err := time.LoadLocation(constants.TIMEZONE)
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
@@ -4,6 +4,7 @@ import '../report/create_report_screen.dart';
 import '../auth/login_screen.dart';
 import '../../services/auth_service.dart';
 import '../../services/report_service.dart';
+import '../../services/notification_service.dart';
 
 class HomeScreen extends StatefulWidget {
   final String token;
@@ -18,6 +19,7 @@ class _HomeScreenState extends State<HomeScreen> with TickerProviderStateMixin {
   late TabController _tabController;
   int _selectedIndex = 0;
   final ReportService _reportService = ReportService();
+  final NotificationService _notificationService = NotificationService();
 
   // Data state
   List<Map<String, dynamic>> _allReports = [];
@@ -25,6 +27,7 @@ class _HomeScreenState extends State<HomeScreen> with TickerProviderStateMixin {
   bool _isLoading = false;
   bool _isLoadingMore = false;
   String? _errorMessage;
+  int _unreadNotifications = 0;
 
   // Pagination state
   int _currentPage = 1;
@@ -46,6 +49,7 @@ class _HomeScreenState extends State<HomeScreen> with TickerProviderStateMixin {
     super.initState();
     _tabController = TabController(length: 3, vsync: this);
     _loadReports();
+    _loadUnreadCount();
 
     // Setup scroll listeners for infinite scroll
     _scrollController.addListener(_onScroll);
@@ -259,7 +263,15 @@ class _HomeScreenState extends State<HomeScreen> with TickerProviderStateMixin {
 
   // Refresh reports
   Future<void> _refreshReports() async {
-    await _loadReports(isRefresh: true);
+    await Future.wait([_loadReports(isRefresh: true), _loadUnreadCount()]);
+  }
+
+  // Load unread notification count for the nav badge
+  Future<void> _loadUnreadCount() async {
+    final result = await _notificationService.getUnreadCount();
+    if (mounted && result.isSuccess) {
+      setState(() => _unreadNotifications = result.data ?? 0);
+    }
   }
 
   // Search functionality
@@ -450,23 +462,37 @@ class _HomeScreenState extends State<HomeScreen> with TickerProviderStateMixin {
         selectedItemColor: AppColors.primary,
         unselectedItemColor: AppColors.accent,
         elevation: 8,
-        items: const [
-          BottomNavigationBarItem(
+        items: [
+          const BottomNavigationBarItem(
             icon: Icon(Icons.home_outlined),
             activeIcon: Icon(Icons.home),
             label: 'Home',
           ),
-          BottomNavigationBarItem(
+          const BottomNavigationBarItem(
             icon: Icon(Icons.search_outlined),
             activeIcon: Icon(Icons.search),
             label: 'Search',
           ),
           BottomNavigationBarItem(
-            icon: Icon(Icons.notifications_outlined),
-            activeIcon: Icon(Icons.notifications),
+            icon: Badge(
+              isLabelVisible: _unreadNotifications > 0,
+              label: Text(
+                _unreadNotifications > 99 ? '99+' : '$_unreadNotifications',
+              ),
+              backgroundColor: AppColors.error,
+              child: const Icon(Icons.notifications_outlined),
+            ),
+            activeIcon: Badge(
+              isLabelVisible: _unreadNotifications > 0,
+              label: Text(
+                _unreadNotifications > 99 ? '99+' : '$_unreadNotifications',
+              ),
+              backgroundColor: AppColors.error,
+              child: const Icon(Icons.notifications),
+            ),
             label: 'Notifications',
           ),
-          BottomNavigationBarItem(
+          const BottomNavigationBarItem(
             icon: Icon(Icons.person_outline),
             activeIcon: Icon(Icons.person),
             label: 'Profile',
@@ -0,0 +1,178 @@
+import 'dart:convert';
+import 'dart:io';
+import 'package:http/http.dart' as http;
+import '../utils/constants.dart';
+import '../models/api_response.dart';
+import 'token_service.dart';
+
+class NotificationService {
+  static const String baseUrl = '${AppConstants.apiBaseUrl}/notifications';
+
+  // Singleton pattern for NotificationService
+  static final NotificationService _instance = NotificationService._internal();
+  factory NotificationService() => _instance;
+  NotificationService._internal();
+
+  // Get number of unread notifications
+  Future<ApiResponse<int>> getUnreadCount() async {
+    try {
+      final token = await TokenService.getToken();
+      if (token == null) {
+        return ApiResponse.error('User not authenticated');
+      }
+
+      final response = await http
+          .get(
+            Uri.parse('$baseUrl/unread_count'),
+            headers: {
+              'Content-Type': 'application/json',
+              'Accept': 'application/json',
+              'Authorization': 'Bearer $token',
+            },
+          )
+          .timeout(const Duration(seconds: 30));
+
+      if (response.body.isEmpty) {
+        return ApiResponse.error('Server returned empty response');
+      }
+
+      final responseData = json.decode(response.body) as Map<String, dynamic>;
+
+      if (response.statusCode == 200) {
+        final data = responseData['data'] as Map<String, dynamic>? ?? {};
+        return ApiResponse.success((data['unread'] as num?)?.toInt() ?? 0);
+      } else {
+        final errorMessage =
+            responseData['message'] ??
+            responseData['error'] ??
+            'Failed to fetch unread notifications';
+        return ApiResponse.error(errorMessage);
+      }
+    } on SocketException {
+      return ApiResponse.error(
+        'Unable to connect to server. Please check your internet connection.',
+      );
+    } on FormatException catch (e) {
+      return ApiResponse.error('Invalid response format: ${e.message}');
+    } on http.ClientException catch (e) {
+      return ApiResponse.error('Network error: ${e.message}');
+    } catch (e) {
+      print('Fetch unread count error: $e');
+      return ApiResponse.error(
+        'Failed to fetch unread notifications: ${e.toString()}',
+      );
+    }
+  }
+
+  // Get notifications with pagination
+  Future<ApiResponse<Map<String, dynamic>>> getNotifications({
+    int page = 1,
+    int perPage = 20,
+    bool unreadOnly = false,
+  }) async {
+    try {
+      final token = await TokenService.getToken();
+      if (token == null) {
+        return ApiResponse.error('User not authenticated');
+      }
+
+      final queryParams = {
+        'page': page.toString(),
+        'per_page': perPage.toString(),
+        if (unreadOnly) 'unread_only': 'true',
+      };
+
+      final uri = Uri.parse(baseUrl).replace(queryParameters: queryParams);
+
+      final response = await http
+          .get(
+            uri,
+            headers: {
+              'Content-Type': 'application/json',
+              'Accept': 'application/json',
+              'Authorization': 'Bearer $token',
+            },
+          )
+          .timeout(const Duration(seconds: 30));
+
+      if (response.body.isEmpty) {
+        return ApiResponse.error('Server returned empty response');
+      }
+
+      final responseData = json.decode(response.body) as Map<String, dynamic>;
+
+      if (response.statusCode == 200) {
+        return ApiResponse.success(responseData);
+      } else {
+        final errorMessage =
+            responseData['message'] ??
+            responseData['error'] ??
+            'Failed to fetch notifications';
+        return ApiResponse.error(errorMessage);
+      }
+    } on SocketException {
+      return ApiResponse.error(
+        'Unable to connect to server. Please check your internet connection.',
+      );
+    } on FormatException catch (e) {
+      return ApiResponse.error('Invalid response format: ${e.message}');
+    } on http.ClientException catch (e) {
+      return ApiResponse.error('Network error: ${e.message}');
+    } catch (e) {
+      print('Fetch notifications error: $e');
+      return ApiResponse.error(
+        'Failed to fetch notifications: ${e.toString()}',
+      );
+    }
+  }
+
+  // Mark every notification as read
+  Future<ApiResponse<Map<String, dynamic>>> markAllRead() async {
+    try {
+      final token = await TokenService.getToken();
+      if (token == null) {
+        return ApiResponse.error('User not authenticated');
+      }
+
+      final response = await http
+          .post(
+            Uri.parse('$baseUrl/read_all'),
+            headers: {
+              'Content-Type': 'application/json',
+              'Accept': 'application/json',
+              'Authorization': 'Bearer $token',
+            },
+          )
+          .timeout(const Duration(seconds: 30));
+
+      if (response.body.isEmpty) {
+        return ApiResponse.error('Server returned empty response');
+      }
+
+      final responseData = json.decode(response.body) as Map<String, dynamic>;
+
+      if (response.statusCode == 200) {
+        return ApiResponse.success(responseData);
+      } else {
+        final errorMessage =
+            responseData['message'] ??
+            responseData['error'] ??
+            'Failed to mark notifications as read';
+        return ApiResponse.error(errorMessage);
+      }
+    } on SocketException {
+      return ApiResponse.error(
+        'Unable to connect to server. Please check your internet connection.',
+      );
+    } on FormatException catch (e) {
+      return ApiResponse.error('Invalid response format: ${e.message}');
+    } on http.ClientException catch (e) {
+      return ApiResponse.error('Network error: ${e.message}');
+    } catch (e) {
+      print('Mark notifications read error: $e');
+      return ApiResponse.error(
+        'Failed to mark notifications as read: ${e.toString()}',
+      );
+    }
+  }
+}